@@ -7,7 +7,9 @@ import (
 )
 
 type pluginCmd struct {
-	Version string
+	Version    string
+	Index      string
+	PublicKeys []string
 }
 
 func newPluginCmd(f factory.Factory) *cobra.Command {
@@ -22,6 +24,8 @@ func newPluginCmd(f factory.Factory) *cobra.Command {
 Adds a new plugin to devspace
 
 devspace add plugin https://github.com/my-plugin/plugin
+devspace add plugin my-plugin --index https://my-index.com/plugins.yaml --version ">=1.0.0 <2.0.0"
+devspace add plugin my-plugin --public-key ./my-plugin.pub
 #######################################################
 	`,
 		Args: cobra.ExactArgs(1),
@@ -30,14 +34,19 @@ devspace add plugin https://github.com/my-plugin/plugin
 			return cmd.Run(f, args)
 		}}
 
-	pluginCmd.Flags().StringVar(&cmd.Version, "version", "", "The git tag to use")
+	pluginCmd.Flags().StringVar(&cmd.Version, "version", "", "The git tag to use or the version constraint if the plugin is installed from a plugin index")
+	pluginCmd.Flags().StringVar(&cmd.Index, "index", "", "The path or https url of the plugin index to install the plugin by its name (defaults to $"+plugin.IndexEnv+")")
+	pluginCmd.Flags().StringSliceVar(&cmd.PublicKeys, "public-key", []string{}, "A trusted ed25519 public key or key file the plugin binary has to be signed with")
 	return pluginCmd
 }
 
 // Run executes the command logic
 func (cmd *pluginCmd) Run(f factory.Factory, args []string) error {
 	f.GetLog().Info("Installing plugin " + args[0])
-	addedPlugin, err := f.NewPluginManager(f.GetLog()).Add(args[0], cmd.Version)
+	addedPlugin, err := f.NewPluginManager(f.GetLog()).Add(args[0], cmd.Version, &plugin.InstallOptions{
+		Index:      cmd.Index,
+		PublicKeys: cmd.PublicKeys,
+	})
 	if err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/ptr"
@@ -23,6 +24,9 @@ type AttachCmd struct {
 	Container     string
 	Pod           string
 	Pick          bool
+
+	Debug      bool
+	DebugImage string
 }
 
 // NewAttachCmd creates a new attach command
@@ -42,6 +46,7 @@ devspace attach
 devspace attach --pick # Select pod to enter
 devspace attach -c my-container
 devspace attach -n my-namespace
+devspace attach --debug # Attach to a new ephemeral debug container
 #######################################################`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
@@ -56,6 +61,8 @@ devspace attach -n my-namespace
 	attachCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 
 	attachCmd.Flags().BoolVar(&cmd.Pick, "pick", true, "Select a pod")
+	attachCmd.Flags().BoolVar(&cmd.Debug, "debug", false, "Attach to a new ephemeral debug container that shares the process namespace of the selected container")
+	attachCmd.Flags().StringVar(&cmd.DebugImage, "debug-image", services.DefaultDebugImage, "The image to use for the ephemeral debug container")
 
 	return attachCmd
 }
@@ -113,5 +120,10 @@ func (cmd *AttachCmd) Run(f factory.Factory, cobraCmd *cobra.Command, args []str
 	options.Wait = ptr.Bool(false)
 
 	// Start attach
-	return f.NewServicesClient(nil, nil, client, log).StartAttach(options, make(chan error))
+	debugImage := ""
+	if cmd.Debug {
+		debugImage = cmd.DebugImage
+	}
+
+	return f.NewServicesClient(nil, nil, client, log).StartAttach(options, debugImage, make(chan error))
 }
@@ -26,11 +26,12 @@ type BuildCmd struct {
 
 	Tags []string
 
-	SkipPush                bool
-	SkipPushLocalKubernetes bool
-	VerboseDependencies     bool
-	SkipDependency          []string
-	Dependency              []string
+	SkipPush                  bool
+	SkipPushLocalKubernetes   bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
+	SkipDependency            []string
+	Dependency                []string
 
 	ForceBuild          bool
 	BuildSequential     bool
@@ -63,6 +64,7 @@ Builds all defined images and pushes them
 
 	buildCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", true, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 	buildCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", true, "Builds the dependencies verbosely")
+	buildCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", 1, "Builds up to this many independent dependencies in parallel")
 
 	buildCmd.Flags().StringSliceVarP(&cmd.Tags, "tag", "t", []string{}, "Use the given tag for all built images")
 	buildCmd.Flags().StringSliceVar(&cmd.SkipDependency, "skip-dependency", []string{}, "Skips building the following dependencies")
@@ -138,6 +140,7 @@ func (cmd *BuildCmd) runCommand(f factory.Factory, client kubectl.Client, config
 		SkipDependencies:        cmd.SkipDependency,
 		ForceDeployDependencies: cmd.ForceDependencies,
 		Verbose:                 cmd.VerboseDependencies,
+		MaxConcurrency:          cmd.MaxConcurrentDependencies,
 
 		BuildOptions: build.Options{
 			SkipPush:                  cmd.SkipPush,
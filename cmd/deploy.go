@@ -36,11 +36,12 @@ type DeployCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 
-	ForceDeploy         bool
-	SkipDeploy          bool
-	Deployments         string
-	ForceDependencies   bool
-	VerboseDependencies bool
+	ForceDeploy               bool
+	SkipDeploy                bool
+	Deployments               string
+	ForceDependencies         bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
 
 	SkipPush                bool
 	SkipPushLocalKubernetes bool
@@ -83,6 +84,7 @@ devspace deploy --kube-context=deploy-context
 	}
 
 	deployCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", true, "Deploys the dependencies verbosely")
+	deployCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", 1, "Deploys up to this many independent dependencies in parallel")
 	deployCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", true, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 
 	deployCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
@@ -196,6 +198,7 @@ func (cmd *DeployCmd) runCommand(f factory.Factory, client kubectl.Client, confi
 		SkipDeploy:              cmd.SkipDeploy,
 		ForceDeploy:             cmd.ForceDeploy,
 		Verbose:                 cmd.VerboseDependencies,
+		MaxConcurrency:          cmd.MaxConcurrentDependencies,
 
 		BuildOptions: build.Options{
 			SkipPush:                  cmd.SkipPush,
@@ -49,10 +49,11 @@ import (
 type DevCmd struct {
 	*flags.GlobalFlags
 
-	SkipPush                bool
-	SkipPushLocalKubernetes bool
-	VerboseDependencies     bool
-	Open                    bool
+	SkipPush                  bool
+	SkipPushLocalKubernetes   bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
+	Open                      bool
 
 	Dependency     []string
 	SkipDependency []string
@@ -70,6 +71,7 @@ type DevCmd struct {
 	ExitAfterDeploy bool
 	SkipPipeline    bool
 	Portforwarding  bool
+	SSH             bool
 	VerboseSync     bool
 	PrintSyncLog    bool
 
@@ -129,6 +131,7 @@ Open terminal instead of logs:
 	devCmd.Flags().StringSliceVar(&cmd.SkipDependency, "skip-dependency", []string{}, "Skips the following dependencies for deployment")
 	devCmd.Flags().StringSliceVar(&cmd.Dependency, "dependency", []string{}, "Deploys only the specified named dependencies")
 	devCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", true, "Deploys the dependencies verbosely")
+	devCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", 1, "Deploys up to this many independent dependencies in parallel")
 	devCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", true, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 
 	devCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
@@ -151,6 +154,7 @@ Open terminal instead of logs:
 	devCmd.Flags().BoolVar(&cmd.PrintSyncLog, "print-sync", false, "If enabled will print the sync log to the terminal")
 
 	devCmd.Flags().BoolVar(&cmd.Portforwarding, "portforwarding", true, "Enable port forwarding")
+	devCmd.Flags().BoolVar(&cmd.SSH, "ssh", true, "Enable the ssh server if configured in dev.ssh")
 
 	devCmd.Flags().BoolVar(&cmd.ExitAfterDeploy, "exit-after-deploy", false, "Exits the command after building the images and deploying the project")
 	devCmd.Flags().BoolVarP(&cmd.Interactive, "interactive", "i", false, "DEPRECATED: DO NOT USE ANYMORE")
@@ -322,6 +326,7 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 			SkipBuild:               cmd.SkipBuild,
 			ForceDeploy:             cmd.ForceDeploy,
 			Verbose:                 cmd.VerboseDependencies,
+			MaxConcurrency:          cmd.MaxConcurrentDependencies,
 			Dependencies:            cmd.Dependency,
 			SkipDependencies:        cmd.SkipDependency,
 
@@ -427,6 +432,12 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 		if err != nil {
 			return 0, errors.Wrap(err, "update last kube context")
 		}
+	} else {
+		// no dependency is deployed, so referenced dependency outputs cannot be resolved
+		err = dependency.FillOutputs(config, nil)
+		if err != nil {
+			return 0, err
+		}
 	}
 
 	pluginErr := hook.ExecuteHooks(client, configInterface, dependencies, nil, cmd.log, "dev.afterPipeline", "devCommand:after:runPipeline")
@@ -516,6 +527,16 @@ func (cmd *DevCmd) startServices(f factory.Factory, configInterface config.Confi
 		cmd.Sync = false
 	}
 
+	// Start ssh server if configured
+	if cmd.SSH {
+		err := servicesClient.StartSSH(cmd.Interrupt)
+		if err != nil {
+			return 0, errors.Wrap(err, "start ssh")
+		}
+
+		cmd.SSH = false
+	}
+
 	// Start watcher if we have at least one auto reload path and if we should not skip the pipeline
 	if !cmd.SkipPipeline && len(autoReloadPaths) > 0 {
 		var once sync.Once
@@ -609,7 +630,7 @@ func (cmd *DevCmd) startOutput(configInterface config.Config, dependencies []typ
 			cmd.log.Info("Terminal: Waiting for containers to start...")
 			selectorOptions.ImageSelector = imageSelectors
 			stdout, stderr, stdin := defaultStdStreams(cmd.Stdout, cmd.Stderr, cmd.Stdin)
-			code, err := servicesClient.StartTerminal(selectorOptions, args, cmd.WorkingDirectory, exitChan, true, cmd.TerminalReconnect, stdout, stderr, stdin)
+			code, err := servicesClient.StartTerminal(selectorOptions, args, cmd.WorkingDirectory, "", "", exitChan, true, cmd.TerminalReconnect, stdout, stderr, stdin)
 			if services.IsUnexpectedExitCode(code) {
 				cmd.log.Warnf("Command terminated with exit code %d", code)
 			}
@@ -7,6 +7,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/util/exit"
 	"github.com/loft-sh/devspace/pkg/util/factory"
@@ -26,6 +27,9 @@ type EnterCmd struct {
 	Pick          bool
 	Wait          bool
 	Reconnect     bool
+	Session       string
+	Debug         bool
+	DebugImage    string
 
 	WorkingDirectory string
 
@@ -57,6 +61,8 @@ devspace enter bash -n my-namespace
 devspace enter bash -l release=test
 devspace enter bash --image-selector nginx:latest
 devspace enter bash --image-selector "image(app):tag(app)"
+devspace enter --session my-session # Reattach to a persistent session
+devspace enter --debug # Open a shell in a new ephemeral debug container
 #######################################################`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
@@ -70,10 +76,13 @@ devspace enter bash --image-selector "image(app):tag(app)"
 	enterCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	enterCmd.Flags().StringVar(&cmd.ImageSelector, "image-selector", "", "The image to search a pod for (e.g. nginx, nginx:latest, image(app), nginx:tag(app))")
 	enterCmd.Flags().StringVar(&cmd.WorkingDirectory, "workdir", "", "The working directory where to open the terminal or execute the command")
+	enterCmd.Flags().StringVar(&cmd.Session, "session", "", "The name of a persistent terminal session to create or reattach to")
+	enterCmd.Flags().StringVar(&cmd.DebugImage, "debug-image", services.DefaultDebugImage, "The image to use for the ephemeral debug container")
 
 	enterCmd.Flags().BoolVar(&cmd.Pick, "pick", true, "Select a pod / container if multiple are found")
 	enterCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
 	enterCmd.Flags().BoolVar(&cmd.Reconnect, "reconnect", false, "Will reconnect the terminal if an unexpected return code is encountered")
+	enterCmd.Flags().BoolVar(&cmd.Debug, "debug", false, "Open the terminal in a new ephemeral debug container that shares the process namespace of the selected container")
 
 	return enterCmd
 }
@@ -130,8 +139,13 @@ func (cmd *EnterCmd) Run(f factory.Factory, cobraCmd *cobra.Command, args []stri
 	selectorOptions.ImageSelector = imageSelector
 
 	// Start terminal
+	debugImage := ""
+	if cmd.Debug {
+		debugImage = cmd.DebugImage
+	}
+
 	stdout, stderr, stdin := defaultStdStreams(cmd.Stdout, cmd.Stderr, cmd.Stdin)
-	exitCode, err := f.NewServicesClient(nil, nil, client, logger).StartTerminal(selectorOptions, args, cmd.WorkingDirectory, make(chan error), cmd.Wait, cmd.Reconnect, stdout, stderr, stdin)
+	exitCode, err := f.NewServicesClient(nil, nil, client, logger).StartTerminal(selectorOptions, args, cmd.WorkingDirectory, cmd.Session, debugImage, make(chan error), cmd.Wait, cmd.Reconnect, stdout, stderr, stdin)
 	if err != nil {
 		return err
 	} else if exitCode != 0 {
@@ -3,6 +3,7 @@ package list
 import (
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -76,15 +77,21 @@ func (cmd *commandsCmd) RunListProfiles(f factory.Factory, cobraCmd *cobra.Comma
 		"Description",
 	}
 
+	log.PrintTable(logger, headerColumnNames, commandRows(commands, ""))
+	return nil
+}
+
+// commandRows returns a row for every command and sub-command, e.g. db migrate
+func commandRows(commands []*latest.CommandConfig, prefix string) [][]string {
 	rows := [][]string{}
 	for _, command := range commands {
 		rows = append(rows, []string{
-			command.Name,
+			prefix + command.Name,
 			command.Command,
 			command.Description,
 		})
+		rows = append(rows, commandRows(command.Commands, prefix+command.Name+" ")...)
 	}
 
-	log.PrintTable(logger, headerColumnNames, rows)
-	return nil
+	return rows
 }
@@ -0,0 +1,124 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/export"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+
+	"github.com/spf13/cobra"
+)
+
+type dependenciesCmd struct {
+	*flags.GlobalFlags
+
+	Graph  bool
+	Output string
+}
+
+func newDependenciesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &dependenciesCmd{GlobalFlags: globalFlags}
+
+	dependenciesCmd := &cobra.Command{
+		Use:   "dependencies",
+		Short: "Lists all resolved dependencies",
+		Long: `
+#######################################################
+############ devspace list dependencies ###############
+#######################################################
+Resolves and lists all dependencies of the devspace.yaml
+
+Example:
+devspace list dependencies
+devspace list dependencies --graph
+devspace list dependencies --graph --output dot | dot -Tpng > dependencies.png
+devspace list dependencies --graph --output mermaid
+devspace list dependencies --graph --output json
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunListDependencies(f, cobraCmd, args)
+		}}
+
+	dependenciesCmd.Flags().BoolVar(&cmd.Graph, "graph", false, "Print the dependencies as graph")
+	dependenciesCmd.Flags().StringVarP(&cmd.Output, "output", "o", export.FormatTree, "The graph output format. One of: tree, dot, mermaid, json")
+	return dependenciesCmd
+}
+
+// RunListDependencies runs the list dependencies command logic
+func (cmd *dependenciesCmd) RunListDependencies(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	logger := f.GetLog()
+	configOptions := cmd.ToConfigOptions(logger)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Get the config
+	config, err := configLoader.Load(configOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	// Resolve dependencies
+	dependencies, err := f.NewDependencyManager(config, nil, configOptions, logger).ResolveAll(dependency.ResolveOptions{
+		Silent: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	root := filepath.Base(filepath.Dir(config.Path()))
+	graph := export.New(root, dependencies)
+	if cmd.Graph || cobraCmd.Flags().Changed("output") {
+		return graph.Print(cmd.Output, os.Stdout)
+	}
+
+	// Specify the table column names
+	headerColumnNames := []string{
+		"Name",
+		"Source",
+		"Profile",
+		"Namespace",
+		"Images",
+		"Deployments",
+		"Dependencies",
+	}
+
+	rows := [][]string{}
+	for _, node := range graph.Nodes {
+		children := []string{}
+		for _, id := range node.Dependencies {
+			for _, child := range graph.Nodes {
+				if child.ID == id {
+					children = append(children, child.Name)
+				}
+			}
+		}
+
+		rows = append(rows, []string{
+			node.Name,
+			node.Source,
+			node.Profile,
+			node.Namespace,
+			strings.Join(node.Images, ", "),
+			strings.Join(node.Deployments, ", "),
+			strings.Join(children, ", "),
+		})
+	}
+
+	log.PrintTable(logger, headerColumnNames, rows)
+	return nil
+}
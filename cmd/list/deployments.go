@@ -7,6 +7,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	deployHelm "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	deployKubectl "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	deployPlugin "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/plugin"
 	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
@@ -128,6 +129,12 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 					logger.Warnf("Unable to create helm deploy config for %s: %v", deployConfig.Name, err)
 					continue
 				}
+			} else if deployConfig.Plugin != nil {
+				deployClient, err = deployPlugin.New(deployConfig, logger)
+				if err != nil {
+					logger.Warnf("Unable to create plugin deploy config for %s: %v", deployConfig.Name, err)
+					continue
+				}
 			} else {
 				logger.Warnf("No deployment method defined for deployment %s", deployConfig.Name)
 				continue
@@ -0,0 +1,133 @@
+package list
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type hooksCmd struct {
+	*flags.GlobalFlags
+
+	LastRun    bool
+	ShowOutput bool
+}
+
+func newHooksCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &hooksCmd{GlobalFlags: globalFlags}
+
+	hooksCmd := &cobra.Command{
+		Use:   "hooks",
+		Short: "Lists all hooks or the hooks executed during the last run",
+		Long: `
+#######################################################
+############### devspace list hooks ###################
+#######################################################
+Lists all hooks defined in the devspace.yaml or with
+--last-run the hooks that were executed during the last
+devspace run including their duration and exit code
+
+Examples:
+devspace list hooks
+devspace list hooks --last-run
+devspace list hooks --last-run --show-output
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunListHooks(f, cobraCmd, args)
+		}}
+
+	hooksCmd.Flags().BoolVar(&cmd.LastRun, "last-run", false, "Show the hooks that were executed during the last run")
+	hooksCmd.Flags().BoolVar(&cmd.ShowOutput, "show-output", false, "Print the captured output of the hooks executed during the last run")
+	return hooksCmd
+}
+
+// RunListHooks runs the list hooks command logic
+func (cmd *hooksCmd) RunListHooks(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	logger := f.GetLog()
+	configOptions := cmd.ToConfigOptions(logger)
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	if cmd.LastRun {
+		return cmd.listLastRun(logger)
+	}
+
+	config, err := configLoader.Load(configOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{}
+	for _, hookConfig := range config.Config().Hooks {
+		rows = append(rows, []string{
+			hook.Name(hookConfig),
+			hook.Type(hookConfig),
+			strings.Join(hookConfig.Events, ", "),
+			strconv.FormatBool(hookConfig.Background),
+		})
+	}
+
+	log.PrintTable(logger, []string{"Name", "Type", "Events", "Background"}, rows)
+	return nil
+}
+
+func (cmd *hooksCmd) listLastRun(logger log.Logger) error {
+	report, err := hook.LoadReport(hook.ReportPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			logger.Info("No hooks were executed yet")
+			return nil
+		}
+
+		return err
+	}
+
+	logger.Infof("Hooks executed by '%s' at %s", strings.Join(report.Command, " "), report.StartTime.Format(time.RFC1123))
+	rows := [][]string{}
+	for _, hookReport := range report.Hooks {
+		rows = append(rows, []string{
+			hookReport.Name,
+			hookReport.Type,
+			hookReport.Event,
+			hookReport.StartTime.Format("15:04:05"),
+			hookReport.Duration.Round(time.Millisecond).String(),
+			strconv.Itoa(hookReport.ExitCode),
+			strconv.Itoa(hookReport.Attempts),
+			hookReport.Error,
+		})
+	}
+
+	log.PrintTable(logger, []string{"Name", "Type", "Event", "Started", "Duration", "Exit Code", "Attempts", "Error"}, rows)
+	if cmd.ShowOutput {
+		for _, hookReport := range report.Hooks {
+			if hookReport.Output == "" {
+				continue
+			}
+
+			logger.WriteString(fmt.Sprintf("\n--- %s (%s) ---\n%s", hookReport.Name, hookReport.Event, hookReport.Output))
+			if !strings.HasSuffix(hookReport.Output, "\n") {
+				logger.WriteString("\n")
+			}
+		}
+	}
+
+	return nil
+}
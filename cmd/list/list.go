@@ -25,9 +25,11 @@ func NewListCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plu
 	listCmd.AddCommand(newProfilesCmd(f))
 	listCmd.AddCommand(newVarsCmd(f, globalFlags))
 	listCmd.AddCommand(newDeploymentsCmd(f, globalFlags))
+	listCmd.AddCommand(newDependenciesCmd(f, globalFlags))
 	listCmd.AddCommand(newContextsCmd(f))
 	listCmd.AddCommand(newPluginsCmd(f))
 	listCmd.AddCommand(newCommandsCmd(f, globalFlags))
+	listCmd.AddCommand(newHooksCmd(f, globalFlags))
 	listCmd.AddCommand(newNamespacesCmd(f, globalFlags))
 
 	// Add plugin commands
@@ -7,6 +7,7 @@ import (
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -74,7 +75,7 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 		for name, value := range config.Variables() {
 			varRow = append(varRow, []string{
 				name,
-				fmt.Sprintf("%v", value),
+				variable.EncodeValue(variable.RedactValue(value)),
 			})
 		}
 
@@ -91,10 +92,15 @@ func (cmd *varsCmd) RunListVars(f factory.Factory, cobraCmd *cobra.Command, args
 		log.PrintTable(logger, headerColumnNames, varRow)
 	case "keyvalue":
 		for name, value := range config.Variables() {
-			fmt.Printf("%s=%v\n", name, value)
+			fmt.Printf("%s=%s\n", name, variable.EncodeValue(variable.RedactValue(value)))
 		}
 	case "json":
-		out, err := json.MarshalIndent(config.Variables(), "", "  ")
+		vars := map[string]interface{}{}
+		for name, value := range config.Variables() {
+			vars[name] = variable.RedactValue(variable.JSONValue(value))
+		}
+
+		out, err := json.MarshalIndent(vars, "", "  ")
 		if err != nil {
 			return err
 		}
@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
@@ -13,6 +17,8 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/logfilter"
+	"github.com/loft-sh/devspace/pkg/devspace/services/logstore"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/imageselector"
@@ -36,6 +42,14 @@ type LogsCmd struct {
 	Follow            bool
 	Wait              bool
 	LastAmountOfLines int
+
+	Include []string
+	Exclude []string
+	JSON    bool
+	Fields  []string
+
+	Since string
+	Grep  string
 }
 
 // NewLogsCmd creates a new login command
@@ -55,6 +69,9 @@ to it
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs --include "error" --exclude "healthz"
+devspace logs --json --fields msg,trace_id
+devspace logs --since 1h --grep "panic" # Search logs persisted by devspace dev
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -73,6 +90,12 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 	logsCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
+	logsCmd.Flags().StringSliceVar(&cmd.Include, "include", []string{}, "Only print lines that match at least one of these regular expressions")
+	logsCmd.Flags().StringSliceVar(&cmd.Exclude, "exclude", []string{}, "Do not print lines that match any of these regular expressions")
+	logsCmd.Flags().BoolVar(&cmd.JSON, "json", false, "Parse json log lines and color them by log level")
+	logsCmd.Flags().StringSliceVar(&cmd.Fields, "fields", []string{}, "The json fields to print (e.g. msg,trace_id). Implies --json")
+	logsCmd.Flags().StringVar(&cmd.Since, "since", "", "Search the logs persisted by devspace dev that were received within this duration (e.g. 30m, 2h)")
+	logsCmd.Flags().StringVar(&cmd.Grep, "grep", "", "Search the logs persisted by devspace dev for lines matching this regular expression")
 
 	return logsCmd
 }
@@ -88,6 +111,26 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 		return err
 	}
 
+	// Create log filter
+	filter, err := logfilter.New(&logfilter.Options{
+		Include: cmd.Include,
+		Exclude: cmd.Exclude,
+		JSON:    cmd.JSON,
+		Fields:  cmd.Fields,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create log filter")
+	}
+
+	// Search the persisted logs instead of the cluster
+	if cmd.Since != "" || cmd.Grep != "" {
+		if !configExists {
+			return errors.New(message.ConfigNotFound)
+		}
+
+		return cmd.searchPersistedLogs(filter, os.Stdout)
+	}
+
 	// Load config if possible
 	var generatedConfig *generated.Config
 	if configExists {
@@ -128,12 +171,55 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 	// set image selector
 	options.ImageSelector = imageSelector
 
-	// Start terminal
-	err = f.NewServicesClient(nil, nil, client, log).StartLogs(options, cmd.Follow, int64(cmd.LastAmountOfLines), cmd.Wait)
+	// Start logs
+	writer := logfilter.NewWriter(os.Stdout, filter)
+	err = f.NewServicesClient(nil, nil, client, log).StartLogsWithWriter(options, cmd.Follow, int64(cmd.LastAmountOfLines), cmd.Wait, writer)
 	if err != nil {
 		return err
 	}
 
+	return writer.Flush()
+}
+
+func (cmd *LogsCmd) searchPersistedLogs(filter *logfilter.Filter, writer io.Writer) error {
+	query := logstore.Query{
+		Pod:       cmd.Pod,
+		Container: cmd.Container,
+	}
+	if cmd.Since != "" {
+		since, err := time.ParseDuration(cmd.Since)
+		if err != nil {
+			return errors.Wrap(err, "parse --since")
+		}
+
+		query.Since = time.Now().Add(-since)
+	}
+	if cmd.Grep != "" {
+		grep, err := regexp.Compile(cmd.Grep)
+		if err != nil {
+			return errors.Wrap(err, "parse --grep")
+		}
+
+		query.Match = grep.MatchString
+	}
+
+	entries, err := logstore.NewStore(logstore.DefaultFolder, 0, 0).Search(query)
+	if err != nil {
+		return errors.Wrap(err, "search persisted logs")
+	}
+
+	for _, entry := range entries {
+		line, ok := filter.Process(entry.Line)
+		if !ok {
+			continue
+		}
+
+		_, err = fmt.Fprintf(writer, "%s [%s/%s:%s] %s\n", entry.Time.Local().Format(time.RFC3339), entry.Namespace, entry.Pod, entry.Container, line)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
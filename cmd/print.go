@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"io"
 	"os"
@@ -13,6 +12,8 @@ import (
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logger "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -28,6 +29,7 @@ type PrintCmd struct {
 
 	Out      io.Writer
 	SkipInfo bool
+	Schema   bool
 }
 
 // NewPrintCmd creates a new devspace print command
@@ -46,6 +48,11 @@ func NewPrintCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comma
 #######################################################
 Prints the configuration for the current or given 
 profile after all patching and variable substitution
+
+With --schema the json schema of the devspace.yaml is
+printed, which can be used for autocompletion and
+validation in editors:
+devspace print --schema > devspace.schema.json
 #######################################################`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			plugin.SetPluginCommand(cobraCmd, args)
@@ -54,12 +61,23 @@ profile after all patching and variable substitution
 	}
 
 	printCmd.Flags().BoolVar(&cmd.SkipInfo, "skip-info", false, "When enabled, only prints the configuration without additional information")
+	printCmd.Flags().BoolVar(&cmd.Schema, "schema", false, "When enabled, prints the json schema of the devspace.yaml instead of the configuration")
 
 	return printCmd
 }
 
 // Run executes the command logic
 func (cmd *PrintCmd) Run(f factory.Factory) error {
+	if cmd.Schema {
+		out, err := schema.Latest().JSON()
+		if err != nil {
+			return err
+		}
+
+		_, err = cmd.Out.Write(append(out, '\n'))
+		return err
+	}
+
 	// Set config root
 	log := f.GetLog()
 	configOptions := cmd.ToConfigOptions(log)
@@ -111,12 +129,12 @@ func (cmd *PrintCmd) Run(f factory.Factory) error {
 	}
 
 	if cmd.Out != nil {
-		_, err := cmd.Out.Write(bsConfig)
+		_, err := cmd.Out.Write([]byte(logger.Redact(string(bsConfig))))
 		if err != nil {
 			return err
 		}
 	} else {
-		log.WriteString(string(bsConfig))
+		log.WriteString(logger.Redact(string(bsConfig)))
 	}
 
 	return nil
@@ -136,7 +154,7 @@ func printExtraInfo(configPath string, config config.Config, log logger.Logger)
 	for varName, varValue := range resolvedVars {
 		values = append(values, []string{
 			varName,
-			fmt.Sprintf("%v", varValue),
+			variable.EncodeValue(variable.RedactValue(varValue)),
 		})
 	}
 
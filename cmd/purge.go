@@ -25,10 +25,11 @@ import (
 type PurgeCmd struct {
 	*flags.GlobalFlags
 
-	Deployments         string
-	VerboseDependencies bool
-	PurgeDependencies   bool
-	All                 bool
+	Deployments               string
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
+	PurgeDependencies         bool
+	All                       bool
 
 	SkipDependency []string
 	Dependency     []string
@@ -67,6 +68,7 @@ devspace purge -d my-deployment
 	purgeCmd.Flags().BoolVarP(&cmd.All, "all", "a", true, "When enabled purges the dependencies as well")
 	purgeCmd.Flags().BoolVar(&cmd.PurgeDependencies, "dependencies", false, "DEPRECATED: Please use --all instead")
 	purgeCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", true, "Builds the dependencies verbosely")
+	purgeCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", 1, "Purges up to this many independent dependencies in parallel")
 
 	purgeCmd.Flags().StringSliceVar(&cmd.SkipDependency, "skip-dependency", []string{}, "Skips the following dependencies from purging")
 	purgeCmd.Flags().StringSliceVar(&cmd.Dependency, "dependency", []string{}, "Purges only the specific named dependencies")
@@ -155,6 +157,7 @@ func (cmd *PurgeCmd) runCommand(f factory.Factory, client kubectl.Client, config
 			SkipDependencies: cmd.SkipDependency,
 			Dependencies:     cmd.Dependency,
 			Verbose:          cmd.VerboseDependencies,
+			MaxConcurrency:   cmd.MaxConcurrentDependencies,
 		})
 		if err != nil {
 			cmd.log.Errorf("Error purging dependencies: %v", err)
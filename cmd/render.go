@@ -29,9 +29,10 @@ type RenderCmd struct {
 
 	Tags []string
 
-	SkipPush                bool
-	SkipPushLocalKubernetes bool
-	VerboseDependencies     bool
+	SkipPush                  bool
+	SkipPushLocalKubernetes   bool
+	VerboseDependencies       bool
+	MaxConcurrentDependencies int
 
 	SkipBuild           bool
 	ForceBuild          bool
@@ -75,6 +76,7 @@ deployment.
 	renderCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
 	renderCmd.Flags().IntVar(&cmd.MaxConcurrentBuilds, "max-concurrent-builds", 0, "The maximum number of image builds built in parallel (0 for infinite)")
 	renderCmd.Flags().BoolVar(&cmd.VerboseDependencies, "verbose-dependencies", false, "Builds the dependencies verbosely")
+	renderCmd.Flags().IntVar(&cmd.MaxConcurrentDependencies, "max-concurrent-dependencies", 1, "Renders up to this many independent dependencies in parallel")
 	renderCmd.Flags().StringSliceVarP(&cmd.Tags, "tag", "t", []string{}, "Use the given tag for all built images")
 	renderCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
 	renderCmd.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", true, "Skips image pushing, if a local kubernetes environment is detected")
@@ -149,6 +151,7 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 			SkipDependencies: cmd.SkipDependency,
 			SkipBuild:        cmd.SkipBuild,
 			Verbose:          cmd.VerboseDependencies,
+			MaxConcurrency:   cmd.MaxConcurrentDependencies,
 			Writer:           cmd.Writer,
 
 			BuildOptions: build.Options{
@@ -162,6 +165,12 @@ func (cmd *RenderCmd) Run(f factory.Factory) error {
 		if err != nil {
 			return errors.Wrap(err, "render dependencies")
 		}
+	} else {
+		// no dependency is rendered, so referenced dependency outputs cannot be resolved
+		err = dependency.FillOutputs(config, nil)
+		if err != nil {
+			return err
+		}
 	}
 	if len(cmd.Dependency) > 0 {
 		return nil
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/util/interrupt"
 
@@ -109,6 +110,13 @@ func Execute() {
 	// set version for --version flag
 	rootCmd.Version = upgrade.GetVersion()
 
+	// record the hooks executed during this run in the devspace root
+	root, err := loader.FindDevSpaceRoot()
+	if err != nil {
+		f.GetLog().Fatal(err)
+	}
+	hook.EnableReport(root)
+
 	// before hooks
 	pluginErr := hook.ExecuteHooks(nil, nil, nil, nil, nil, "root", "root.beforeExecute", "command:before:execute")
 	if pluginErr != nil {
@@ -116,7 +124,7 @@ func Execute() {
 	}
 
 	// execute command
-	err := rootCmd.Execute()
+	err = rootCmd.Execute()
 
 	// after hooks
 	pluginErr = hook.ExecuteHooks(nil, nil, nil, map[string]interface{}{"error": err}, nil, "root.afterExecute", "command:after:execute")
@@ -124,11 +132,13 @@ func Execute() {
 		// Check if return code error
 		retCode, ok := errors.Cause(err).(*exit.ReturnCodeError)
 		if ok {
+			plugin.StopGRPCPlugins()
 			os.Exit(retCode.ExitCode)
 		}
 
 		// error hooks
 		pluginErr := hook.ExecuteHooks(nil, nil, nil, map[string]interface{}{"error": err}, nil, "root.errorExecution", "command:error")
+		plugin.StopGRPCPlugins()
 		if pluginErr != nil {
 			f.GetLog().Fatalf("%+v", pluginErr)
 		}
@@ -138,7 +148,10 @@ func Execute() {
 		} else {
 			f.GetLog().Fatal(err)
 		}
-	} else if pluginErr != nil {
+	}
+
+	plugin.StopGRPCPlugins()
+	if pluginErr != nil {
 		f.GetLog().Fatalf("%+v", pluginErr)
 	}
 }
@@ -4,21 +4,31 @@ import (
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/loft-sh/devspace/pkg/devspace/command"
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	flagspkg "github.com/loft-sh/devspace/pkg/util/flags"
+	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
 	"github.com/sirupsen/logrus"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // RunCmd holds the run cmd flags
@@ -28,6 +38,7 @@ type RunCmd struct {
 	Dependency string
 	Stdout     io.Writer
 	Stderr     io.Writer
+	Stdin      io.Reader
 }
 
 // NewRunCmd creates a new run command
@@ -36,6 +47,7 @@ func NewRunCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command
 		GlobalFlags: globalFlags,
 		Stdout:      os.Stdout,
 		Stderr:      os.Stderr,
+		Stdin:       os.Stdin,
 	}
 
 	runCmd := &cobra.Command{
@@ -51,52 +63,124 @@ Run executes a predefined command from the devspace.yaml
 Examples:
 devspace run mycommand --myarg 123
 devspace run mycommand2 1 2 3
+devspace run mycommand3 --help
 devspace --dependency my-dependency run any-command --any-command-flag
 #######################################################
 	`,
 		Args: cobra.MinimumNArgs(1),
-		RunE: func(cobraCmd *cobra.Command, _ []string) error {
-			log := f.GetLog()
-
-			// get all flags till "run"
-			index := -1
-			for i, v := range os.Args {
-				if v == "run" {
-					index = i + 1
-					break
-				}
-			}
-			if index == -1 {
-				return fmt.Errorf("error parsing command: couldn't find run in command: %v", os.Args)
-			}
+	}
+	runCmd.RunE = func(_ *cobra.Command, _ []string) error {
+		log := f.GetLog()
 
-			// check if is help command
-			osArgs := os.Args[:index]
-			if len(os.Args) == index+1 && (os.Args[index] == "-h" || os.Args[index] == "--help") {
-				return cobraCmd.Help()
-			}
+		// get all flags till "run"
+		index := runIndex()
+		if index == -1 {
+			return fmt.Errorf("error parsing command: couldn't find run in command: %v", os.Args)
+		}
 
-			// enable flag parsing
-			cobraCmd.DisableFlagParsing = false
+		// check if is help command
+		osArgs := os.Args[:index]
+		if len(os.Args) == index+1 && (os.Args[index] == "-h" || os.Args[index] == "--help") {
+			return runCmd.Help()
+		}
 
-			// apply extra flags
-			_, err := flagspkg.ApplyExtraFlags(cobraCmd, osArgs, true)
-			if err != nil {
-				return err
-			} else if cmd.Silent {
-				log.SetLevel(logrus.FatalLevel)
-			}
+		// enable flag parsing
+		runCmd.DisableFlagParsing = false
 
-			args := os.Args[index:]
-			plugin.SetPluginCommand(cobraCmd, args)
-			return cmd.RunRun(f, args)
-		},
+		// apply extra flags
+		_, err := flagspkg.ApplyExtraFlags(runCmd, osArgs, true)
+		if err != nil {
+			return err
+		} else if cmd.Silent {
+			log.SetLevel(logrus.FatalLevel)
+		}
+
+		args := os.Args[index:]
+		plugin.SetPluginCommand(runCmd, args)
+		return cmd.RunRun(f, args)
+	}
+
+	// the commands of the config are registered, so that they show up in the help and completion.
+	// The config is only parsed if these are requested, because devspace run parses it anyway.
+	if index := runIndex(); index != -1 && configCommandsRequested(index) {
+		command.AddCobraCommands(runCmd, loadCommands(f, os.Args[1:index-1]), runCmd.RunE)
 	}
 
 	runCmd.Flags().StringVar(&cmd.Dependency, "dependency", "", "Run a command from a specific dependency")
 	return runCmd
 }
 
+// runIndex returns the index of the first argument after run in os.Args or -1 if there is none
+func runIndex() int {
+	for i, v := range os.Args {
+		if v == "run" {
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// configCommandsRequested returns true if the help or the shell completion of devspace run is
+// requested, which list the commands of the config
+func configCommandsRequested(index int) bool {
+	for _, arg := range os.Args[1 : index-1] {
+		if arg == "help" || arg == cobra.ShellCompRequestCmd || arg == cobra.ShellCompNoDescRequestCmd {
+			return true
+		}
+	}
+
+	return len(os.Args) == index+1 && (os.Args[index] == "-h" || os.Args[index] == "--help")
+}
+
+// loadCommands parses the commands of the config that devspace run would use with the given
+// global flags. The variables of the config are not resolved, because no questions should be
+// asked while the commands are built. If the config cannot be parsed, no commands are returned.
+func loadCommands(f factory.Factory, args []string) []*latest.CommandConfig {
+	// the global flags are not parsed yet, so they are parsed into a separate flag set
+	flagSet := pflag.NewFlagSet("devspace", pflag.ContinueOnError)
+	flagSet.ParseErrorsWhitelist.UnknownFlags = true
+	flagSet.SetOutput(ioutil.Discard)
+	globalFlags := flags.SetGlobalFlags(flagSet)
+	extraFlags, err := flagspkg.ParseCommandLine(os.Getenv("DEVSPACE_FLAGS"))
+	if err == nil {
+		args = append(extraFlags, args...)
+	}
+	_ = flagSet.Parse(args)
+
+	configPath := globalFlags.ConfigPath
+	if configPath == "" {
+		root, err := loader.FindDevSpaceRoot()
+		if err != nil || root == "" {
+			return nil
+		}
+
+		configPath = filepath.Join(root, constants.DefaultConfigPath)
+	}
+
+	configLoader := f.NewConfigLoader(configPath)
+	if !configLoader.Exists() {
+		return nil
+	}
+
+	rawConfig, err := configLoader.LoadRaw()
+	if err != nil {
+		return nil
+	}
+
+	commandsConfig, err := versions.ParseCommands(rawConfig)
+	if err != nil {
+		return nil
+	}
+
+	config, err := versions.Parse(commandsConfig, log.Discard)
+	if err != nil {
+		return nil
+	}
+
+	return config.Commands
+}
+
 // RunRun executes the functionality "devspace run"
 func (cmd *RunCmd) RunRun(f factory.Factory, args []string) error {
 	if len(args) == 0 {
@@ -138,6 +222,13 @@ func (cmd *RunCmd) RunRun(f factory.Factory, args []string) error {
 			Dependency: cmd.Dependency,
 			Command:    args[0],
 			Args:       args[1:],
+			Stdout:     cmd.Stdout,
+			Stderr:     cmd.Stderr,
+			Stdin:      cmd.Stdin,
+
+			NewContainerExecuter: func(dependencyConfig config2.Config) (command.ContainerExecuter, error) {
+				return cmd.newContainerExecuter(f, dependencyConfig, config.Generated())
+			},
 		})
 	}
 
@@ -161,6 +252,60 @@ func (cmd *RunCmd) RunRun(f factory.Factory, args []string) error {
 		return err
 	}
 
+	options := &command.Options{
+		Stdout: cmd.Stdout,
+		Stderr: cmd.Stderr,
+		Stdin:  cmd.Stdin,
+		Cache:  generatedConfig.GetActive(),
+		Log:    f.GetLog(),
+	}
+
+	// Create a services client if the command or one of its dependencies is executed in a container
+	for _, c := range command.Dependencies(commands, args[0]) {
+		if command.NeedsContainer(c) {
+			// Load the config to resolve image selectors
+			config, err := configLoader.Load(configOptions, f.GetLog())
+			if err != nil {
+				return err
+			}
+
+			options.ContainerExecuter, err = cmd.newContainerExecuter(f, config, generatedConfig)
+			if err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
 	// Execute command
-	return dependency.ExecuteCommand(commands, args[0], args[1:], cmd.Stdout, cmd.Stderr)
+	err = dependency.ExecuteCommandWithOptions(commands, args[0], args[1:], options)
+
+	// Save the hashes of the executed commands
+	saveErr := configLoader.SaveGenerated(generatedConfig)
+	if err != nil {
+		return err
+	} else if saveErr != nil {
+		return errors.Wrap(saveErr, "save generated config")
+	}
+
+	return nil
+}
+
+func (cmd *RunCmd) newContainerExecuter(f factory.Factory, config config2.Config, generatedConfig *generated.Config) (command.ContainerExecuter, error) {
+	log := f.GetLog()
+
+	// Use last context if specified
+	err := cmd.UseLastContext(generatedConfig, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get kubectl client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return nil, errors.Wrap(err, "new kube client")
+	}
+
+	return f.NewServicesClient(config, nil, client, log), nil
 }
@@ -27,7 +27,8 @@ func newDependenciesCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobr
 ############ devspace update dependencies #############
 #######################################################
 Updates the git repositories of the dependencies defined
-in the devspace.yaml
+in the devspace.yaml and pins the resolved commits in the
+devspace.lock
 #######################################################
 	`,
 		Args: cobra.NoArgs,
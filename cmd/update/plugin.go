@@ -7,7 +7,9 @@ import (
 )
 
 type pluginCmd struct {
-	Version string
+	Version    string
+	Index      string
+	PublicKeys []string
 }
 
 func newPluginCmd(f factory.Factory) *cobra.Command {
@@ -30,7 +32,9 @@ devspace update plugin my-plugin
 			return cmd.Run(f, args)
 		}}
 
-	pluginCmd.Flags().StringVar(&cmd.Version, "version", "", "The git tag to use")
+	pluginCmd.Flags().StringVar(&cmd.Version, "version", "", "The git tag to use or the version constraint if the plugin was installed from a plugin index")
+	pluginCmd.Flags().StringVar(&cmd.Index, "index", "", "The path or https url of the plugin index to update the plugin from (defaults to the index the plugin was installed from)")
+	pluginCmd.Flags().StringSliceVar(&cmd.PublicKeys, "public-key", []string{}, "A trusted ed25519 public key or key file the plugin binary has to be signed with (defaults to the keys the plugin was installed with)")
 	return pluginCmd
 }
 
@@ -51,7 +55,10 @@ func (cmd *pluginCmd) Run(f factory.Factory, args []string) error {
 	f.GetLog().StartWait("Updating plugin " + args[0])
 	defer f.GetLog().StopWait()
 
-	updatedPlugin, err := pluginManager.Update(args[0], cmd.Version)
+	updatedPlugin, err := pluginManager.Update(args[0], cmd.Version, &plugin.InstallOptions{
+		Index:      cmd.Index,
+		PublicKeys: cmd.PublicKeys,
+	})
 	if err != nil {
 		if newestVersion, ok := err.(*plugin.NewestVersionError); ok {
 			f.GetLog().Info(newestVersion.Error())
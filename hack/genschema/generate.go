@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `// Code generated by hack/genschema. DO NOT EDIT.
+
+package schema
+`
+
+// main extracts the doc comments and enum values of the given go file (usually
+// pkg/devspace/config/versions/latest/schema.go), because they are not available via reflection
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: genschema SOURCE OUTPUT")
+	}
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, os.Args[1], nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	typeDescriptions := map[string]string{}
+	fieldDescriptions := map[string]string{}
+	enumValues := map[string][]string{}
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				doc := spec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				if text := commentText(doc); text != "" {
+					typeDescriptions[spec.Name.Name] = text
+				}
+
+				structType, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+					if text == "" {
+						continue
+					}
+					for _, name := range field.Names {
+						fieldDescriptions[spec.Name.Name+"."+name.Name] = text
+					}
+				}
+			case *ast.ValueSpec:
+				typeName, ok := spec.Type.(*ast.Ident)
+				if !ok || genDecl.Tok != token.CONST || !ast.IsExported(typeName.Name) {
+					continue
+				}
+				for _, value := range spec.Values {
+					literal, ok := value.(*ast.BasicLit)
+					if !ok || literal.Kind != token.STRING {
+						continue
+					}
+
+					unquoted, err := strconv.Unquote(literal.Value)
+					if err != nil {
+						log.Fatal(err)
+					}
+					enumValues[typeName.Name] = append(enumValues[typeName.Name], unquoted)
+				}
+			}
+		}
+	}
+
+	out := &bytes.Buffer{}
+	out.WriteString(header)
+	writeMap(out, "typeDescriptions", "map[string]string", typeDescriptions, strconv.Quote)
+	writeMap(out, "fieldDescriptions", "map[string]string", fieldDescriptions, strconv.Quote)
+
+	enumStrings := map[string]string{}
+	for name, values := range enumValues {
+		quoted := []string{}
+		for _, value := range values {
+			quoted = append(quoted, strconv.Quote(value))
+		}
+		enumStrings[name] = "{" + strings.Join(quoted, ", ") + "}"
+	}
+	writeMap(out, "enumValues", "map[string][]string", enumStrings, func(s string) string { return s })
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(os.Args[2], formatted, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeMap(out *bytes.Buffer, name, mapType string, values map[string]string, formatValue func(string) string) {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out.WriteString(fmt.Sprintf("\nvar %s = %s{\n", name, mapType))
+	for _, key := range keys {
+		out.WriteString(fmt.Sprintf("\t%s: %s,\n", strconv.Quote(key), formatValue(values[key])))
+	}
+	out.WriteString("}\n")
+}
+
+func commentText(comment *ast.CommentGroup) string {
+	if comment == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(comment.Text())
+}
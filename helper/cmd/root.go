@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/loft-sh/devspace/helper/cmd/session"
 	"github.com/loft-sh/devspace/helper/cmd/sync"
 	"github.com/spf13/cobra"
 )
@@ -37,7 +38,9 @@ func BuildRoot() *cobra.Command {
 	rootCmd.AddCommand(NewRestartCmd())
 	rootCmd.AddCommand(NewVersionCmd())
 	rootCmd.AddCommand(NewTunnelCmd())
+	rootCmd.AddCommand(NewSSHCmd())
 	rootCmd.AddCommand(sync.NewSyncCmd())
+	rootCmd.AddCommand(session.NewSessionCmd())
 
 	return rootCmd
 }
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"os"
+
+	"github.com/loft-sh/devspace/helper/session"
+	"github.com/spf13/cobra"
+)
+
+// AttachCmd holds the attach cmd flags
+type AttachCmd struct{}
+
+// NewAttachCmd creates a new attach command
+func NewAttachCmd() *cobra.Command {
+	cmd := &AttachCmd{}
+	attachCmd := &cobra.Command{
+		Use:   "attach NAME [-- COMMAND]",
+		Short: "Attaches to the session or starts it with the given command if it does not exist",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  cmd.Run,
+	}
+
+	return attachCmd
+}
+
+// Run runs the command logic
+func (cmd *AttachCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	command := args[1:]
+	if len(command) == 0 {
+		command = []string{"sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh"}
+	}
+
+	exitCode, err := session.Attach(args[0], command, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	} else if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+
+	return nil
+}
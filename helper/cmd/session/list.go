@@ -0,0 +1,39 @@
+package session
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/loft-sh/devspace/helper/session"
+	"github.com/spf13/cobra"
+)
+
+// ListCmd holds the list cmd flags
+type ListCmd struct{}
+
+// NewListCmd creates a new list command
+func NewListCmd() *cobra.Command {
+	cmd := &ListCmd{}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all running sessions",
+		Args:  cobra.NoArgs,
+		RunE:  cmd.Run,
+	}
+
+	return listCmd
+}
+
+// Run runs the command logic
+func (cmd *ListCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	names, err := session.List()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fmt.Fprintln(os.Stdout, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"github.com/loft-sh/devspace/helper/session"
+	"github.com/spf13/cobra"
+)
+
+// ServeCmd holds the serve cmd flags
+type ServeCmd struct{}
+
+// NewServeCmd creates a new serve command
+func NewServeCmd() *cobra.Command {
+	cmd := &ServeCmd{}
+	serveCmd := &cobra.Command{
+		Use:    "serve NAME -- COMMAND",
+		Short:  "Runs the session server in the foreground",
+		Args:   cobra.MinimumNArgs(2),
+		Hidden: true,
+		RunE:   cmd.Run,
+	}
+
+	return serveCmd
+}
+
+// Run runs the command logic
+func (cmd *ServeCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	return session.NewServer(args[0], args[1:]).Serve()
+}
@@ -0,0 +1,19 @@
+package session
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewSessionCmd creates a new cobra command
+func NewSessionCmd() *cobra.Command {
+	sessionCmd := &cobra.Command{
+		Use:   "session",
+		Short: "Session holds the persistent terminal session relevant commands",
+		Args:  cobra.NoArgs,
+	}
+
+	sessionCmd.AddCommand(NewAttachCmd())
+	sessionCmd.AddCommand(NewServeCmd())
+	sessionCmd.AddCommand(NewListCmd())
+	return sessionCmd
+}
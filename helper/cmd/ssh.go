@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/loft-sh/devspace/helper/ssh"
+	"github.com/spf13/cobra"
+)
+
+// SSHCmd holds the ssh cmd flags
+type SSHCmd struct {
+	AuthorizedKey string
+}
+
+// NewSSHCmd creates a new ssh command
+func NewSSHCmd() *cobra.Command {
+	cmd := &SSHCmd{}
+	sshCmd := &cobra.Command{
+		Use:   "ssh",
+		Short: "Starts a new ssh server on stdin & stdout",
+		Args:  cobra.NoArgs,
+		RunE:  cmd.Run,
+	}
+
+	sshCmd.Flags().StringVar(&cmd.AuthorizedKey, "authorized-key", "", "The public key in authorized_keys format that is allowed to connect")
+	_ = sshCmd.MarkFlagRequired("authorized-key")
+	return sshCmd
+}
+
+// Run runs the command logic
+func (cmd *SSHCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	return ssh.StartSSHServer(os.Stdin, os.Stdout, cmd.AuthorizedKey)
+}
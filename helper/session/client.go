@@ -0,0 +1,144 @@
+package session
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// Attach connects to the session with the given name and forwards stdin and stdout
+// until either the session command exits or the connection is closed. If the session
+// does not exist yet, a new session server is started in the background that runs
+// the given command. Attach returns the exit code of the session command.
+func Attach(name string, command []string, stdin *os.File, stdout io.Writer) (int, error) {
+	err := ValidateName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := net.Dial("unix", SocketPath(name))
+	if err != nil {
+		err = startServer(name, command)
+		if err != nil {
+			return 0, errors.Wrap(err, "start session")
+		}
+
+		conn, err = waitForServer(name, time.Second*10)
+		if err != nil {
+			return 0, err
+		}
+	}
+	defer conn.Close()
+
+	// put the terminal into raw mode, because the session pty does
+	// the line editing and echoing
+	if term.IsTerminal(int(stdin.Fd())) {
+		state, err := term.MakeRaw(int(stdin.Fd()))
+		if err == nil {
+			defer func() {
+				_ = term.Restore(int(stdin.Fd()), state)
+			}()
+		}
+	}
+
+	sendResize := func() {
+		cols, rows, err := term.GetSize(int(stdin.Fd()))
+		if err == nil {
+			_ = WriteFrame(conn, FrameResize, EncodeResize(uint16(cols), uint16(rows)))
+		}
+	}
+	sendResize()
+	stopResize := watchResize(sendResize)
+	defer stopResize()
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := stdin.Read(buf)
+			if n > 0 {
+				if WriteFrame(conn, FrameData, buf[:n]) != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		frame, err := ReadFrame(conn)
+		if err != nil {
+			return 0, errors.Wrap(err, "session connection closed")
+		}
+
+		switch frame.Type {
+		case FrameData:
+			_, err = stdout.Write(frame.Payload)
+			if err != nil {
+				return 0, err
+			}
+		case FrameExit:
+			return DecodeExit(frame.Payload)
+		}
+	}
+}
+
+// List returns the names of all running sessions
+func List() ([]string, error) {
+	files, err := ioutil.ReadDir(SocketDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".sock") {
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name(), ".sock")
+		conn, err := net.Dial("unix", SocketPath(name))
+		if err != nil {
+			// remove stale socket
+			_ = os.Remove(SocketPath(name))
+			continue
+		}
+
+		_ = conn.Close()
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func waitForServer(name string, timeout time.Duration) (net.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, err = net.Dial("unix", SocketPath(name))
+		if err == nil {
+			return conn, nil
+		}
+
+		time.Sleep(time.Millisecond * 100)
+	}
+
+	return nil, errors.Wrapf(err, "timed out waiting for session %s", name)
+}
@@ -0,0 +1,68 @@
+//go:build linux
+// +build linux
+
+package session
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize calls onResize whenever the terminal size changes
+func watchResize(onResize func()) func() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGWINCH)
+	go func() {
+		for range sigChan {
+			onResize()
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigChan)
+		close(sigChan)
+	}
+}
+
+// startServer starts a detached session server that outlives the
+// current process
+func startServer(name string, command []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"session", "serve", name, "--"}, command...)
+	cmd := exec.Command(executable, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid: true,
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	return cmd.Process.Release()
+}
+
+// lockFile acquires an exclusive lock on the given file and returns a function that releases it
+func lockFile(path string) (func(), error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		_ = file.Close()
+	}, nil
+}
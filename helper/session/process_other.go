@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+package session
+
+import (
+	"github.com/pkg/errors"
+)
+
+func watchResize(onResize func()) func() {
+	return func() {}
+}
+
+func startServer(name string, command []string) error {
+	return errors.New("sessions are only supported on linux")
+}
+
+func lockFile(path string) (func(), error) {
+	return nil, errors.New("sessions are only supported on linux")
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// FrameType identifies the payload of a single frame exchanged between
+// a session server and an attached client
+type FrameType byte
+
+const (
+	// FrameData carries raw terminal input (client -> server) or output (server -> client)
+	FrameData FrameType = iota + 1
+	// FrameResize carries the new terminal size of the client (client -> server)
+	FrameResize
+	// FrameExit carries the exit code of the session process (server -> client)
+	FrameExit
+)
+
+// maxFrameSize is the maximum payload size of a single frame
+const maxFrameSize = 1024 * 1024
+
+// Frame is a single message exchanged over the session socket
+type Frame struct {
+	Type    FrameType
+	Payload []byte
+}
+
+// WriteFrame writes a single frame to the given writer
+func WriteFrame(w io.Writer, frameType FrameType, payload []byte) error {
+	header := make([]byte, 5)
+	header[0] = byte(frameType)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	_, err := w.Write(append(header, payload...))
+	return err
+}
+
+// ReadFrame reads a single frame from the given reader
+func ReadFrame(r io.Reader) (*Frame, error) {
+	header := make([]byte, 5)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxFrameSize {
+		return nil, errors.Errorf("frame size %d exceeds maximum of %d", size, maxFrameSize)
+	}
+
+	payload := make([]byte, size)
+	_, err = io.ReadFull(r, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Frame{
+		Type:    FrameType(header[0]),
+		Payload: payload,
+	}, nil
+}
+
+// EncodeResize encodes a terminal size into a resize payload
+func EncodeResize(cols, rows uint16) []byte {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint16(payload[0:], cols)
+	binary.BigEndian.PutUint16(payload[2:], rows)
+	return payload
+}
+
+// DecodeResize decodes a resize payload into columns and rows
+func DecodeResize(payload []byte) (uint16, uint16, error) {
+	if len(payload) != 4 {
+		return 0, 0, errors.Errorf("unexpected resize payload length %d", len(payload))
+	}
+
+	return binary.BigEndian.Uint16(payload[0:]), binary.BigEndian.Uint16(payload[2:]), nil
+}
+
+// EncodeExit encodes an exit code into an exit payload
+func EncodeExit(code int) []byte {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, uint32(int32(code)))
+	return payload
+}
+
+// DecodeExit decodes an exit payload into an exit code
+func DecodeExit(payload []byte) (int, error) {
+	if len(payload) != 4 {
+		return 0, errors.Errorf("unexpected exit payload length %d", len(payload))
+	}
+
+	return int(int32(binary.BigEndian.Uint32(payload))), nil
+}
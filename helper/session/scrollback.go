@@ -0,0 +1,66 @@
+package session
+
+import "sync"
+
+// DefaultScrollbackSize is the amount of terminal output in bytes that is
+// replayed to a client when it attaches to an existing session
+const DefaultScrollbackSize = 256 * 1024
+
+// scrollback is a fixed size ring buffer that holds the most recent output
+// of a session
+type scrollback struct {
+	m sync.Mutex
+
+	buffer []byte
+	pos    int
+	full   bool
+}
+
+func newScrollback(size int) *scrollback {
+	return &scrollback{
+		buffer: make([]byte, size),
+	}
+}
+
+// Write appends p to the buffer and overwrites the oldest data if necessary
+func (s *scrollback) Write(p []byte) (int, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	n := len(p)
+	size := len(s.buffer)
+	if n >= size {
+		copy(s.buffer, p[n-size:])
+		s.pos = 0
+		s.full = true
+		return n, nil
+	}
+
+	copied := copy(s.buffer[s.pos:], p)
+	if copied < n {
+		copy(s.buffer, p[copied:])
+	}
+	if s.pos+n >= size {
+		s.full = true
+	}
+
+	s.pos = (s.pos + n) % size
+	return n, nil
+}
+
+// Bytes returns a copy of the buffered output in the order it was written
+func (s *scrollback) Bytes() []byte {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if !s.full {
+		out := make([]byte, s.pos)
+		copy(out, s.buffer[:s.pos])
+		return out
+	}
+
+	out := make([]byte, 0, len(s.buffer))
+	out = append(out, s.buffer[s.pos:]...)
+	out = append(out, s.buffer[:s.pos]...)
+	return out
+}
@@ -0,0 +1,323 @@
+package session
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/util/pty"
+	"github.com/pkg/errors"
+)
+
+// SocketDir is the directory in the container where the session sockets are created
+const SocketDir = "/tmp/devspace-sessions"
+
+var nameRegEx = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ValidateName checks if the given session name can be used
+func ValidateName(name string) error {
+	if !nameRegEx.MatchString(name) {
+		return errors.Errorf("invalid session name '%s': only alphanumeric characters, '.', '_' and '-' are allowed", name)
+	}
+
+	return nil
+}
+
+// SocketPath returns the path of the unix socket for the given session
+func SocketPath(name string) string {
+	return filepath.Join(SocketDir, name+".sock")
+}
+
+// Server hosts a single named session. It runs the session command in a pty
+// and multiplexes its input and output to all attached clients.
+type Server struct {
+	name       string
+	command    []string
+	scrollback *scrollback
+
+	clientsMutex sync.Mutex
+	clients      map[*client]bool
+
+	pty *os.File
+	cmd *exec.Cmd
+}
+
+// NewServer creates a new session server for the given name and command
+func NewServer(name string, command []string) *Server {
+	return &Server{
+		name:       name,
+		command:    command,
+		scrollback: newScrollback(DefaultScrollbackSize),
+		clients:    map[*client]bool{},
+	}
+}
+
+// clientBufferSize is the number of frames that are buffered for a client. A client
+// that falls further behind is disconnected, so that it cannot block the session.
+const clientBufferSize = 256
+
+// clientWriteTimeout is the maximum time writing a single frame to a client may take
+var clientWriteTimeout = 10 * time.Second
+
+// client is an attached client. Frames are written by a separate goroutine, so that
+// a slow client does not block the session output or the other clients.
+type client struct {
+	conn   net.Conn
+	frames chan *Frame
+	done   chan struct{}
+
+	closedMutex sync.Mutex
+	closed      bool
+}
+
+func newClient(conn net.Conn) *client {
+	c := &client{
+		conn:   conn,
+		frames: make(chan *Frame, clientBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go c.writeFrames()
+	return c
+}
+
+func (c *client) writeFrames() {
+	defer close(c.done)
+	for frame := range c.frames {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(clientWriteTimeout))
+		err := WriteFrame(c.conn, frame.Type, frame.Payload)
+		if err != nil {
+			_ = c.conn.Close()
+			return
+		}
+	}
+}
+
+// send queues the frame and returns false if the client was closed or its buffer is full
+func (c *client) send(frameType FrameType, payload []byte) bool {
+	c.closedMutex.Lock()
+	defer c.closedMutex.Unlock()
+	if c.closed {
+		return false
+	}
+
+	select {
+	case c.frames <- &Frame{Type: frameType, Payload: payload}:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops accepting frames, waits until the queued frames were written and closes
+// the connection
+func (c *client) close() {
+	c.closedMutex.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.frames)
+	}
+	c.closedMutex.Unlock()
+
+	<-c.done
+	_ = c.conn.Close()
+}
+
+// Serve starts the session command and accepts clients until the command exits
+func (s *Server) Serve() error {
+	err := ValidateName(s.name)
+	if err != nil {
+		return err
+	} else if len(s.command) == 0 {
+		return errors.New("no command specified")
+	}
+
+	err = os.MkdirAll(SocketDir, 0700)
+	if err != nil {
+		return errors.Wrap(err, "create socket dir")
+	}
+
+	// closing the listener removes the socket again
+	listener, err := listen(SocketPath(s.name))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	s.cmd = exec.Command(s.command[0], s.command[1:]...)
+	s.cmd.Env = append(os.Environ(), "TERM="+terminalType())
+	s.pty, err = pty.Start(s.cmd)
+	if err != nil {
+		return errors.Wrap(err, "start command")
+	}
+	defer s.pty.Close()
+
+	outputDone := make(chan struct{})
+	go func() {
+		defer close(outputDone)
+		s.copyOutput()
+	}()
+	go s.acceptClients(listener)
+
+	exitCode := 0
+	err = s.cmd.Wait()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		} else {
+			exitCode = 1
+		}
+	}
+
+	// give the output reader a chance to flush the remaining output
+	select {
+	case <-outputDone:
+	case <-time.After(time.Second):
+	}
+
+	s.clientsMutex.Lock()
+	clients := s.clients
+	s.clients = map[*client]bool{}
+	s.clientsMutex.Unlock()
+
+	waitGroup := sync.WaitGroup{}
+	for c := range clients {
+		waitGroup.Add(1)
+		go func(c *client) {
+			defer waitGroup.Done()
+			c.send(FrameExit, EncodeExit(exitCode))
+			c.close()
+		}(c)
+	}
+	waitGroup.Wait()
+	return nil
+}
+
+// listen binds the socket of the session. A socket that was left behind by a server
+// that is not running anymore is replaced, but the socket of a running server is never
+// removed. The lock file makes sure that concurrently started servers do not race.
+func listen(socketPath string) (net.Listener, error) {
+	unlock, err := lockFile(socketPath + ".lock")
+	if err != nil {
+		return nil, errors.Wrap(err, "lock socket")
+	}
+	defer unlock()
+
+	listener, err := net.Listen("unix", socketPath)
+	if err == nil {
+		return listener, nil
+	}
+
+	conn, dialErr := net.DialTimeout("unix", socketPath, time.Second)
+	if dialErr == nil {
+		_ = conn.Close()
+		return nil, errors.Errorf("session is already running: %v", err)
+	}
+
+	// the socket is stale
+	_ = os.Remove(socketPath)
+	listener, err = net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen")
+	}
+
+	return listener, nil
+}
+
+func (s *Server) copyOutput() {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := s.pty.Read(buf)
+		if n > 0 {
+			s.broadcast(buf[:n])
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) broadcast(data []byte) {
+	// the data is queued for every client, so it has to be copied
+	// before the read buffer is reused
+	payload := make([]byte, len(data))
+	copy(payload, data)
+
+	s.clientsMutex.Lock()
+	_, _ = s.scrollback.Write(payload)
+	clients := make([]*client, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.clientsMutex.Unlock()
+
+	for _, c := range clients {
+		if !c.send(FrameData, payload) {
+			s.removeClient(c)
+		}
+	}
+}
+
+func (s *Server) removeClient(c *client) {
+	s.clientsMutex.Lock()
+	delete(s.clients, c)
+	s.clientsMutex.Unlock()
+
+	// the client is closed asynchronously, because it might wait for a blocked write
+	go c.close()
+}
+
+func (s *Server) acceptClients(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handleClient(conn)
+	}
+}
+
+func (s *Server) handleClient(conn net.Conn) {
+	// queue the scrollback and register the client atomically, so that
+	// no output gets lost or duplicated in between
+	c := newClient(conn)
+	s.clientsMutex.Lock()
+	c.send(FrameData, s.scrollback.Bytes())
+	s.clients[c] = true
+	s.clientsMutex.Unlock()
+	defer s.removeClient(c)
+
+	for {
+		frame, err := ReadFrame(conn)
+		if err != nil {
+			return
+		}
+
+		switch frame.Type {
+		case FrameData:
+			_, err = s.pty.Write(frame.Payload)
+			if err != nil && err != io.EOF {
+				return
+			}
+		case FrameResize:
+			cols, rows, err := DecodeResize(frame.Payload)
+			if err == nil {
+				_ = pty.SetSize(s.pty, cols, rows)
+			}
+		}
+	}
+}
+
+func terminalType() string {
+	if term := os.Getenv("TERM"); term != "" {
+		return term
+	}
+
+	return "xterm-256color"
+}
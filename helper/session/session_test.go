@@ -0,0 +1,152 @@
+package session
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestScrollback(t *testing.T) {
+	s := newScrollback(8)
+	assert.Equal(t, "", string(s.Bytes()))
+
+	_, _ = s.Write([]byte("abc"))
+	assert.Equal(t, "abc", string(s.Bytes()))
+
+	_, _ = s.Write([]byte("defgh"))
+	assert.Equal(t, "abcdefgh", string(s.Bytes()))
+
+	_, _ = s.Write([]byte("ij"))
+	assert.Equal(t, "cdefghij", string(s.Bytes()))
+
+	_, _ = s.Write([]byte("0123456789"))
+	assert.Equal(t, "23456789", string(s.Bytes()))
+
+	_, _ = s.Write([]byte("xyz"))
+	assert.Equal(t, "56789xyz", string(s.Bytes()))
+}
+
+func TestFrames(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NilError(t, WriteFrame(buf, FrameData, []byte("hello")))
+	assert.NilError(t, WriteFrame(buf, FrameResize, EncodeResize(120, 40)))
+	assert.NilError(t, WriteFrame(buf, FrameExit, EncodeExit(-1)))
+
+	frame, err := ReadFrame(buf)
+	assert.NilError(t, err)
+	assert.Equal(t, FrameData, frame.Type)
+	assert.Equal(t, "hello", string(frame.Payload))
+
+	frame, err = ReadFrame(buf)
+	assert.NilError(t, err)
+	assert.Equal(t, FrameResize, frame.Type)
+	cols, rows, err := DecodeResize(frame.Payload)
+	assert.NilError(t, err)
+	assert.Equal(t, uint16(120), cols)
+	assert.Equal(t, uint16(40), rows)
+
+	frame, err = ReadFrame(buf)
+	assert.NilError(t, err)
+	assert.Equal(t, FrameExit, frame.Type)
+	code, err := DecodeExit(frame.Payload)
+	assert.NilError(t, err)
+	assert.Equal(t, -1, code)
+
+	_, err = ReadFrame(buf)
+	assert.ErrorContains(t, err, "EOF")
+}
+
+func TestValidateName(t *testing.T) {
+	assert.NilError(t, ValidateName("my-session_1.0"))
+	assert.ErrorContains(t, ValidateName("../etc"), "invalid session name")
+	assert.ErrorContains(t, ValidateName(""), "invalid session name")
+}
+
+func TestBroadcastSlowClient(t *testing.T) {
+	defer func(timeout time.Duration) { clientWriteTimeout = timeout }(clientWriteTimeout)
+	clientWriteTimeout = 100 * time.Millisecond
+
+	s := NewServer("test", []string{"sh"})
+	slowConn, slowRemote := net.Pipe()
+	defer slowRemote.Close()
+	fastConn, fastRemote := net.Pipe()
+	defer fastRemote.Close()
+
+	slow := newClient(slowConn)
+	fast := newClient(fastConn)
+	s.clients[slow] = true
+	s.clients[fast] = true
+
+	received := make(chan struct{})
+	go func() {
+		for {
+			_, err := ReadFrame(fastRemote)
+			if err != nil {
+				close(received)
+				return
+			}
+			received <- struct{}{}
+		}
+	}()
+
+	// the slow client never reads, which must neither block the broadcast nor the fast client
+	broadcast := func(frames int) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < frames; i++ {
+				s.broadcast([]byte("output"))
+			}
+		}()
+		for i := 0; i < frames; i++ {
+			select {
+			case <-received:
+			case <-time.After(5 * time.Second):
+				t.Fatal("fast client did not receive the output")
+			}
+		}
+		<-done
+	}
+	broadcast(clientBufferSize)
+	broadcast(2)
+
+	s.clientsMutex.Lock()
+	assert.Equal(t, len(s.clients), 1)
+	assert.Assert(t, s.clients[fast])
+	s.clientsMutex.Unlock()
+
+	fast.close()
+	_, ok := <-received
+	assert.Assert(t, !ok)
+
+	// the write of the slow client times out
+	slow.close()
+}
+
+func TestListen(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "test.sock")
+
+	listener, err := listen(socketPath)
+	assert.NilError(t, err)
+
+	// the socket of a running server is not taken over
+	_, err = listen(socketPath)
+	assert.ErrorContains(t, err, "session is already running")
+	conn, err := net.Dial("unix", socketPath)
+	assert.NilError(t, err)
+	_ = conn.Close()
+	_ = listener.Close()
+
+	// a stale socket is replaced
+	file, err := os.Create(socketPath)
+	assert.NilError(t, err)
+	_ = file.Close()
+	listener, err = listen(socketPath)
+	assert.NilError(t, err)
+	_ = listener.Close()
+}
@@ -0,0 +1,363 @@
+package ssh
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/util"
+	"github.com/loft-sh/devspace/helper/util/pty"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+func logErrorf(message string, args ...interface{}) {
+	_, _ = fmt.Fprintf(os.Stderr, message+"\n", args...)
+}
+
+// StartSSHServer serves a single ssh connection over the given reader and writer. Only
+// clients that authenticate with the given authorized key are accepted.
+func StartSSHServer(reader io.Reader, writer io.Writer, authorizedKey string) error {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return errors.Wrap(err, "parse authorized key")
+	}
+
+	hostKey, err := generateHostKey()
+	if err != nil {
+		return errors.Wrap(err, "generate host key")
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), publicKey.Marshal()) {
+				return &ssh.Permissions{}, nil
+			}
+
+			return nil, fmt.Errorf("unknown public key for %s", conn.User())
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	conn, channels, requests, err := ssh.NewServerConn(util.NewStdStreamJoint(reader, writer, false), config)
+	if err != nil {
+		return errors.Wrap(err, "ssh handshake")
+	}
+	defer conn.Close()
+
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go handleSession(newChannel)
+		case "direct-tcpip":
+			go handleDirectTCPIP(newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type "+newChannel.ChannelType())
+		}
+	}
+
+	return nil
+}
+
+func generateHostKey() (ssh.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.NewSignerFromKey(key)
+}
+
+// directTCPIPPayload is the payload of a direct-tcpip channel request (RFC 4254 7.2)
+type directTCPIPPayload struct {
+	Host       string
+	Port       uint32
+	OriginHost string
+	OriginPort uint32
+}
+
+func handleDirectTCPIP(newChannel ssh.NewChannel) {
+	payload := &directTCPIPPayload{}
+	err := ssh.Unmarshal(newChannel.ExtraData(), payload)
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid payload")
+		return
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer conn.Close()
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(channel, conn)
+		_ = channel.CloseWrite()
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, channel)
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			_ = tcpConn.CloseWrite()
+		}
+		done <- struct{}{}
+	}()
+
+	<-done
+	<-done
+}
+
+type session struct {
+	channel ssh.Channel
+
+	m       sync.Mutex
+	env     []string
+	ptyTerm string
+	ptyCols uint16
+	ptyRows uint16
+	pty     *os.File
+	started bool
+
+	outputDone chan struct{}
+}
+
+func handleSession(newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+
+	s := &session{
+		channel: channel,
+		env:     os.Environ(),
+	}
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			payload := struct{ Name, Value string }{}
+			if ssh.Unmarshal(req.Payload, &payload) == nil {
+				s.m.Lock()
+				s.env = append(s.env, payload.Name+"="+payload.Value)
+				s.m.Unlock()
+			}
+			replyRequest(req, true)
+		case "pty-req":
+			payload := struct {
+				Term          string
+				Columns, Rows uint32
+				Width, Height uint32
+				Modes         string
+			}{}
+			if ssh.Unmarshal(req.Payload, &payload) != nil {
+				replyRequest(req, false)
+				continue
+			}
+
+			s.m.Lock()
+			s.ptyTerm = payload.Term
+			s.ptyCols = uint16(payload.Columns)
+			s.ptyRows = uint16(payload.Rows)
+			s.m.Unlock()
+			replyRequest(req, true)
+		case "window-change":
+			payload := struct {
+				Columns, Rows uint32
+				Width, Height uint32
+			}{}
+			if ssh.Unmarshal(req.Payload, &payload) == nil {
+				s.resize(uint16(payload.Columns), uint16(payload.Rows))
+			}
+			replyRequest(req, true)
+		case "shell", "exec":
+			command := ""
+			if req.Type == "exec" {
+				payload := struct{ Command string }{}
+				if ssh.Unmarshal(req.Payload, &payload) != nil {
+					replyRequest(req, false)
+					continue
+				}
+				command = payload.Command
+			}
+
+			err := s.start(newCommand(command))
+			if err != nil {
+				logErrorf("Error starting ssh session command: %v", err)
+				replyRequest(req, false)
+				continue
+			}
+			replyRequest(req, true)
+		case "subsystem":
+			payload := struct{ Name string }{}
+			if ssh.Unmarshal(req.Payload, &payload) != nil || payload.Name != "sftp" {
+				replyRequest(req, false)
+				continue
+			}
+
+			sftpServer, err := findSFTPServer()
+			if err != nil {
+				logErrorf("Error starting sftp subsystem: %v", err)
+				replyRequest(req, false)
+				continue
+			}
+
+			err = s.start(exec.Command(sftpServer))
+			if err != nil {
+				logErrorf("Error starting sftp subsystem: %v", err)
+				replyRequest(req, false)
+				continue
+			}
+			replyRequest(req, true)
+		default:
+			replyRequest(req, false)
+		}
+	}
+}
+
+func replyRequest(req *ssh.Request, ok bool) {
+	if req.WantReply {
+		_ = req.Reply(ok, nil)
+	}
+}
+
+func (s *session) resize(cols, rows uint16) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.ptyCols = cols
+	s.ptyRows = rows
+	if s.pty != nil {
+		_ = pty.SetSize(s.pty, cols, rows)
+	}
+}
+
+// sftpServerPaths are the locations of the openssh sftp-server binary in common distributions.
+// The helper does not implement sftp itself, but serves the sftp subsystem with this binary
+var sftpServerPaths = []string{
+	"/usr/lib/openssh/sftp-server",
+	"/usr/lib/ssh/sftp-server",
+	"/usr/libexec/openssh/sftp-server",
+	"/usr/libexec/sftp-server",
+}
+
+func findSFTPServer() (string, error) {
+	for _, path := range sftpServerPaths {
+		stat, err := os.Stat(path)
+		if err == nil && !stat.IsDir() {
+			return path, nil
+		}
+	}
+
+	return "", errors.New("no sftp-server binary found in the container, please install openssh-sftp-server (or openssh-server) in the image to use sftp")
+}
+
+func newCommand(command string) *exec.Cmd {
+	if command == "" {
+		return exec.Command("sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash -l || exec sh -l")
+	}
+
+	return exec.Command("sh", "-c", command)
+}
+
+func (s *session) start(cmd *exec.Cmd) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.started {
+		return errors.New("session command already started")
+	}
+	s.started = true
+
+	cmd.Env = s.env
+	if home, err := os.UserHomeDir(); err == nil {
+		cmd.Dir = home
+	}
+
+	if s.ptyTerm != "" {
+		cmd.Env = append(cmd.Env, "TERM="+s.ptyTerm)
+		ptyFile, err := pty.Start(cmd)
+		if err != nil {
+			return err
+		}
+
+		s.pty = ptyFile
+		_ = pty.SetSize(ptyFile, s.ptyCols, s.ptyRows)
+		go func() {
+			_, _ = io.Copy(ptyFile, s.channel)
+		}()
+		s.outputDone = make(chan struct{})
+		go func() {
+			defer close(s.outputDone)
+			_, _ = io.Copy(s.channel, ptyFile)
+		}()
+	} else {
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return err
+		}
+		cmd.Stdout = s.channel
+		cmd.Stderr = s.channel.Stderr()
+		err = cmd.Start()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			defer stdin.Close()
+			_, _ = io.Copy(stdin, s.channel)
+		}()
+	}
+
+	go s.wait(cmd)
+	return nil
+}
+
+func (s *session) wait(cmd *exec.Cmd) {
+	exitCode := 0
+	err := cmd.Wait()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			exitCode = exitError.ExitCode()
+		} else {
+			exitCode = 1
+		}
+	}
+
+	// wait until the remaining pty output was copied
+	if s.outputDone != nil {
+		select {
+		case <-s.outputDone:
+		case <-time.After(time.Second):
+		}
+	}
+
+	s.m.Lock()
+	if s.pty != nil {
+		_ = s.pty.Close()
+	}
+	s.m.Unlock()
+
+	status := make([]byte, 4)
+	binary.BigEndian.PutUint32(status, uint32(exitCode))
+	_, _ = s.channel.SendRequest("exit-status", false, status)
+	_ = s.channel.Close()
+}
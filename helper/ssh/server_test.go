@@ -0,0 +1,160 @@
+package ssh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"gotest.tools/assert"
+)
+
+func TestSSHServerExec(t *testing.T) {
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	signer, err := ssh.NewSignerFromKey(clientKey)
+	assert.NilError(t, err)
+
+	serverConn, clientConn := connPair(t)
+	defer clientConn.Close()
+
+	go func() {
+		_ = StartSSHServer(serverConn, serverConn, string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	}()
+
+	conn, channels, requests, err := ssh.NewClientConn(clientConn, "test", &ssh.ClientConfig{
+		User:            "devspace",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	assert.NilError(t, err)
+
+	client := ssh.NewClient(conn, channels, requests)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	assert.NilError(t, err)
+	out, err := session.Output("echo hello")
+	assert.NilError(t, err)
+	assert.Equal(t, "hello\n", string(out))
+
+	session, err = client.NewSession()
+	assert.NilError(t, err)
+	err = session.Run("exit 3")
+	exitErr, ok := err.(*ssh.ExitError)
+	assert.Assert(t, ok, "expected exit error, got %v", err)
+	assert.Equal(t, 3, exitErr.ExitStatus())
+}
+
+func TestSSHServerSFTPSubsystem(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sftp-server fake is a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "sftp-server")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the fake sftp-server echoes the sftp packets back
+	sftpServer := filepath.Join(dir, "sftp-server")
+	err = ioutil.WriteFile(sftpServer, []byte("#!/bin/sh\nexec cat\n"), 0755)
+	assert.NilError(t, err)
+
+	oldPaths := sftpServerPaths
+	defer func() { sftpServerPaths = oldPaths }()
+	sftpServerPaths = []string{filepath.Join(dir, "missing"), sftpServer}
+
+	client := newTestClient(t)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	assert.NilError(t, err)
+	stdin, err := session.StdinPipe()
+	assert.NilError(t, err)
+	stdout, err := session.StdoutPipe()
+	assert.NilError(t, err)
+	err = session.RequestSubsystem("sftp")
+	assert.NilError(t, err)
+
+	_, err = stdin.Write([]byte("packet"))
+	assert.NilError(t, err)
+	assert.NilError(t, stdin.Close())
+	out, err := ioutil.ReadAll(stdout)
+	assert.NilError(t, err)
+	assert.Equal(t, "packet", string(out))
+
+	sftpServerPaths = []string{filepath.Join(dir, "missing")}
+	session, err = client.NewSession()
+	assert.NilError(t, err)
+	err = session.RequestSubsystem("sftp")
+	assert.ErrorContains(t, err, "ssh: subsystem request failed")
+
+	session, err = client.NewSession()
+	assert.NilError(t, err)
+	err = session.RequestSubsystem("unknown")
+	assert.ErrorContains(t, err, "ssh: subsystem request failed")
+}
+
+func TestSSHServerRejectsUnknownKey(t *testing.T) {
+	allowedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	allowedSigner, err := ssh.NewSignerFromKey(allowedKey)
+	assert.NilError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	otherSigner, err := ssh.NewSignerFromKey(otherKey)
+	assert.NilError(t, err)
+
+	serverConn, clientConn := connPair(t)
+	defer clientConn.Close()
+
+	go func() {
+		_ = StartSSHServer(serverConn, serverConn, string(ssh.MarshalAuthorizedKey(allowedSigner.PublicKey())))
+		_ = serverConn.Close()
+	}()
+
+	_, _, _, err = ssh.NewClientConn(clientConn, "test", &ssh.ClientConfig{
+		User:            "devspace",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(otherSigner)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	assert.ErrorContains(t, err, "unable to authenticate")
+}
+
+func newTestClient(t *testing.T) *ssh.Client {
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	signer, err := ssh.NewSignerFromKey(clientKey)
+	assert.NilError(t, err)
+
+	serverConn, clientConn := connPair(t)
+	go func() {
+		_ = StartSSHServer(serverConn, serverConn, string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	}()
+
+	conn, channels, requests, err := ssh.NewClientConn(clientConn, "test", &ssh.ClientConfig{
+		User:            "devspace",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+	assert.NilError(t, err)
+	return ssh.NewClient(conn, channels, requests)
+}
+
+func connPair(t *testing.T) (net.Conn, net.Conn) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	assert.NilError(t, err)
+	serverConn, err := listener.Accept()
+	assert.NilError(t, err)
+	return serverConn, clientConn
+}
@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package pty
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// Start starts the given command with a newly allocated pseudo terminal
+// as its controlling terminal and returns the master side of it
+func Start(cmd *exec.Cmd) (*os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "open /dev/ptmx")
+	}
+
+	err = unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, errors.Wrap(err, "unlock pty")
+	}
+
+	ptyNumber, err := unix.IoctlGetInt(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		_ = master.Close()
+		return nil, errors.Wrap(err, "get pty number")
+	}
+
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(ptyNumber), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		_ = master.Close()
+		return nil, errors.Wrap(err, "open pty slave")
+	}
+	defer slave.Close()
+
+	cmd.Stdin = slave
+	cmd.Stdout = slave
+	cmd.Stderr = slave
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setsid = true
+	cmd.SysProcAttr.Setctty = true
+
+	err = cmd.Start()
+	if err != nil {
+		_ = master.Close()
+		return nil, err
+	}
+
+	return master, nil
+}
+
+// SetSize sets the window size of the given pty
+func SetSize(pty *os.File, cols, rows uint16) error {
+	return unix.IoctlSetWinsize(int(pty.Fd()), unix.TIOCSWINSZ, &unix.Winsize{
+		Col: cols,
+		Row: rows,
+	})
+}
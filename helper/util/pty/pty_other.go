@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package pty
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// Start is only supported on linux
+func Start(cmd *exec.Cmd) (*os.File, error) {
+	return nil, errors.New("pseudo terminals are only supported on linux")
+}
+
+// SetSize is only supported on linux
+func SetSize(pty *os.File, cols, rows uint16) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspaceplugin "github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin/pluginpb"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Builder builds the image with a builder that is provided by a grpc plugin
+type Builder struct {
+	imageConf *latest.ImageConfig
+
+	imageConfigName string
+	imageTags       []string
+}
+
+// NewBuilder creates a new plugin builder
+func NewBuilder(imageConfigName string, imageConf *latest.ImageConfig, imageTags []string) *Builder {
+	return &Builder{
+		imageConfigName: imageConfigName,
+		imageConf:       imageConf,
+		imageTags:       imageTags,
+	}
+}
+
+// ShouldRebuild implements interface. The plugin decides itself if the image has to be
+// rebuilt, so the build is always started
+func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool, log logpkg.Logger) (bool, error) {
+	return true, nil
+}
+
+// Build implements interface
+func (b *Builder) Build(devspacePID string, log logpkg.Logger) error {
+	p, err := devspaceplugin.FindBuilder(b.imageConf.Build.Plugin.Name)
+	if err != nil {
+		return err
+	}
+
+	imageConfig, err := yaml.Marshal(b.imageConf)
+	if err != nil {
+		return errors.Wrap(err, "marshal image config")
+	}
+
+	log.Infof("Build %s:%s with plugin builder %s", b.imageConf.Image, b.imageTags[0], b.imageConf.Build.Plugin.Name)
+	return p.Build(&pluginpb.BuildRequest{
+		Builder:         b.imageConf.Build.Plugin.Name,
+		ImageConfigName: b.imageConfigName,
+		ImageConfig:     string(imageConfig),
+		Tags:            b.imageTags,
+	}, log)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/custom"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -25,6 +26,8 @@ func (c *controller) createBuilder(imageConfigName string, imageConf *latest.Ima
 
 	if imageConf.Build != nil && imageConf.Build.Custom != nil {
 		builder = custom.NewBuilder(imageConfigName, imageConf, imageTags)
+	} else if imageConf.Build != nil && imageConf.Build.Plugin != nil {
+		builder = plugin.NewBuilder(imageConfigName, imageConf, imageTags)
 	} else if imageConf.Build != nil && imageConf.Build.BuildKit != nil {
 		log.StartWait("Creating BuildKit builder")
 		defer log.StopWait()
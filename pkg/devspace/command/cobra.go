@@ -0,0 +1,305 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// FlagEnvPrefix is the prefix of the environment variables that hold the flag values
+	FlagEnvPrefix = "DEVSPACE_FLAG_"
+
+	// ArgEnvPrefix is the prefix of the environment variables that hold the positional argument values
+	ArgEnvPrefix = "DEVSPACE_ARG_"
+)
+
+// IsStructured returns true if the command declares flags, positional arguments or sub-commands.
+// The arguments of these commands are parsed by devspace instead of passed through.
+func IsStructured(command *latest.CommandConfig) bool {
+	return len(command.Flags) > 0 || len(command.PositionalArgs) > 0 || len(command.Commands) > 0
+}
+
+// AddCobraCommands registers the commands under the given parent command, so that they are
+// listed in its help text and included in the generated shell completion. The registered commands
+// do not parse their arguments themselves, they pass them to runE instead, which is expected to
+// load the config and execute the command with ExecuteCommandWithOptions.
+func AddCobraCommands(parent *cobra.Command, commands []*latest.CommandConfig, runE func(cobraCmd *cobra.Command, args []string) error) {
+	for _, command := range commands {
+		if command == nil || command.Name == "" {
+			continue
+		}
+
+		var cobraCmd *cobra.Command
+		if IsStructured(command) {
+			cobraCmd = NewCobraCommand(command, &Options{})
+		} else {
+			cobraCmd = &cobra.Command{
+				Use:   command.Name,
+				Short: command.Description,
+			}
+		}
+
+		passThrough(cobraCmd, runE)
+		parent.AddCommand(cobraCmd)
+	}
+}
+
+func passThrough(cobraCmd *cobra.Command, runE func(cobraCmd *cobra.Command, args []string) error) {
+	cobraCmd.DisableFlagParsing = true
+	cobraCmd.Args = cobra.ArbitraryArgs
+	cobraCmd.RunE = runE
+	for _, subCommand := range cobraCmd.Commands() {
+		passThrough(subCommand, runE)
+	}
+}
+
+// executeStructured parses the args with cobra and executes the selected (sub-)command. The
+// commands registered under devspace run with AddCobraCommands are built from the config before
+// its variables are resolved, which is why the arguments are parsed here again with the command
+// of the loaded config.
+func executeStructured(command *latest.CommandConfig, args []string, options *Options) error {
+	root := &cobra.Command{
+		Use:           "devspace",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	run := &cobra.Command{
+		Use: "run",
+	}
+
+	root.AddCommand(run)
+	run.AddCommand(NewCobraCommand(command, options))
+	root.SetOut(options.Stdout)
+	root.SetErr(options.Stderr)
+	root.SetArgs(append([]string{"run", command.Name}, args...))
+	return root.Execute()
+}
+
+// NewCobraCommand creates a cobra command with the declared flags, positional arguments and
+// sub-commands of the command, which generates the help text and parses the arguments
+func NewCobraCommand(command *latest.CommandConfig, options *Options) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:           usage(command),
+		Short:         command.Description,
+		Long:          long(command),
+		Args:          positionalArgs(command),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	for _, flag := range command.Flags {
+		addFlag(cobraCmd.Flags(), flag)
+		if flag.Required {
+			_ = cobraCmd.MarkFlagRequired(flag.Name)
+		}
+	}
+
+	if command.Command != "" {
+		cobraCmd.RunE = func(cobraCmd *cobra.Command, args []string) error {
+			env, err := environment(command, cobraCmd.Flags(), args)
+			if err != nil {
+				return err
+			}
+
+			return execute(command, args, env, options)
+		}
+	}
+
+	for _, subCommand := range command.Commands {
+		cobraCmd.AddCommand(NewCobraCommand(subCommand, options))
+	}
+
+	return cobraCmd
+}
+
+func usage(command *latest.CommandConfig) string {
+	out := command.Name
+	if len(command.Commands) > 0 && command.Command == "" {
+		return out + " [command]"
+	}
+	if len(command.Flags) > 0 {
+		out += " [flags]"
+	}
+	for _, arg := range command.PositionalArgs {
+		name := arg.Name
+		if arg.Type == latest.CommandArgTypeList {
+			name += "..."
+		}
+		if arg.Required {
+			out += " <" + name + ">"
+		} else {
+			out += " [" + name + "]"
+		}
+	}
+
+	return out
+}
+
+func long(command *latest.CommandConfig) string {
+	out := command.Description
+	if len(command.PositionalArgs) == 0 {
+		return out
+	}
+
+	out += "\n\nArguments:\n"
+	for _, arg := range command.PositionalArgs {
+		line := "  " + arg.Name
+		if arg.Description != "" {
+			line += "\t" + arg.Description
+		}
+		if arg.Default != nil {
+			line += fmt.Sprintf(" (default %v)", arg.Default)
+		}
+		out += line + "\n"
+	}
+
+	return strings.TrimSpace(out)
+}
+
+func addFlag(flags *pflag.FlagSet, flag *latest.CommandFlag) {
+	switch flag.Type {
+	case latest.CommandArgTypeInt:
+		defaultValue, _ := strconv.Atoi(defaultString(flag.Default))
+		flags.IntP(flag.Name, flag.Short, defaultValue, flag.Description)
+	case latest.CommandArgTypeBool:
+		defaultValue, _ := strconv.ParseBool(defaultString(flag.Default))
+		flags.BoolP(flag.Name, flag.Short, defaultValue, flag.Description)
+	case latest.CommandArgTypeList:
+		flags.StringSliceP(flag.Name, flag.Short, defaultList(flag.Default), flag.Description)
+	default:
+		flags.StringP(flag.Name, flag.Short, defaultString(flag.Default), flag.Description)
+	}
+}
+
+func positionalArgs(command *latest.CommandConfig) cobra.PositionalArgs {
+	return func(cobraCmd *cobra.Command, args []string) error {
+		// commands without declared arguments receive all arguments as they are
+		if len(command.PositionalArgs) == 0 {
+			return nil
+		}
+
+		last := command.PositionalArgs[len(command.PositionalArgs)-1]
+		if last.Type != latest.CommandArgTypeList && len(args) > len(command.PositionalArgs) {
+			return errors.Errorf("%s accepts at most %d argument(s), but received %d", cobraCmd.CommandPath(), len(command.PositionalArgs), len(args))
+		}
+
+		for index, arg := range command.PositionalArgs {
+			if index >= len(args) {
+				if arg.Required {
+					return errors.Errorf("missing required argument %s, see '%s --help'", arg.Name, cobraCmd.CommandPath())
+				}
+
+				continue
+			}
+
+			values := []string{args[index]}
+			if arg.Type == latest.CommandArgTypeList {
+				values = args[index:]
+			}
+			for _, value := range values {
+				err := validateType(arg.Type, value)
+				if err != nil {
+					return errors.Wrapf(err, "argument %s", arg.Name)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateType(argType latest.CommandArgType, value string) error {
+	switch argType {
+	case latest.CommandArgTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return errors.Errorf("expected an integer, but got %s", value)
+		}
+	case latest.CommandArgTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.Errorf("expected a boolean, but got %s", value)
+		}
+	}
+
+	return nil
+}
+
+// environment returns the environment variables that expose the flags and positional
+// arguments to the command
+func environment(command *latest.CommandConfig, flags *pflag.FlagSet, args []string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, flag := range command.Flags {
+		value := ""
+		if flag.Type == latest.CommandArgTypeList {
+			list, err := flags.GetStringSlice(flag.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			value = strings.Join(list, ",")
+		} else {
+			value = flags.Lookup(flag.Name).Value.String()
+		}
+
+		env[EnvName(FlagEnvPrefix, flag.Name)] = value
+	}
+
+	for index, arg := range command.PositionalArgs {
+		value := ""
+		if index < len(args) {
+			value = args[index]
+			if arg.Type == latest.CommandArgTypeList {
+				value = strings.Join(args[index:], ",")
+			}
+		} else if arg.Default != nil {
+			value = defaultString(arg.Default)
+			if arg.Type == latest.CommandArgTypeList {
+				value = strings.Join(defaultList(arg.Default), ",")
+			}
+		}
+
+		env[EnvName(ArgEnvPrefix, arg.Name)] = value
+	}
+
+	return env, nil
+}
+
+// EnvName returns the environment variable name for the given flag or argument name,
+// e.g. DEVSPACE_FLAG_DRY_RUN for dry-run
+func EnvName(prefix, name string) string {
+	return prefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+func defaultString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+func defaultList(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		list := []string{}
+		for _, item := range v {
+			list = append(list, fmt.Sprintf("%v", item))
+		}
+		return list
+	case string:
+		if v == "" {
+			return []string{}
+		}
+
+		return strings.Split(v, ",")
+	}
+
+	return []string{fmt.Sprintf("%v", value)}
+}
@@ -0,0 +1,128 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+var testCommands = []*latest.CommandConfig{
+	{
+		Name:        "deploy",
+		Command:     `echo "$DEVSPACE_FLAG_REPLICAS $DEVSPACE_FLAG_DRY_RUN $DEVSPACE_FLAG_TAGS $DEVSPACE_ARG_ENV $DEVSPACE_ARG_SERVICES" "$@"`,
+		Description: "Deploys the application",
+		Flags: []*latest.CommandFlag{
+			{Name: "replicas", Short: "r", Type: latest.CommandArgTypeInt, Default: 1},
+			{Name: "dry-run", Type: latest.CommandArgTypeBool},
+			{Name: "tags", Type: latest.CommandArgTypeList, Default: []interface{}{"latest"}},
+		},
+		PositionalArgs: []*latest.CommandArg{
+			{Name: "env", Required: true, Description: "Environment to deploy to"},
+			{Name: "services", Type: latest.CommandArgTypeList},
+		},
+	},
+	{
+		Name:        "db",
+		Description: "Database commands",
+		Commands: []*latest.CommandConfig{
+			{
+				Name:    "migrate",
+				Command: `echo "migrate $DEVSPACE_FLAG_STEPS"`,
+				Flags: []*latest.CommandFlag{
+					{Name: "steps", Type: latest.CommandArgTypeInt, Required: true},
+				},
+			},
+		},
+	},
+}
+
+type structuredTestCase struct {
+	args           []string
+	expectedOutput string
+	expectedError  string
+}
+
+func TestExecuteStructuredCommand(t *testing.T) {
+	testCases := map[string]structuredTestCase{
+		"Defaults": {
+			args:           []string{"deploy", "dev"},
+			expectedOutput: "1 false latest dev  dev\n",
+		},
+		"Flags and list argument": {
+			args:           []string{"deploy", "-r", "3", "--dry-run", "--tags", "a,b", "prod", "api", "web"},
+			expectedOutput: "3 true a,b prod api,web prod api web\n",
+		},
+		"Missing argument": {
+			args:          []string{"deploy"},
+			expectedError: "missing required argument env, see 'devspace run deploy --help'",
+		},
+		"Invalid flag type": {
+			args:          []string{"deploy", "--replicas", "many", "dev"},
+			expectedError: `invalid argument "many" for "-r, --replicas" flag: strconv.ParseInt: parsing "many": invalid syntax`,
+		},
+		"Sub-command": {
+			args:           []string{"db", "migrate", "--steps", "2"},
+			expectedOutput: "migrate 2\n",
+		},
+		"Required flag": {
+			args:          []string{"db", "migrate"},
+			expectedError: `required flag(s) "steps" not set`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		stdout := &bytes.Buffer{}
+		err := ExecuteCommand(testCommands, testCase.args[0], testCase.args[1:], stdout, stdout)
+		if testCase.expectedError != "" {
+			assert.Error(t, err, testCase.expectedError, name)
+			continue
+		}
+
+		assert.NilError(t, err, name)
+		assert.Equal(t, stdout.String(), testCase.expectedOutput, name)
+	}
+}
+
+func TestStructuredCommandHelp(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	err := ExecuteCommand(testCommands, "deploy", []string{"--help"}, stdout, stdout)
+	assert.NilError(t, err)
+
+	help := stdout.String()
+	for _, expected := range []string{"devspace run deploy [flags] <env> [services...]", "Environment to deploy to", "-r, --replicas int", "--tags strings"} {
+		assert.Assert(t, strings.Contains(help, expected), "expected %q in help:\n%s", expected, help)
+	}
+}
+
+func TestAddCobraCommands(t *testing.T) {
+	var executed []string
+	root := &cobra.Command{Use: "devspace"}
+	run := &cobra.Command{Use: "run"}
+	root.AddCommand(run)
+	AddCobraCommands(run, append(testCommands, &latest.CommandConfig{Name: "plain", Command: "echo plain", Description: "A plain command"}), func(cobraCmd *cobra.Command, args []string) error {
+		executed = append([]string{cobraCmd.CommandPath()}, args...)
+		return nil
+	})
+
+	stdout := &bytes.Buffer{}
+	run.SetOut(stdout)
+	assert.NilError(t, run.Help())
+	for _, expected := range []string{"deploy      Deploys the application", "db          Database commands", "plain       A plain command"} {
+		assert.Assert(t, strings.Contains(stdout.String(), expected), "expected %q in help:\n%s", expected, stdout.String())
+	}
+
+	completion := &bytes.Buffer{}
+	assert.NilError(t, root.GenBashCompletion(completion))
+	for _, expected := range []string{"_devspace_run_deploy()", "_devspace_run_db_migrate()", "flags+=(\"--replicas=\")"} {
+		assert.Assert(t, strings.Contains(completion.String(), expected), "expected %q in completion", expected)
+	}
+
+	// the arguments are passed through without being parsed or validated
+	root.SetArgs([]string{"run", "db", "migrate", "--unknown", "1"})
+	assert.NilError(t, root.Execute())
+	assert.DeepEqual(t, executed, []string{"devspace run db migrate", "--unknown", "1"})
+}
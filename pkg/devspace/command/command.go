@@ -1,10 +1,15 @@
 package command
 
 import (
+	"context"
 	"io"
+	"os"
+	"sort"
 	"strings"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/util/command"
+	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/shell"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -12,38 +17,142 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ContainerExecuter executes commands within a container
+type ContainerExecuter interface {
+	ExecuteCommandInContainer(container *latest.CommandContainer, command []string, stdout io.Writer, stderr io.Writer, stdin io.Reader) error
+}
+
+// Options holds the options for executing a command
+type Options struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+
+	// ContainerExecuter executes commands that define a container. If it is nil, these
+	// commands cannot be executed.
+	ContainerExecuter ContainerExecuter
+
+	// Cache stores the hashes of the inputs and outputs of executed commands. If it is nil,
+	// commands are always executed.
+	Cache *generated.CacheConfig
+
+	// Log is used to print which commands are skipped
+	Log log.Logger
+}
+
 // ExecuteCommand executes a command from the config
 func ExecuteCommand(commands []*latest.CommandConfig, name string, args []string, stdout io.Writer, stderr io.Writer) error {
-	shellCommand := ""
-	var shellArgs []string
-	var appendArgs bool
+	return ExecuteCommandWithOptions(commands, name, args, &Options{
+		Stdout: stdout,
+		Stderr: stderr,
+		Stdin:  os.Stdin,
+	})
+}
+
+// ExecuteCommandWithOptions executes a command from the config with the given options
+func ExecuteCommandWithOptions(commands []*latest.CommandConfig, name string, args []string, options *Options) error {
 	for _, cmd := range commands {
 		if cmd.Name == name {
-			shellCommand = cmd.Command
-			shellArgs = cmd.Args
-			appendArgs = cmd.AppendArgs
-			break
+			return newPipeline(commands, options).Run(cmd, args)
+		}
+	}
+
+	return errors.Errorf("couldn't find command '%s' in devspace config", name)
+}
+
+// NeedsContainer returns true if the command or one of its sub-commands is executed in a container
+func NeedsContainer(command *latest.CommandConfig) bool {
+	if command.Container != nil {
+		return true
+	}
+	for _, subCommand := range command.Commands {
+		if NeedsContainer(subCommand) {
+			return true
 		}
 	}
 
+	return false
+}
+
+func execute(cmd *latest.CommandConfig, args []string, env map[string]string, options *Options) error {
+	shellCommand := cmd.Command
 	if shellCommand == "" {
-		return errors.Errorf("couldn't find command '%s' in devspace config", name)
+		return errors.Errorf("couldn't find command '%s' in devspace config", cmd.Name)
 	}
 
-	if shellArgs == nil {
-		if appendArgs {
-			// Append args to shell command
-			for _, arg := range args {
-				arg = strings.Replace(arg, "'", "'\"'\"'", -1)
+	if cmd.Args == nil && cmd.AppendArgs {
+		// Append args to shell command
+		for _, arg := range args {
+			shellCommand += " " + quote(arg)
+		}
+	}
 
-				shellCommand += " '" + arg + "'"
-			}
+	if cmd.Container != nil {
+		if options.ContainerExecuter == nil {
+			return errors.Errorf("command '%s' should be executed in a container, but no kubernetes client is available", cmd.Name)
 		}
 
+		return options.ContainerExecuter.ExecuteCommandInContainer(cmd.Container, containerCommand(cmd, shellCommand, args, env), options.Stdout, options.Stderr, options.Stdin)
+	}
+
+	if cmd.Args == nil {
 		// execute the command in a shell
-		return shell.ExecuteShellCommand(shellCommand, args, "", stdout, stderr, nil)
+		return shell.ExecuteShellCommandWithStdin(context.Background(), shellCommand, args, "", options.Stdin, options.Stdout, options.Stderr, env)
 	}
 
+	shellArgs := append([]string{}, cmd.Args...)
 	shellArgs = append(shellArgs, args...)
-	return command.ExecuteCommand(shellCommand, shellArgs, stdout, stderr)
+	return command.ExecuteCommandWithEnv(shellCommand, shellArgs, "", options.Stdout, options.Stderr, env)
+}
+
+// containerCommand returns the command that is executed in the container. Shell commands are
+// executed with sh and receive the args as positional parameters, the environment variables
+// are exported before the command is executed.
+func containerCommand(cmd *latest.CommandConfig, shellCommand string, args []string, env map[string]string) []string {
+	names := []string{}
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if cmd.Args == nil {
+		script := ""
+		for _, name := range names {
+			script += "export " + name + "=" + quote(env[name]) + "; "
+		}
+		if cmd.Container.WorkingDir != "" {
+			script += "cd " + quote(cmd.Container.WorkingDir) + " && "
+		}
+
+		return append([]string{"sh", "-c", script + shellCommand, "sh"}, args...)
+	}
+
+	command := []string{}
+	if len(names) > 0 || cmd.Container.WorkingDir != "" {
+		command = append(command, "env")
+		for _, name := range names {
+			command = append(command, name+"="+env[name])
+		}
+	}
+	command = append(command, cmd.Command)
+	command = append(command, cmd.Args...)
+	command = append(command, args...)
+	if cmd.Container.WorkingDir != "" {
+		return []string{"sh", "-c", "cd " + quote(cmd.Container.WorkingDir) + " && exec " + quoteAll(command)}
+	}
+
+	return command
+}
+
+func quote(arg string) string {
+	return "'" + strings.Replace(arg, "'", "'\"'\"'", -1) + "'"
+}
+
+func quoteAll(args []string) string {
+	quoted := []string{}
+	for _, arg := range args {
+		quoted = append(quoted, quote(arg))
+	}
+
+	return strings.Join(quoted, " ")
 }
@@ -3,9 +3,12 @@ package command
 import (
 	"bytes"
 	"context"
+	"io"
 	"strings"
 	"testing"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
 	"mvdan.cc/sh/v3/interp"
 	"mvdan.cc/sh/v3/syntax"
 )
@@ -41,3 +44,75 @@ func TestCommand(t *testing.T) {
 		t.Fatalf("Expected stdout '1234'56', got stdout '%s' - stderr '%s'", stdout.String(), stderr.String())
 	}
 }
+
+type fakeContainerExecuter struct {
+	container *latest.CommandContainer
+	command   []string
+}
+
+func (f *fakeContainerExecuter) ExecuteCommandInContainer(container *latest.CommandContainer, command []string, stdout io.Writer, stderr io.Writer, stdin io.Reader) error {
+	f.container = container
+	f.command = command
+	return nil
+}
+
+type containerTestCase struct {
+	command         *latest.CommandConfig
+	args            []string
+	expectedCommand []string
+}
+
+func TestExecuteCommandInContainer(t *testing.T) {
+	container := &latest.CommandContainer{ImageSelector: "image(api)"}
+	testCases := map[string]containerTestCase{
+		"Shell command": {
+			command:         &latest.CommandConfig{Name: "test", Command: "npm test", AppendArgs: true, Container: container},
+			args:            []string{"--watch", "it's"},
+			expectedCommand: []string{"sh", "-c", "npm test '--watch' 'it'\"'\"'s'", "sh", "--watch", "it's"},
+		},
+		"Shell command with working dir": {
+			command:         &latest.CommandConfig{Name: "test", Command: "npm test", Container: &latest.CommandContainer{Pod: "api", WorkingDir: "/app"}},
+			expectedCommand: []string{"sh", "-c", "cd '/app' && npm test", "sh"},
+		},
+		"Command with args": {
+			command:         &latest.CommandConfig{Name: "test", Command: "npm", Args: []string{"test"}, Container: container},
+			args:            []string{"--watch"},
+			expectedCommand: []string{"npm", "test", "--watch"},
+		},
+		"Structured command": {
+			command: &latest.CommandConfig{
+				Name:      "test",
+				Command:   "npm test",
+				Flags:     []*latest.CommandFlag{{Name: "watch", Type: latest.CommandArgTypeBool}},
+				Container: container,
+			},
+			args:            []string{"--watch"},
+			expectedCommand: []string{"sh", "-c", "export DEVSPACE_FLAG_WATCH='true'; npm test", "sh"},
+		},
+		"Structured command with args": {
+			command: &latest.CommandConfig{
+				Name:      "test",
+				Command:   "npm",
+				Args:      []string{"test"},
+				Flags:     []*latest.CommandFlag{{Name: "watch", Type: latest.CommandArgTypeBool}},
+				Container: &latest.CommandContainer{Pod: "api", WorkingDir: "/app"},
+			},
+			expectedCommand: []string{"sh", "-c", "cd '/app' && exec 'env' 'DEVSPACE_FLAG_WATCH=false' 'npm' 'test'"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		executer := &fakeContainerExecuter{}
+		err := ExecuteCommandWithOptions([]*latest.CommandConfig{testCase.command}, testCase.command.Name, testCase.args, &Options{
+			Stdout:            &bytes.Buffer{},
+			Stderr:            &bytes.Buffer{},
+			ContainerExecuter: executer,
+		})
+		assert.NilError(t, err, "Error in testCase %s", testName)
+		assert.Equal(t, executer.container, testCase.command.Container, "Wrong container in testCase %s", testName)
+		assert.DeepEqual(t, executer.command, testCase.expectedCommand)
+	}
+
+	err := ExecuteCommandWithOptions([]*latest.CommandConfig{{Name: "test", Command: "npm test", Container: container}}, "test", nil, &Options{})
+	assert.Error(t, err, "command 'test' should be executed in a container, but no kubernetes client is available")
+}
@@ -0,0 +1,266 @@
+package command
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// pipeline executes a command after all commands it depends on. Commands that do not depend on
+// each other are executed in parallel and every command is executed at most once. Commands
+// with inputs are skipped if their inputs and outputs have not changed since the last run.
+type pipeline struct {
+	commands map[string]*latest.CommandConfig
+	options  *Options
+
+	tasksMutex sync.Mutex
+	tasks      map[string]*task
+
+	cacheMutex sync.Mutex
+}
+
+type task struct {
+	done chan struct{}
+	err  error
+}
+
+func newPipeline(commands []*latest.CommandConfig, options *Options) *pipeline {
+	byName := map[string]*latest.CommandConfig{}
+	for _, cmd := range commands {
+		if _, ok := byName[cmd.Name]; !ok {
+			byName[cmd.Name] = cmd
+		}
+	}
+
+	return &pipeline{
+		commands: byName,
+		options:  options,
+		tasks:    map[string]*task{},
+	}
+}
+
+// Run executes the dependencies of the command and the command itself with the given args
+func (p *pipeline) Run(cmd *latest.CommandConfig, args []string) error {
+	return p.run(cmd, args, p.options)
+}
+
+func (p *pipeline) run(cmd *latest.CommandConfig, args []string, options *Options) error {
+	err := p.runDependencies(cmd, options)
+	if err != nil {
+		return err
+	}
+
+	return p.execute(cmd, args, options)
+}
+
+// runTask executes the command without args, if it wasn't executed before, and waits
+// until it has finished
+func (p *pipeline) runTask(name string, options *Options) error {
+	p.tasksMutex.Lock()
+	t, ok := p.tasks[name]
+	if ok {
+		p.tasksMutex.Unlock()
+		<-t.done
+		return t.err
+	}
+
+	t = &task{done: make(chan struct{})}
+	p.tasks[name] = t
+	p.tasksMutex.Unlock()
+
+	defer close(t.done)
+	cmd, ok := p.commands[name]
+	if !ok {
+		t.err = errors.Errorf("couldn't find command '%s' in devspace config", name)
+		return t.err
+	}
+
+	t.err = p.run(cmd, nil, options)
+	return t.err
+}
+
+func (p *pipeline) runDependencies(cmd *latest.CommandConfig, options *Options) error {
+	if len(cmd.DependsOn) == 0 {
+		return nil
+	} else if len(cmd.DependsOn) == 1 {
+		return p.runTask(cmd.DependsOn[0], options)
+	}
+
+	errs := make([]error, len(cmd.DependsOn))
+	wg := sync.WaitGroup{}
+	outputMutex := &sync.Mutex{}
+	for i, dependency := range cmd.DependsOn {
+		wg.Add(1)
+		go func(i int, dependency string) {
+			defer wg.Done()
+			errs[i] = p.runParallelTask(dependency, options, outputMutex)
+		}(i, dependency)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runParallelTask runs the task with its own output, which prefixes every line with the name of
+// the command. Commands that run in parallel cannot share the input, so they don't receive any.
+func (p *pipeline) runParallelTask(name string, options *Options, outputMutex *sync.Mutex) error {
+	taskOptions := *options
+	taskOptions.Stdin = bytes.NewReader(nil)
+	if options.Stdout != nil {
+		stdout := newPrefixWriter(options.Stdout, "["+name+"] ", outputMutex)
+		defer func() { _ = stdout.Flush() }()
+		taskOptions.Stdout = stdout
+	}
+	if options.Stderr != nil {
+		stderr := newPrefixWriter(options.Stderr, "["+name+"] ", outputMutex)
+		defer func() { _ = stderr.Flush() }()
+		taskOptions.Stderr = stderr
+	}
+
+	return p.runTask(name, &taskOptions)
+}
+
+func (p *pipeline) execute(cmd *latest.CommandConfig, args []string, options *Options) error {
+	if options.Cache == nil || len(cmd.Inputs) == 0 {
+		return executeCommand(cmd, args, options)
+	}
+
+	inputs, err := inputsHash(cmd, args)
+	if err != nil {
+		return errors.Wrapf(err, "hash inputs of command %s", cmd.Name)
+	}
+	outputs, err := outputsHash(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "hash outputs of command %s", cmd.Name)
+	}
+
+	p.cacheMutex.Lock()
+	commandCache := options.Cache.GetCommandCache(cmd.Name)
+	skip := outputs != "" && commandCache.InputsHash == inputs && commandCache.OutputsHash == outputs
+	p.cacheMutex.Unlock()
+	if skip {
+		p.log().Infof("Skip command '%s', because its inputs have not changed", cmd.Name)
+		return nil
+	}
+
+	err = executeCommand(cmd, args, options)
+	if err != nil {
+		return err
+	}
+
+	outputs, err = outputsHash(cmd)
+	if err != nil {
+		return errors.Wrapf(err, "hash outputs of command %s", cmd.Name)
+	}
+
+	p.cacheMutex.Lock()
+	defer p.cacheMutex.Unlock()
+	commandCache = options.Cache.GetCommandCache(cmd.Name)
+	commandCache.InputsHash = inputs
+	commandCache.OutputsHash = outputs
+	return nil
+}
+
+func (p *pipeline) log() log.Logger {
+	if p.options.Log == nil {
+		return log.Discard
+	}
+
+	return p.options.Log
+}
+
+func executeCommand(cmd *latest.CommandConfig, args []string, options *Options) error {
+	if IsStructured(cmd) {
+		return executeStructured(cmd, args, options)
+	}
+
+	return execute(cmd, args, nil, options)
+}
+
+// inputsHash hashes the command config, the args and all files that match the input patterns
+func inputsHash(cmd *latest.CommandConfig, args []string) (string, error) {
+	configStr, err := yaml.Marshal(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	filesHash, _, err := globHash(cmd.Inputs)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(string(configStr) + strings.Join(args, " ") + filesHash), nil
+}
+
+// outputsHash hashes all files that match the output patterns. If the command has outputs and
+// none of them exist, an empty string is returned
+func outputsHash(cmd *latest.CommandConfig) (string, error) {
+	filesHash, found, err := globHash(cmd.Outputs)
+	if err != nil {
+		return "", err
+	} else if len(cmd.Outputs) > 0 && !found {
+		return "", nil
+	}
+
+	return filesHash, nil
+}
+
+func globHash(patterns []string) (string, bool, error) {
+	found := false
+	filesHash := ""
+	for _, pattern := range patterns {
+		files, err := doublestar.Glob(pattern)
+		if err != nil {
+			return "", false, err
+		}
+
+		for _, file := range files {
+			sha256, err := hash.Directory(file)
+			if err != nil {
+				return "", false, errors.Wrap(err, "hash "+file)
+			}
+
+			found = true
+			filesHash += file + sha256
+		}
+	}
+
+	return hash.String(filesHash), found, nil
+}
+
+// Dependencies returns the command and all commands it depends on directly or indirectly
+func Dependencies(commands []*latest.CommandConfig, name string) []*latest.CommandConfig {
+	byName := newPipeline(commands, nil).commands
+	visited := map[string]bool{}
+	ret := []*latest.CommandConfig{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		cmd, ok := byName[name]
+		if !ok || visited[name] {
+			return
+		}
+
+		visited[name] = true
+		ret = append(ret, cmd)
+		for _, dependency := range cmd.DependsOn {
+			visit(dependency)
+		}
+	}
+
+	visit(name)
+	return ret
+}
@@ -0,0 +1,154 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+
+	defer func() {
+		err = os.Chdir(wdBackup)
+		if err != nil {
+			t.Fatalf("Error changing dir back: %v", err)
+		}
+		err = os.RemoveAll(dir)
+		if err != nil {
+			t.Fatalf("Error removing dir: %v", err)
+		}
+	}()
+
+	commands := []*latest.CommandConfig{
+		{
+			Name:    "generate",
+			Command: "echo generate >> log.txt && echo generated > out.txt",
+			Inputs:  []string{"*.in"},
+			Outputs: []string{"out.txt"},
+		},
+		{
+			Name:      "lint",
+			Command:   "echo lint > lint.txt",
+			DependsOn: []string{"generate"},
+		},
+		{
+			Name:      "test",
+			Command:   `echo test "$@" >> log.txt`,
+			DependsOn: []string{"generate", "lint"},
+		},
+	}
+
+	err = ioutil.WriteFile("source.in", []byte("a"), 0666)
+	assert.NilError(t, err)
+
+	cache := generated.NewCache()
+	run := func(args ...string) {
+		err := ExecuteCommandWithOptions(commands, "test", args, &Options{
+			Stdout: &bytes.Buffer{},
+			Stderr: &bytes.Buffer{},
+			Cache:  cache,
+		})
+		assert.NilError(t, err)
+	}
+	assertLog := func(expected string) {
+		out, err := ioutil.ReadFile("log.txt")
+		assert.NilError(t, err)
+		assert.Equal(t, string(out), expected)
+	}
+
+	run("1")
+	assertLog("generate\ntest 1\n")
+	_, err = os.Stat("lint.txt")
+	assert.NilError(t, err)
+
+	// generate is skipped, because its inputs have not changed
+	run("2")
+	assertLog("generate\ntest 1\ntest 2\n")
+
+	// generate is executed again, because its inputs have changed
+	err = ioutil.WriteFile("source.in", []byte("b"), 0666)
+	assert.NilError(t, err)
+	run("3")
+	assertLog("generate\ntest 1\ntest 2\ngenerate\ntest 3\n")
+
+	// generate is executed again, because its output was removed
+	err = os.Remove("out.txt")
+	assert.NilError(t, err)
+	run("4")
+	assertLog("generate\ntest 1\ntest 2\ngenerate\ntest 3\ngenerate\ntest 4\n")
+
+	// without a cache every command is executed
+	cache = nil
+	run("5")
+	assertLog("generate\ntest 1\ntest 2\ngenerate\ntest 3\ngenerate\ntest 4\ngenerate\ntest 5\n")
+}
+
+func TestDependencies(t *testing.T) {
+	commands := []*latest.CommandConfig{
+		{Name: "a", DependsOn: []string{"b", "c"}},
+		{Name: "b", DependsOn: []string{"c"}},
+		{Name: "c"},
+		{Name: "d"},
+	}
+
+	names := []string{}
+	for _, command := range Dependencies(commands, "a") {
+		names = append(names, command.Name)
+	}
+	assert.DeepEqual(t, names, []string{"a", "b", "c"})
+}
+
+func TestPipelineParallelOutput(t *testing.T) {
+	commands := []*latest.CommandConfig{
+		{Name: "api", Command: `echo "building api"; echo "api done"; read input || echo "no input"`},
+		{Name: "ui", Command: `printf "building ui"`},
+		{Name: "all", Command: "echo all", DependsOn: []string{"api", "ui"}},
+	}
+
+	stdout := &bytes.Buffer{}
+	err := ExecuteCommandWithOptions(commands, "all", nil, &Options{
+		Stdout: stdout,
+		Stderr: stdout,
+		Stdin:  bytes.NewBufferString("terminal input\n"),
+	})
+	assert.NilError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	sort.Strings(lines[:len(lines)-1])
+	assert.DeepEqual(t, lines, []string{"[api] api done", "[api] building api", "[api] no input", "[ui] building ui", "all"})
+}
+
+func TestPrefixWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	writer := newPrefixWriter(out, "[api] ", &sync.Mutex{})
+
+	_, err := writer.Write([]byte("first\nsec"))
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "[api] first\n")
+
+	_, err = writer.Write([]byte("ond\nthird"))
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Flush())
+	assert.Equal(t, out.String(), "[api] first\n[api] second\n[api] third\n")
+}
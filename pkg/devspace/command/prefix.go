@@ -0,0 +1,64 @@
+package command
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// prefixWriter is an io.Writer that prefixes every complete line with the name of the command,
+// so that the output of commands that are executed in parallel can be told apart. All writers
+// of the same output share the mutex, so that lines are written as a whole.
+type prefixWriter struct {
+	m      *sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buffer bytes.Buffer
+}
+
+func newPrefixWriter(out io.Writer, prefix string, m *sync.Mutex) *prefixWriter {
+	return &prefixWriter{
+		m:      m,
+		out:    out,
+		prefix: []byte(prefix),
+	}
+}
+
+// Write implements io.Writer
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	w.buffer.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buffer.Bytes(), '\n')
+		if idx == -1 {
+			break
+		}
+
+		err := w.writeLine(w.buffer.Next(idx + 1))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes a remaining incomplete line
+func (w *prefixWriter) Flush() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	line := append(w.buffer.Next(w.buffer.Len()), '\n')
+	return w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	_, err := w.out.Write(append(append([]byte{}, w.prefix...), line...))
+	return err
+}
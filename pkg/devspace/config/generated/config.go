@@ -170,6 +170,7 @@ func NewCache() *CacheConfig {
 		Images:      make(map[string]*ImageCache),
 
 		Dependencies: make(map[string]string),
+		Commands:     make(map[string]*CommandCache),
 	}
 }
 
@@ -209,6 +210,18 @@ func (cache *CacheConfig) GetDeploymentCache(deploymentName string) *DeploymentC
 	return cache.Deployments[deploymentName]
 }
 
+// GetCommandCache returns the command cache if it exists and creates one if not
+func (cache *CacheConfig) GetCommandCache(commandName string) *CommandCache {
+	if cache.Commands == nil {
+		cache.Commands = make(map[string]*CommandCache)
+	}
+	if _, ok := cache.Commands[commandName]; !ok {
+		cache.Commands[commandName] = &CommandCache{}
+	}
+
+	return cache.Commands[commandName]
+}
+
 // InitDevSpaceConfig verifies a given config name is set
 func InitDevSpaceConfig(config *Config, configName string) {
 	if cache, ok := config.Profiles[configName]; !ok || cache == nil {
@@ -225,6 +238,9 @@ func InitDevSpaceConfig(config *Config, configName string) {
 	if config.Profiles[configName].Dependencies == nil {
 		config.Profiles[configName].Dependencies = make(map[string]string)
 	}
+	if config.Profiles[configName].Commands == nil {
+		config.Profiles[configName].Commands = make(map[string]*CommandCache)
+	}
 }
 
 // configPath returns the generated config absolute path. The if the default devspace.yaml is given the generated config path
@@ -30,9 +30,16 @@ type CacheConfig struct {
 	Deployments  map[string]*DeploymentCache `yaml:"deployments,omitempty"`
 	Images       map[string]*ImageCache      `yaml:"images,omitempty"`
 	Dependencies map[string]string           `yaml:"dependencies,omitempty"`
+	Commands     map[string]*CommandCache    `yaml:"commands,omitempty"`
 	LastContext  *LastContextConfig          `yaml:"lastContext,omitempty"`
 }
 
+// CommandCache holds the hashes of the inputs and outputs of the last successful command execution
+type CommandCache struct {
+	InputsHash  string `yaml:"inputsHash,omitempty"`
+	OutputsHash string `yaml:"outputsHash,omitempty"`
+}
+
 // ImageCache holds the cache related information about a certain image
 type ImageCache struct {
 	ImageConfigHash string `yaml:"imageConfigHash,omitempty"`
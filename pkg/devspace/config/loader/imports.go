@@ -0,0 +1,328 @@
+package loader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// resolveImports merges all imported config fragments into the config. Values of the config itself
+// take precedence over imported values and later imports take precedence over earlier ones.
+func (l *configLoader) resolveImports(data map[interface{}]interface{}, sourceMap sourcemap.SourceMap, options *ConfigOptions, log log.Logger) (map[interface{}]interface{}, sourcemap.SourceMap, error) {
+	absPath, err := filepath.Abs(ConfigPath(l.configPath))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resolveImports(data, sourceMap, filepath.Dir(absPath), []string{absPath}, options.ProfileRefresh, log)
+}
+
+func resolveImports(data map[interface{}]interface{}, sourceMap sourcemap.SourceMap, basePath string, chain []string, update bool, log log.Logger) (map[interface{}]interface{}, sourcemap.SourceMap, error) {
+	imports, err := parseImports(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	delete(data, "imports")
+	if len(imports) == 0 {
+		return data, sourceMap, nil
+	}
+
+	merged := map[interface{}]interface{}{}
+	importsSourceMap := sourcemap.SourceMap{}
+	fragments := []map[interface{}]interface{}{}
+	fragmentSourceMaps := []sourcemap.SourceMap{}
+	for index, source := range imports {
+		path, err := importPath(basePath, source, update, log)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "imports[%d]", index)
+		}
+
+		// check for cycles
+		for _, parent := range chain {
+			if parent == path {
+				return nil, nil, errors.Errorf("import cycle detected: %s", strings.Join(append(chain, path), " -> "))
+			}
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "read import %s", path)
+		}
+
+		fragment := map[interface{}]interface{}{}
+		err = yaml.Unmarshal(content, &fragment)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "parse import %s", path)
+		}
+
+		// imports of the fragment are relative to the fragment itself
+		fragmentChain := append(append([]string{}, chain...), path)
+		fragment, fragmentSourceMap, err := resolveImports(fragment, sourcemap.Parse(importName(chain[0], path), content), filepath.Dir(path), fragmentChain, update, log)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		merged, err = mergeImport(merged, fragment)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "merge import %s", path)
+		}
+
+		fragments = append(fragments, fragment)
+		fragmentSourceMaps = append(fragmentSourceMaps, fragmentSourceMap)
+	}
+
+	result, err := mergeImport(merged, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the positions of every source are moved to the indices of the merged lists
+	for index := range fragments {
+		fragmentSourceMap, err := reindexSourceMap(fragmentSourceMaps[index], fragments[index], result)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		importsSourceMap = fragmentSourceMap.Merge(importsSourceMap)
+	}
+	sourceMap, err = reindexSourceMap(sourceMap, data, result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, sourceMap.Merge(importsSourceMap), nil
+}
+
+func parseImports(data map[interface{}]interface{}) ([]*latest.SourceConfig, error) {
+	if data["imports"] == nil {
+		return nil, nil
+	}
+
+	out, err := yaml.Marshal(data["imports"])
+	if err != nil {
+		return nil, err
+	}
+
+	imports := []*latest.SourceConfig{}
+	err = yaml.UnmarshalStrict(out, &imports)
+	if err != nil {
+		return nil, errors.Errorf("error parsing imports: %v", err)
+	}
+
+	return imports, nil
+}
+
+// importPath downloads the import source if necessary and returns the path to the fragment file.
+// Remote sources are cached the same way as dependency sources.
+func importPath(basePath string, source *latest.SourceConfig, update bool, log log.Logger) (string, error) {
+	if source.Git == "" && source.Path == "" && source.OCI == "" && source.Tarball == "" {
+		return "", errors.New("path, git, oci or tarball is required")
+	}
+
+	ID := dependencyutil.GetParentProfileID(basePath, source, "", nil)
+	localPath, err := dependencyutil.DownloadDependency(ID, basePath, source, update, log)
+	if err != nil {
+		return "", err
+	}
+
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	} else if stat.IsDir() {
+		configName := constants.DefaultConfigPath
+		if source.ConfigName != "" {
+			configName = source.ConfigName
+		}
+
+		localPath = filepath.Join(localPath, configName)
+	}
+
+	return filepath.Abs(localPath)
+}
+
+// mergeImport merges the overlay into the base with the same semantics as profile strategic merges,
+// so that lists like deployments or commands are merged by their name
+func mergeImport(base, overlay map[interface{}]interface{}) (map[interface{}]interface{}, error) {
+	return ApplyStrategicMerge(base, map[interface{}]interface{}{
+		"strategicMerge": overlay,
+	})
+}
+
+// reindexSourceMap returns the source map of the source data for the merged data. Items of lists
+// that are merged by a merge key, e.g. deployments by their name, can have a different index in
+// the merged data, so their positions are moved to the index of the merged item. Positions of
+// lists that were replaced by another source are removed.
+func reindexSourceMap(sourceMap sourcemap.SourceMap, source, merged map[interface{}]interface{}) (sourcemap.SourceMap, error) {
+	schema, err := strategicpatch.NewPatchMetaFromStruct(&latest.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &reindexer{
+		sourceMap: sourceMap,
+		result:    sourcemap.SourceMap{},
+		visited:   map[string]bool{},
+	}
+	r.reindexMap(source, merged, "", "", PatchMetaFromStruct{PatchMetaFromStruct: schema})
+
+	// positions of paths that are not part of the data, e.g. imports, are kept
+	for path, position := range sourceMap {
+		if !r.visited[path] {
+			r.result[path] = position
+		}
+	}
+
+	return r.result, nil
+}
+
+type reindexer struct {
+	sourceMap sourcemap.SourceMap
+	result    sourcemap.SourceMap
+	visited   map[string]bool
+}
+
+// move moves the position of the old path to the new path
+func (r *reindexer) move(oldPath, newPath string) {
+	r.visited[oldPath] = true
+	if position, ok := r.sourceMap[oldPath]; ok {
+		r.result[newPath] = position
+	}
+}
+
+// drop removes the positions of the value and all its children
+func (r *reindexer) drop(value interface{}, path string) {
+	r.visited[path] = true
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range value {
+			r.drop(child, sourcemap.Join(path, key))
+		}
+	case []interface{}:
+		for index, child := range value {
+			r.drop(child, sourcemap.Join(path, index))
+		}
+	}
+}
+
+func (r *reindexer) reindex(value, merged interface{}, oldPath, newPath string, schema strategicpatch.LookupPatchMeta) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		mergedMap, ok := merged.(map[interface{}]interface{})
+		if !ok {
+			r.drop(value, oldPath)
+			return
+		}
+
+		r.move(oldPath, newPath)
+		r.reindexMap(value, mergedMap, oldPath, newPath, schema)
+	default:
+		r.move(oldPath, newPath)
+	}
+}
+
+func (r *reindexer) reindexMap(value, merged map[interface{}]interface{}, oldPath, newPath string, schema strategicpatch.LookupPatchMeta) {
+	for key, child := range value {
+		childOldPath, childNewPath := sourcemap.Join(oldPath, key), sourcemap.Join(newPath, key)
+		mergedChild, ok := merged[key]
+		if !ok {
+			r.drop(child, childOldPath)
+			continue
+		}
+
+		list, isList := child.([]interface{})
+		if !isList {
+			var childSchema strategicpatch.LookupPatchMeta
+			if schema != nil {
+				childSchema, _, _ = schema.LookupPatchMetadataForStruct(fmt.Sprintf("%v", key))
+			}
+
+			r.reindex(child, mergedChild, childOldPath, childNewPath, childSchema)
+			continue
+		}
+
+		mergedList, ok := mergedChild.([]interface{})
+		if !ok {
+			r.drop(child, childOldPath)
+			continue
+		}
+
+		var (
+			elemSchema strategicpatch.LookupPatchMeta
+			mergeKey   string
+		)
+		if schema != nil {
+			var patchMeta strategicpatch.PatchMeta
+			elemSchema, patchMeta, _ = schema.LookupPatchMetadataForSlice(fmt.Sprintf("%v", key))
+			mergeKey = patchMeta.GetPatchMergeKey()
+		}
+
+		r.move(childOldPath, childNewPath)
+		r.reindexList(list, mergedList, childOldPath, childNewPath, mergeKey, elemSchema)
+	}
+}
+
+func (r *reindexer) reindexList(list, merged []interface{}, oldPath, newPath, mergeKey string, schema strategicpatch.LookupPatchMeta) {
+	// lists without a merge key are replaced as a whole
+	if mergeKey == "" {
+		if !reflect.DeepEqual(list, merged) {
+			r.drop(list, oldPath)
+			return
+		}
+
+		for index, item := range list {
+			r.reindex(item, merged[index], sourcemap.Join(oldPath, index), sourcemap.Join(newPath, index), schema)
+		}
+
+		return
+	}
+
+	for index, item := range list {
+		mergedIndex := findMergedItem(item, merged, mergeKey)
+		if mergedIndex == -1 {
+			r.drop(item, sourcemap.Join(oldPath, index))
+			continue
+		}
+
+		r.reindex(item, merged[mergedIndex], sourcemap.Join(oldPath, index), sourcemap.Join(newPath, mergedIndex), schema)
+	}
+}
+
+// findMergedItem returns the index of the item in the merged list that has the same merge key
+func findMergedItem(item interface{}, merged []interface{}, mergeKey string) int {
+	itemMap, ok := item.(map[interface{}]interface{})
+	if !ok || itemMap[mergeKey] == nil {
+		return -1
+	}
+
+	for index, mergedItem := range merged {
+		mergedMap, ok := mergedItem.(map[interface{}]interface{})
+		if ok && fmt.Sprintf("%v", mergedMap[mergeKey]) == fmt.Sprintf("%v", itemMap[mergeKey]) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// importName returns the path of the import relative to the root config if possible
+func importName(rootPath, path string) string {
+	relPath, err := filepath.Rel(filepath.Dir(rootPath), path)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return path
+	}
+
+	return relPath
+}
@@ -0,0 +1,202 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	fakegenerated "github.com/loft-sh/devspace/pkg/devspace/config/generated/testing"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestResolveImports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"devspace.yaml": `version: v1beta11
+imports:
+- path: shared/base.yaml
+- path: shared
+deployments:
+- name: api
+  helm:
+    values:
+      replicas: 2
+`,
+		"shared/base.yaml": `imports:
+- path: common.yaml
+deployments:
+- name: api
+  helm:
+    values:
+      replicas: 1
+      image: api
+- name: db
+  helm:
+    chart:
+      name: postgres
+`,
+		"shared/common.yaml": `commands:
+- name: test
+  command: go test ./...
+`,
+		"shared/devspace.yaml": `commands:
+- name: lint
+  command: golangci-lint run
+`,
+	}
+	for name, content := range files {
+		err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)
+		assert.NilError(t, err)
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err)
+	}
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	data, sourceMap := loadTestImport(t, configPath)
+	data, sourceMap, err := resolveImports(data, sourceMap, dir, []string{configPath}, false, log.Discard)
+	assert.NilError(t, err)
+
+	expected := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(`version: v1beta11
+deployments:
+- name: api
+  helm:
+    values:
+      replicas: 2
+      image: api
+- name: db
+  helm:
+    chart:
+      name: postgres
+commands:
+- name: lint
+  command: golangci-lint run
+- name: test
+  command: go test ./...
+`), &expected)
+	assert.NilError(t, err)
+
+	out, err := yaml.Marshal(data)
+	assert.NilError(t, err)
+	expectedOut, err := yaml.Marshal(expected)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), string(expectedOut))
+
+	assert.Equal(t, sourceMap.Lookup("deployments[0].helm.values.replicas").String(), "devspace.yaml:9:7")
+	assert.Equal(t, sourceMap.Lookup("deployments[1].helm.chart.name").String(), filepath.Join("shared", "base.yaml")+":12:7")
+}
+
+func TestResolveImportsCycle(t *testing.T) {
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "devspace.yaml"), []byte("version: v1beta11\nimports:\n- path: a.yaml\n"), 0644)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte("imports:\n- path: devspace.yaml\n"), 0644)
+	assert.NilError(t, err)
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	data, sourceMap := loadTestImport(t, configPath)
+	_, _, err = resolveImports(data, sourceMap, dir, []string{configPath}, false, log.Discard)
+	assert.Error(t, err, "import cycle detected: "+configPath+" -> "+filepath.Join(dir, "a.yaml")+" -> "+configPath)
+}
+
+func loadTestImport(t *testing.T, path string) (map[interface{}]interface{}, sourcemap.SourceMap) {
+	content, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+
+	data := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(content, &data)
+	assert.NilError(t, err)
+
+	return data, sourcemap.Parse("devspace.yaml", content)
+}
+
+func TestResolveImportsSourceMapIndices(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"devspace.yaml": `version: v1beta11
+imports:
+- path: base.yaml
+deployments:
+- name: x
+  helm:
+    chart:
+      name: x-chart
+`,
+		"base.yaml": `deployments:
+- name: api
+  helm:
+    chart:
+      name: api-chart
+- name: db
+  helm:
+    chart:
+      name: db-chart
+`,
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err)
+	}
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	data, sourceMap := loadTestImport(t, configPath)
+	data, sourceMap, err := resolveImports(data, sourceMap, dir, []string{configPath}, false, log.Discard)
+	assert.NilError(t, err)
+
+	// every deployment points to its own definition, independent of its merged index
+	expected := map[string]string{
+		"x":   "devspace.yaml:8:7",
+		"api": "base.yaml:5:7",
+		"db":  "base.yaml:9:7",
+	}
+	deployments := data["deployments"].([]interface{})
+	assert.Equal(t, len(deployments), 3)
+	for index, deployment := range deployments {
+		name := deployment.(map[interface{}]interface{})["name"].(string)
+		position := sourceMap.Lookup(sourcemap.Join(sourcemap.Join("deployments", index), "helm") + ".chart.name")
+		assert.Equal(t, position.String(), expected[name], "Unexpected position of deployment %s", name)
+	}
+}
+
+func TestLoadImportedProfiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"devspace.yaml": `version: v1beta11
+imports:
+- path: base.yaml
+profiles:
+- name: local
+`,
+		"base.yaml": `profiles:
+- name: production
+  patches:
+  - op: add
+    path: deployments
+    value: []
+`,
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		assert.NilError(t, err)
+	}
+
+	configPath := filepath.Join(dir, "devspace.yaml")
+	loader := &configLoader{
+		configPath: configPath,
+	}
+	rawConfig, err := loader.LoadRaw()
+	assert.NilError(t, err)
+	c, _, _, err := loader.parseConfig(configPath, rawConfig, NewProfilesParser(), &ConfigOptions{GeneratedLoader: &fakegenerated.Loader{Config: generated.Config{}}}, log.Discard)
+	assert.NilError(t, err)
+
+	profiles := []string{}
+	for _, profile := range c.Profiles {
+		profiles = append(profiles, profile.Name)
+	}
+	assert.DeepEqual(t, profiles, []string{"local", "production"})
+}
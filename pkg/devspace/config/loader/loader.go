@@ -12,6 +12,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
 
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -280,8 +281,24 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 		return nil, nil, nil, err
 	}
 
+	// parse the positions of the config values, so that errors can point to them
+	sourceMap := sourcemap.ParseFile(absPath)
+
+	// merge the imported config fragments
+	copiedRawConfig, sourceMap, err = l.resolveImports(copiedRawConfig, sourceMap, options, log)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "resolve imports")
+	}
+
+	// the parser receives the config with imports, but without applied profiles, so that
+	// profiles of imports are listed as well
+	importedRawConfig, err := copyRaw(copiedRawConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// apply the profiles
-	copiedRawConfig, err = l.applyProfiles(copiedRawConfig, options, log)
+	copiedRawConfig, sourceMap, err = l.applyProfiles(copiedRawConfig, sourceMap, options, log)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -302,15 +319,15 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 	delete(copiedRawConfig, "vars")
 
 	// parse the config
-	latestConfig, err := parser.Parse(absPath, rawConfig, copiedRawConfig, vars, resolver, options, log)
+	latestConfig, err := parser.Parse(absPath, importedRawConfig, copiedRawConfig, vars, resolver, options, log)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, annotate(sourceMap, err)
 	}
 
 	// now we validate the config
 	err = validate(latestConfig, log)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, annotate(sourceMap, err)
 	}
 
 	// Save generated config
@@ -334,44 +351,66 @@ func (l *configLoader) parseConfig(absPath string, rawConfig map[interface{}]int
 	return latestConfig, generatedConfig, resolver, nil
 }
 
-func (l *configLoader) applyProfiles(data map[interface{}]interface{}, options *ConfigOptions, log log.Logger) (map[interface{}]interface{}, error) {
+func (l *configLoader) applyProfiles(data map[interface{}]interface{}, sourceMap sourcemap.SourceMap, options *ConfigOptions, log log.Logger) (map[interface{}]interface{}, sourcemap.SourceMap, error) {
 	// Get profile
 	profiles, err := versions.ParseProfile(filepath.Dir(l.configPath), data, options.Profiles, options.ProfileRefresh, options.DisableProfileActivation, log)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Now delete not needed parts from config
+	rawProfiles, _ := data["profiles"].([]interface{})
 	delete(data, "profiles")
 
 	// Apply profiles
 	for i := len(profiles) - 1; i >= 0; i-- {
+		before, err := copyRaw(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
 		// Apply replace
 		err = ApplyReplace(data, profiles[i])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Apply merge
 		data, err = ApplyMerge(data, profiles[i])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Apply strategic merge
 		data, err = ApplyStrategicMerge(data, profiles[i])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Apply patches
 		data, err = ApplyPatches(data, profiles[i])
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		// Remember which values were introduced by the profile
+		name, _ := profiles[i]["name"].(string)
+		sourceMap = sourceMap.ApplyProfile(before, data, profilePath(rawProfiles, name), name)
+	}
+
+	return data, sourceMap, nil
+}
+
+// profilePath returns the path of the profile with the given name within the config
+func profilePath(profiles []interface{}, name string) string {
+	for index, profile := range profiles {
+		profileMap, ok := profile.(map[interface{}]interface{})
+		if ok && profileMap["name"] == name {
+			return sourcemap.Join("profiles", index)
 		}
 	}
 
-	return data, nil
+	return ""
 }
 
 func (l *configLoader) newVariableResolver(generatedConfig *generated.Config, options *ConfigOptions, log log.Logger) variable.Resolver {
@@ -443,42 +482,55 @@ func (l *configLoader) SetDevSpaceRoot(log log.Logger) (bool, error) {
 		return true, nil
 	}
 
-	cwd, err := os.Getwd()
+	originalCwd, err := os.Getwd()
 	if err != nil {
 		return false, err
 	}
 
-	originalCwd := cwd
-	homeDir, err := homedir.Dir()
+	root, err := FindDevSpaceRoot()
+	if err != nil || root == "" {
+		return false, err
+	}
+
+	// Change working directory
+	err = os.Chdir(root)
 	if err != nil {
 		return false, err
 	}
 
+	// Notify user that we are not using the current working directory
+	if originalCwd != root {
+		log.Infof("Using devspace config in %s", filepath.ToSlash(root))
+	}
+
+	return true, nil
+}
+
+// FindDevSpaceRoot returns the first directory that contains a devspace.yaml, starting at the
+// current working directory and going up to its parents. The home directory is skipped. If no
+// devspace.yaml is found, an empty string is returned.
+func FindDevSpaceRoot() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
 	lastLength := 0
 	for len(cwd) != lastLength {
-		if cwd != homeDir {
-			configExists := configExistsInPath(filepath.Join(cwd, constants.DefaultConfigPath))
-			if configExists {
-				// Change working directory
-				err = os.Chdir(cwd)
-				if err != nil {
-					return false, err
-				}
-
-				// Notify user that we are not using the current working directory
-				if originalCwd != cwd {
-					log.Infof("Using devspace config in %s", filepath.ToSlash(cwd))
-				}
-
-				return true, nil
-			}
+		if cwd != homeDir && configExistsInPath(filepath.Join(cwd, constants.DefaultConfigPath)) {
+			return cwd, nil
 		}
 
 		lastLength = len(cwd)
 		cwd = filepath.Dir(cwd)
 	}
 
-	return false, nil
+	return "", nil
 }
 
 func ConfigPath(configPath string) string {
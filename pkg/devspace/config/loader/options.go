@@ -28,7 +28,7 @@ type ConfigOptions struct {
 	BasePath string
 	// The profile that should be loaded
 	Profiles []string
-	// If the profile parents that are loaded from other sources should be refreshed
+	// If the profile parents and imports that are loaded from other sources should be refreshed
 	ProfileRefresh bool
 	// If the profile activations should be disabled
 	DisableProfileActivation bool
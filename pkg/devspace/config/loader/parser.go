@@ -117,6 +117,12 @@ func fillVariablesAndParse(configPath string, preparedConfig map[interface{}]int
 		return nil, err
 	}
 
+	// validate the config against the schema
+	err = validateSchema(preparedConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	// Now convert the whole config to latest
 	latestConfig, err := versions.Parse(preparedConfig, log)
 	if err != nil {
@@ -291,9 +291,12 @@ func convertFrom(v interface{}) interface{} {
 		v = m
 
 	case []interface{}:
+		// a new slice is created, so that the items of the original config are not changed
+		l := make([]interface{}, len(x))
 		for i, v2 := range x {
-			x[i] = convertFrom(v2)
+			l[i] = convertFrom(v2)
 		}
+		v = l
 
 	case map[string]interface{}:
 		for k, v2 := range x {
@@ -0,0 +1,36 @@
+package loader
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+	"github.com/loft-sh/devspace/pkg/devspace/config/schema"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// validateSchema validates the prepared config against the json schema of the latest config version,
+// so that all invalid fields are reported at once
+func validateSchema(preparedConfig map[interface{}]interface{}) error {
+	// older versions are converted and validated while parsing
+	if version, _ := preparedConfig["version"].(string); version != latest.Version {
+		return nil
+	}
+
+	validationErrors := schema.Latest().Validate(preparedConfig)
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	return schema.ValidationErrors(validationErrors)
+}
+
+// annotate adds the positions of the invalid values to the error. Schema violations are located by
+// their path, all other errors by the config paths they mention.
+func annotate(sourceMap sourcemap.SourceMap, err error) error {
+	var validationErrors schema.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		validationErrors.Locate(sourceMap)
+		return err
+	}
+
+	return sourceMap.Annotate(err)
+}
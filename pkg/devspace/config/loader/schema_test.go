@@ -0,0 +1,37 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func TestAnnotate(t *testing.T) {
+	sourceMap := sourcemap.Parse("devspace.yaml", []byte(`version: v1beta11
+images:
+  my.image:
+    image: nginx
+    tags: latest
+dev:
+  sync:
+  - imageSelector: nginx
+    arch: arm
+`))
+
+	// schema violations are located by their path, even if keys contain dots
+	err := validateSchema(map[interface{}]interface{}{
+		"version": latest.Version,
+		"images": map[interface{}]interface{}{
+			"my.image": map[interface{}]interface{}{"image": "nginx", "tags": "latest"},
+		},
+	})
+	err = annotate(sourceMap, errors.Wrap(err, "parse config"))
+	assert.Error(t, err, "parse config: invalid config:\ndevspace.yaml:5:5: images.my.image.tags: expected an array, but got the string \"latest\"")
+
+	// other errors are located by the paths they mention
+	err = annotate(sourceMap, validateDev(&latest.Config{Dev: latest.DevConfig{Sync: []*latest.SyncConfig{{ImageSelector: "nginx", Arch: "arm"}}}}))
+	assert.Error(t, err, "devspace.yaml:9:5: Error in config: dev.sync[0].arch is not valid 'arm'")
+}
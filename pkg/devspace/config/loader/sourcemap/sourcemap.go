@@ -0,0 +1,295 @@
+package sourcemap
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Position is the location of a config value
+type Position struct {
+	// File is the name of the file the value was defined in
+	File string
+
+	// Line and Column of the value within the file
+	Line   int
+	Column int
+
+	// Origin describes what introduced the value, e.g. profile production
+	Origin string
+}
+
+// String returns the position in the form devspace.yaml:42:7
+func (p *Position) String() string {
+	out := ""
+	if p.Line > 0 {
+		out = fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+	}
+	if p.Origin != "" {
+		if out == "" {
+			return p.Origin
+		}
+
+		out += " (" + p.Origin + ")"
+	}
+
+	return out
+}
+
+// SourceMap maps config paths such as dev.sync[2].containerPath to the position of the value
+type SourceMap map[string]*Position
+
+// ParseFile parses the yaml file at the given path into a source map. If the file cannot be parsed
+// an empty source map is returned, because positions are only used to improve error messages.
+func ParseFile(path string) SourceMap {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return SourceMap{}
+	}
+
+	return Parse(filepath.Base(path), source)
+}
+
+// Parse parses the given yaml source into a source map
+func Parse(file string, source []byte) SourceMap {
+	sourceMap := SourceMap{}
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(source, document)
+	if err != nil {
+		return sourceMap
+	}
+
+	sourceMap.add(file, document, "", false)
+	return sourceMap
+}
+
+func (s SourceMap) add(file string, node *yaml.Node, path string, merged bool) {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	set := func(path string, node *yaml.Node) {
+		// explicit keys take precedence over keys that are merged via <<
+		if _, ok := s[path]; ok && merged {
+			return
+		}
+
+		s[path] = &Position{File: file, Line: node.Line, Column: node.Column}
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			s.add(file, child, path, merged)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				if value.Kind == yaml.SequenceNode {
+					for _, child := range value.Content {
+						s.add(file, child, path, true)
+					}
+				} else {
+					s.add(file, value, path, true)
+				}
+
+				continue
+			}
+
+			childPath := Join(path, key.Value)
+			set(childPath, key)
+			s.add(file, value, childPath, merged)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			set(childPath, child)
+			s.add(file, child, childPath, merged)
+		}
+	}
+}
+
+// Join appends the key to the path
+func Join(path string, key interface{}) string {
+	if index, ok := key.(int); ok {
+		return path + "[" + strconv.Itoa(index) + "]"
+	} else if path == "" {
+		return fmt.Sprintf("%v", key)
+	}
+
+	return path + "." + fmt.Sprintf("%v", key)
+}
+
+// Merge returns a new source map that contains the positions of both source maps. Positions of
+// this source map take precedence.
+func (s SourceMap) Merge(other SourceMap) SourceMap {
+	ret := SourceMap{}
+	for path, position := range other {
+		ret[path] = position
+	}
+	for path, position := range s {
+		ret[path] = position
+	}
+
+	return ret
+}
+
+var lastSegmentRegEx = regexp.MustCompile(`(\.[^.\[\]]*|\[\d+\])$`)
+
+// Lookup returns the position of the given path. If the path itself has no position, the position
+// of the closest parent is returned instead, which is useful for missing values.
+func (s SourceMap) Lookup(path string) *Position {
+	for path != "" {
+		if position, ok := s[path]; ok {
+			return position
+		}
+
+		newPath := lastSegmentRegEx.ReplaceAllString(path, "")
+		if newPath == path {
+			return nil
+		}
+
+		path = newPath
+	}
+
+	return nil
+}
+
+// LookupPath returns the position of the path given as segments, e.g. ["dev", "sync", 2]. In
+// contrast to Lookup, keys may contain dots. If the path itself has no position, the position of
+// the closest parent is returned instead.
+func (s SourceMap) LookupPath(path []interface{}) *Position {
+	for i := len(path); i > 0; i-- {
+		joined := ""
+		for _, segment := range path[:i] {
+			joined = Join(joined, segment)
+		}
+
+		if position, ok := s[joined]; ok {
+			return position
+		}
+	}
+
+	return nil
+}
+
+// ApplyProfile returns a new source map for the config after the profile was applied. Values that
+// were changed or added by the profile point to their definition within the profile at profilePath, or
+// to the profile itself if the exact definition is unknown (e.g. for patches).
+func (s SourceMap) ApplyProfile(before, after map[interface{}]interface{}, profilePath, profileName string) SourceMap {
+	ret := SourceMap{}
+	for path, position := range s {
+		ret[path] = position
+	}
+
+	beforeValues := map[string]interface{}{}
+	walk(before, "", func(path string, value interface{}, leaf bool) {
+		beforeValues[path] = value
+	})
+
+	origin := "profile " + profileName
+	walk(after, "", func(path string, value interface{}, leaf bool) {
+		if _, exists := s[path]; exists {
+			oldValue, ok := beforeValues[path]
+			if ok && (!leaf || fmt.Sprintf("%v", oldValue) == fmt.Sprintf("%v", value)) {
+				return
+			}
+		}
+
+		for _, section := range []string{"replace", "merge", "strategicMerge"} {
+			if position, ok := s[profilePath+"."+section+"."+path]; ok {
+				ret[path] = &Position{File: position.File, Line: position.Line, Column: position.Column, Origin: origin}
+				return
+			}
+		}
+
+		position := s.Lookup(profilePath + ".patches")
+		if position == nil || profilePath == "" {
+			ret[path] = &Position{Origin: origin}
+			return
+		}
+
+		ret[path] = &Position{File: position.File, Line: position.Line, Column: position.Column, Origin: origin}
+	})
+
+	return ret
+}
+
+func walk(value interface{}, path string, fn func(path string, value interface{}, leaf bool)) {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range value {
+			childPath := Join(path, key)
+			_, isMap := child.(map[interface{}]interface{})
+			_, isSlice := child.([]interface{})
+			fn(childPath, child, !isMap && !isSlice)
+			walk(child, childPath, fn)
+		}
+	case []interface{}:
+		for index, child := range value {
+			childPath := Join(path, index)
+			_, isMap := child.(map[interface{}]interface{})
+			_, isSlice := child.([]interface{})
+			fn(childPath, child, !isMap && !isSlice)
+			walk(child, childPath, fn)
+		}
+	}
+}
+
+var pathRegEx = regexp.MustCompile(`(?:^|[\s'"(,])([a-zA-Z0-9\-_]+(?:\[\d+\]|\.[a-zA-Z0-9\-_/]+)+)`)
+
+// Annotate prefixes every line of the error that mentions a config path with the position of that
+// path, e.g. devspace.yaml:42:7: Error in config: dev.sync[2].arch is not valid. Paths that exist in
+// the config are preferred over missing paths, which point to their closest parent.
+func (s SourceMap) Annotate(err error) error {
+	if err == nil || len(s) == 0 {
+		return err
+	}
+
+	changed := false
+	lines := strings.Split(err.Error(), "\n")
+	for i, line := range lines {
+		var position *Position
+		for _, match := range pathRegEx.FindAllStringSubmatch(line, -1) {
+			if exact, ok := s[match[1]]; ok {
+				position = exact
+				break
+			} else if position == nil {
+				position = s.lookupParent(match[1])
+			}
+		}
+		if position == nil {
+			continue
+		}
+
+		lines[i] = position.String() + ": " + line
+		changed = true
+	}
+	if !changed {
+		return err
+	}
+
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+// lookupParent returns the position of the closest parent of the path below the top level, so
+// that words such as dev.yaml are not mistaken for the path dev
+func (s SourceMap) lookupParent(path string) *Position {
+	for {
+		parent := lastSegmentRegEx.ReplaceAllString(path, "")
+		if parent == path || lastSegmentRegEx.ReplaceAllString(parent, "") == parent {
+			return nil
+		} else if position, ok := s[parent]; ok {
+			return position
+		}
+
+		path = parent
+	}
+}
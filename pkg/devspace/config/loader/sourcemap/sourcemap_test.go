@@ -0,0 +1,113 @@
+package sourcemap
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+const testConfig = `version: v1beta11
+defaults: &defaults
+  imageSelector: nginx
+dev:
+  sync:
+  - <<: *defaults
+    containerPath: /app
+profiles:
+- name: production
+  merge:
+    dev:
+      sync:
+      - imageSelector: nginx:prod
+        containerPath: /prod
+- name: staging
+  patches:
+  - op: add
+    path: dev.sync
+    value:
+      containerPath: /staging
+`
+
+func TestParse(t *testing.T) {
+	sourceMap := Parse("devspace.yaml", []byte(testConfig))
+
+	assert.Equal(t, sourceMap.Lookup("version").String(), "devspace.yaml:1:1")
+	assert.Equal(t, sourceMap.Lookup("dev.sync[0]").String(), "devspace.yaml:6:5")
+	assert.Equal(t, sourceMap.Lookup("dev.sync[0].containerPath").String(), "devspace.yaml:7:5")
+	assert.Equal(t, sourceMap.Lookup("dev.sync[0].imageSelector").String(), "devspace.yaml:3:3")
+
+	// missing values point to their parent
+	assert.Equal(t, sourceMap.Lookup("dev.sync[0].localSubPath").String(), "devspace.yaml:6:5")
+	assert.Assert(t, sourceMap.Lookup("images.default") == nil)
+}
+
+func TestLookupPath(t *testing.T) {
+	sourceMap := Parse("devspace.yaml", []byte(`images:
+  my.image:
+    image: nginx
+`))
+
+	assert.Equal(t, sourceMap.LookupPath([]interface{}{"images", "my.image", "image"}).String(), "devspace.yaml:3:5")
+	assert.Equal(t, sourceMap.LookupPath([]interface{}{"images", "my.image", "tags"}).String(), "devspace.yaml:2:3")
+	assert.Assert(t, sourceMap.LookupPath([]interface{}{"dev", "sync", 0}) == nil)
+}
+
+func TestApplyProfile(t *testing.T) {
+	sourceMap := Parse("devspace.yaml", []byte(testConfig))
+
+	before := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`dev:
+  sync:
+  - imageSelector: nginx
+    containerPath: /app
+`), before)
+	assert.NilError(t, err)
+
+	after := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(`dev:
+  sync:
+  - imageSelector: nginx:prod
+    containerPath: /prod
+`), after)
+	assert.NilError(t, err)
+
+	merged := sourceMap.ApplyProfile(before, after, "profiles[0]", "production")
+	assert.Equal(t, merged.Lookup("dev.sync[0]").String(), "devspace.yaml:6:5")
+	assert.Equal(t, merged.Lookup("dev.sync[0].containerPath").String(), "devspace.yaml:14:9 (profile production)")
+
+	patched := map[interface{}]interface{}{}
+	err = yaml.Unmarshal([]byte(`dev:
+  sync:
+  - imageSelector: nginx
+    containerPath: /app
+  - containerPath: /staging
+`), patched)
+	assert.NilError(t, err)
+
+	patchedMap := sourceMap.ApplyProfile(before, patched, "profiles[1]", "staging")
+	assert.Equal(t, patchedMap.Lookup("dev.sync[0].containerPath").String(), "devspace.yaml:7:5")
+	assert.Equal(t, patchedMap.Lookup("dev.sync[1].containerPath").String(), "devspace.yaml:16:3 (profile staging)")
+
+	unknown := sourceMap.ApplyProfile(before, patched, "", "remote")
+	assert.Equal(t, unknown.Lookup("dev.sync[1]").String(), "profile remote")
+}
+
+func TestAnnotate(t *testing.T) {
+	sourceMap := Parse("devspace.yaml", []byte(testConfig))
+
+	err := sourceMap.Annotate(errors.New("invalid config:\ndev.sync[0].containerPath: expected a string\ndev.sync[0].localSubPath is required"))
+	assert.Error(t, err, "invalid config:\ndevspace.yaml:7:5: dev.sync[0].containerPath: expected a string\ndevspace.yaml:6:5: dev.sync[0].localSubPath is required")
+
+	// paths are also found within the message
+	err = sourceMap.Annotate(errors.New("Error in config: dev.sync[0].arch is not valid 'arm'\nyou can only use one of dev.sync[0].localSubPath or dev.sync[0].containerPath"))
+	assert.Error(t, err, "devspace.yaml:6:5: Error in config: dev.sync[0].arch is not valid 'arm'\ndevspace.yaml:7:5: you can only use one of dev.sync[0].localSubPath or dev.sync[0].containerPath")
+
+	// file names are no config paths
+	err = sourceMap.Annotate(errors.New("error reading dev.yaml"))
+	assert.Error(t, err, "error reading dev.yaml")
+
+	err = sourceMap.Annotate(errors.New("error loading config"))
+	assert.Error(t, err, "error loading config")
+}
@@ -3,13 +3,16 @@ package loader
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	jsonyaml "github.com/ghodss/yaml"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/merge"
 	"github.com/loft-sh/devspace/pkg/util/imageselector"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -75,6 +78,11 @@ func validate(config *latest.Config, log log.Logger) error {
 		return err
 	}
 
+	err = validateOutputs(config)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -90,6 +98,65 @@ func validateVars(vars []*latest.Variable) error {
 				return fmt.Errorf("multiple definitions for variable %s found", v.Name)
 			}
 		}
+
+		err := validateVarType(i, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateVarType(index int, v *latest.Variable) error {
+	if v.Type == latest.VariableTypeAuto {
+		return nil
+	} else if !variable.IsValidType(v.Type) {
+		validTypes := []string{}
+		for _, validType := range variable.ValidTypes {
+			validTypes = append(validTypes, string(validType))
+		}
+
+		return errors.Errorf("vars[%d].type: unsupported type %s, please use one of: %s", index, v.Type, strings.Join(validTypes, ", "))
+	}
+
+	if len(v.Options) > 0 {
+		if v.Type == latest.VariableTypeMap {
+			return errors.Errorf("vars[%d].options: options cannot be used with type %s", index, v.Type)
+		}
+
+		// options are the possible items of a list
+		itemType := v.Type
+		if itemType == latest.VariableTypeList {
+			itemType = latest.VariableTypeString
+		}
+		for optionIndex, option := range v.Options {
+			_, err := variable.ConvertValue(option, itemType)
+			if err != nil {
+				return errors.Errorf("vars[%d].options[%d]: %v", index, optionIndex, err)
+			}
+		}
+	}
+
+	values := []struct {
+		field string
+		value interface{}
+	}{{"value", v.Value}, {"default", v.Default}}
+	for _, fieldValue := range values {
+		field, value := fieldValue.field, fieldValue.value
+
+		// values that reference other variables can only be validated after they were resolved
+		if str, ok := value.(string); value == nil || (ok && varspkg.VarMatchRegex.MatchString(str)) {
+			continue
+		}
+
+		converted, err := variable.ConvertValue(value, v.Type)
+		if err == nil {
+			err = variable.ValidateValue(converted, v)
+		}
+		if err != nil {
+			return errors.Errorf("vars[%d].%s: %v", index, field, err)
+		}
 	}
 
 	return nil
@@ -131,8 +198,11 @@ func validateDependencies(config *latest.Config) error {
 		if dep.Source == nil {
 			return errors.Errorf("dependencies[%d].source is required", index)
 		}
-		if dep.Source.Git == "" && dep.Source.Path == "" {
-			return errors.Errorf("dependencies[%d].source.git or dependencies[%d].source.path is required", index, index)
+		if dep.Source.Git == "" && dep.Source.Path == "" && dep.Source.OCI == "" && dep.Source.Tarball == "" {
+			return errors.Errorf("dependencies[%d].source.git, dependencies[%d].source.path, dependencies[%d].source.oci or dependencies[%d].source.tarball is required", index, index, index, index)
+		}
+		if dep.Source.Tarball != "" && !strings.HasPrefix(dep.Source.Tarball, "https://") {
+			return errors.Errorf("dependencies[%d].source.tarball has to be a https url", index)
 		}
 		if len(dep.Profiles) > 0 && (dep.Profile != "" || len(dep.ProfileParents) > 0) {
 			return errors.Errorf("dependencies[%d].profiles and dependencies[%d].profile & dependencies[%d].profileParents cannot be used together", index, index, index)
@@ -158,12 +228,217 @@ func isDependencyNameUnique(name string, dependencies []*latest.DependencyConfig
 }
 
 func validateCommands(config *latest.Config) error {
-	for index, command := range config.Commands {
+	// top level commands can be defined multiple times, where the first definition is used
+	err := validateCommandList(config.Commands, "commands", false)
+	if err != nil {
+		return err
+	}
+
+	return validateCommandDependencies(config.Commands)
+}
+
+func validateCommandList(commands []*latest.CommandConfig, path string, unique bool) error {
+	names := map[string]bool{}
+	for index, command := range commands {
+		commandPath := fmt.Sprintf("%s[%d]", path, index)
 		if command.Name == "" {
-			return errors.Errorf("commands[%d].name is required", index)
+			return errors.Errorf("%s.name is required", commandPath)
+		}
+		if command.Command == "" && len(command.Commands) == 0 {
+			return errors.Errorf("%s.command is required", commandPath)
+		}
+		if unique && names[command.Name] {
+			return errors.Errorf("%s.name: multiple definitions for command %s found", commandPath, command.Name)
+		}
+		names[command.Name] = true
+		if command.Container != nil && command.Container.ImageSelector == "" && len(command.Container.LabelSelector) == 0 && command.Container.Pod == "" {
+			return errors.Errorf("%s.container: imageSelector, labelSelector or pod is required", commandPath)
+		}
+
+		err := validateCommandFlags(command, commandPath)
+		if err != nil {
+			return err
+		}
+
+		err = validateCommandArgs(command, commandPath)
+		if err != nil {
+			return err
+		}
+
+		err = validateCommandList(command.Commands, commandPath+".commands", true)
+		if err != nil {
+			return err
+		}
+		for subIndex, subCommand := range command.Commands {
+			if len(subCommand.DependsOn) > 0 {
+				return errors.Errorf("%s.commands[%d].dependsOn: dependencies are only supported for top level commands", commandPath, subIndex)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCommandDependencies checks that all dependencies of the top level commands exist and
+// that there are no cyclic dependencies
+func validateCommandDependencies(commands []*latest.CommandConfig) error {
+	byName := map[string]*latest.CommandConfig{}
+	for _, command := range commands {
+		if _, ok := byName[command.Name]; !ok {
+			byName[command.Name] = command
+		}
+	}
+
+	for index, command := range commands {
+		for depIndex, dependency := range command.DependsOn {
+			dependencyCommand, ok := byName[dependency]
+			if !ok {
+				return errors.Errorf("commands[%d].dependsOn[%d]: command %s does not exist", index, depIndex, dependency)
+			} else if reason := requiredInput(dependencyCommand); reason != "" {
+				return errors.Errorf("commands[%d].dependsOn[%d]: command %s cannot be a dependency, because it %s", index, depIndex, dependency, reason)
+			}
+		}
+	}
+
+	// 0 = unvisited, 1 = in progress, 2 = done
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch state[name] {
+		case 1:
+			return errors.Errorf("commands: cyclic dependency found: %s", strings.Join(path, " -> "))
+		case 2:
+			return nil
+		}
+
+		state[name] = 1
+		for _, dependency := range byName[name].DependsOn {
+			err := visit(dependency, path)
+			if err != nil {
+				return err
+			}
+		}
+
+		state[name] = 2
+		return nil
+	}
+	for _, command := range commands {
+		err := visit(command.Name, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// requiredInput returns why the command cannot be executed without any arguments, which is how
+// dependencies are executed, or an empty string if it can
+func requiredInput(command *latest.CommandConfig) string {
+	for _, flag := range command.Flags {
+		if flag.Required {
+			return "requires the flag --" + flag.Name
+		}
+	}
+	for _, arg := range command.PositionalArgs {
+		if arg.Required {
+			return "requires the argument " + arg.Name
+		}
+	}
+	if len(command.Commands) > 0 && command.Command == "" {
+		return "has to be called with one of its commands"
+	}
+
+	return ""
+}
+
+func validateCommandFlags(command *latest.CommandConfig, path string) error {
+	names := map[string]bool{}
+	for index, flag := range command.Flags {
+		flagPath := fmt.Sprintf("%s.flags[%d]", path, index)
+		if flag.Name == "" {
+			return errors.Errorf("%s.name is required", flagPath)
+		} else if names[flag.Name] {
+			return errors.Errorf("%s.name: multiple definitions for flag %s found", flagPath, flag.Name)
+		} else if len(flag.Short) > 1 {
+			return errors.Errorf("%s.short: shorthand %s has to be a single letter", flagPath, flag.Short)
+		}
+		names[flag.Name] = true
+
+		err := validateCommandArgType(flag.Type, flag.Default, flagPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateCommandArgs(command *latest.CommandConfig, path string) error {
+	names := map[string]bool{}
+	optional := false
+	for index, arg := range command.PositionalArgs {
+		argPath := fmt.Sprintf("%s.positionalArgs[%d]", path, index)
+		if arg.Name == "" {
+			return errors.Errorf("%s.name is required", argPath)
+		} else if names[arg.Name] {
+			return errors.Errorf("%s.name: multiple definitions for argument %s found", argPath, arg.Name)
+		} else if arg.Required && optional {
+			return errors.Errorf("%s.required: required arguments cannot follow optional arguments", argPath)
+		} else if arg.Type == latest.CommandArgTypeList && index != len(command.PositionalArgs)-1 {
+			return errors.Errorf("%s.type: only the last argument can be a list", argPath)
+		}
+		names[arg.Name] = true
+		optional = !arg.Required
+
+		err := validateCommandArgType(arg.Type, arg.Default, argPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateCommandArgType(argType latest.CommandArgType, defaultValue interface{}, path string) error {
+	switch argType {
+	case latest.CommandArgTypeDefault, latest.CommandArgTypeString, latest.CommandArgTypeList:
+		return nil
+	case latest.CommandArgTypeInt:
+		if _, ok := defaultValue.(int); !ok && defaultValue != nil {
+			return errors.Errorf("%s.default: expected an integer, but got %v", path, defaultValue)
 		}
-		if command.Command == "" {
-			return errors.Errorf("commands[%d].command is required", index)
+	case latest.CommandArgTypeBool:
+		if _, ok := defaultValue.(bool); !ok && defaultValue != nil {
+			return errors.Errorf("%s.default: expected a boolean, but got %v", path, defaultValue)
+		}
+	default:
+		return errors.Errorf("%s.type: unsupported type %s, please use one of: string, int, bool, list", path, argType)
+	}
+
+	return nil
+}
+
+var outputNameRegEx = regexp.MustCompile(`^[a-zA-Z0-9\-\_]+$`)
+
+func validateOutputs(config *latest.Config) error {
+	for index, output := range config.Outputs {
+		if output.Name == "" {
+			return errors.Errorf("outputs[%d].name is required", index)
+		}
+		if !outputNameRegEx.MatchString(output.Name) {
+			return errors.Errorf("outputs[%d].name %s can only contain letters, numbers, '-' and '_'", index, output.Name)
+		}
+		if output.Value == "" && output.Command == "" {
+			return errors.Errorf("outputs[%d].value or outputs[%d].command is required", index, index)
+		} else if output.Value != "" && output.Command != "" {
+			return errors.Errorf("outputs[%d].value and outputs[%d].command cannot be used together", index, index)
+		}
+		for j, other := range config.Outputs {
+			if index != j && output.Name == other.Name {
+				return errors.Errorf("multiple definitions for output %s found", output.Name)
+			}
 		}
 	}
 
@@ -175,8 +450,8 @@ func validateHooks(config *latest.Config) error {
 		if len(hookConfig.Events) == 0 {
 			return errors.Errorf("hooks[%d].events is required", index)
 		}
-		if hookConfig.Command == "" && hookConfig.Upload == nil && hookConfig.Download == nil && hookConfig.Logs == nil && hookConfig.Wait == nil {
-			return errors.Errorf("hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].download or hooks[%d].upload is required", index, index, index, index, index)
+		if hookConfig.Command == "" && hookConfig.Upload == nil && hookConfig.Download == nil && hookConfig.Logs == nil && hookConfig.Wait == nil && hookConfig.Job == nil && hookConfig.Webhook == nil {
+			return errors.Errorf("hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].download, hooks[%d].upload, hooks[%d].job or hooks[%d].webhook is required", index, index, index, index, index, index, index)
 		}
 		enabled := 0
 		if hookConfig.Command != "" {
@@ -194,8 +469,26 @@ func validateHooks(config *latest.Config) error {
 		if hookConfig.Wait != nil {
 			enabled++
 		}
+		if hookConfig.Job != nil {
+			enabled++
+		}
+		if hookConfig.Webhook != nil {
+			enabled++
+		}
 		if enabled > 1 {
-			return errors.Errorf("you can only use one of hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].upload and hooks[%d].download per hook", index, index, index, index, index)
+			return errors.Errorf("you can only use one of hooks[%d].command, hooks[%d].logs, hooks[%d].wait, hooks[%d].upload, hooks[%d].download, hooks[%d].job and hooks[%d].webhook per hook", index, index, index, index, index, index, index)
+		}
+		if hookConfig.Webhook != nil && hookConfig.Container != nil {
+			return errors.Errorf("hooks[%d].container cannot be used together with hooks[%d].webhook", index, index)
+		}
+		if hookConfig.Webhook != nil && hookConfig.Webhook.URL == "" {
+			return errors.Errorf("hooks[%d].webhook.url is required", index)
+		}
+		if hookConfig.Job != nil && hookConfig.Container != nil {
+			return errors.Errorf("hooks[%d].container cannot be used together with hooks[%d].job", index, index)
+		}
+		if hookConfig.Job != nil && len(hookConfig.Job.Template) == 0 {
+			return errors.Errorf("hooks[%d].job.template is required", index)
 		}
 		if hookConfig.Upload != nil && hookConfig.Container == nil {
 			return errors.Errorf("hooks[%d].container is required if hooks[%d].upload is used", index, index)
@@ -212,6 +505,12 @@ func validateHooks(config *latest.Config) error {
 		if hookConfig.Wait != nil && !hookConfig.Wait.Running && hookConfig.Wait.TerminatedWithCode == nil {
 			return errors.Errorf("hooks[%d].wait.running or hooks[%d].wait.terminatedWithCode is required if hooks[%d].wait is used", index, index, index)
 		}
+		if hookConfig.Timeout < 0 {
+			return errors.Errorf("hooks[%d].timeout cannot be negative", index)
+		}
+		if hookConfig.Retry != nil && (hookConfig.Retry.Count < 0 || hookConfig.Retry.Backoff < 0) {
+			return errors.Errorf("hooks[%d].retry.count and hooks[%d].retry.backoff cannot be negative", index, index)
+		}
 		if hookConfig.Container != nil {
 			if hookConfig.Container.ContainerName != "" && len(hookConfig.Container.LabelSelector) == 0 {
 				return errors.Errorf("hooks[%d].container.containerName is defined but hooks[%d].container.labelSelector is not defined", index, index)
@@ -230,8 +529,11 @@ func validateDeployments(config *latest.Config) error {
 		if deployConfig.Name == "" {
 			return errors.Errorf("deployments[%d].name is required", index)
 		}
-		if deployConfig.Helm == nil && deployConfig.Kubectl == nil {
-			return errors.Errorf("Please specify either helm or kubectl as deployment type in deployment %s", deployConfig.Name)
+		if deployConfig.Helm == nil && deployConfig.Kubectl == nil && deployConfig.Plugin == nil {
+			return errors.Errorf("deployments[%d].helm, deployments[%d].kubectl or deployments[%d].plugin is required", index, index, index)
+		}
+		if deployConfig.Plugin != nil && deployConfig.Plugin.Name == "" {
+			return errors.Errorf("deployments[%d].plugin.name is required", index)
 		}
 		if deployConfig.Helm != nil && (deployConfig.Helm.Chart == nil || deployConfig.Helm.Chart.Name == "") && (deployConfig.Helm.ComponentChart == nil || !*deployConfig.Helm.ComponentChart) {
 			return errors.Errorf("deployments[%d].helm.chart and deployments[%d].helm.chart.name or deployments[%d].helm.componentChart is required", index, index, index)
@@ -307,6 +609,9 @@ func validateImages(config *latest.Config) error {
 		if imageConf.Build != nil && imageConf.Build.Custom != nil && imageConf.Build.Custom.Command == "" && len(imageConf.Build.Custom.Commands) == 0 {
 			return errors.Errorf("images.%s.build.custom.command or images.%s.build.custom.commands is required", imageConfigName, imageConfigName)
 		}
+		if imageConf.Build != nil && imageConf.Build.Plugin != nil && imageConf.Build.Plugin.Name == "" {
+			return errors.Errorf("images.%s.build.plugin.name is required", imageConfigName)
+		}
 		if images[imageConf.Image] {
 			return errors.Errorf("multiple image definitions with the same image name are not allowed")
 		}
@@ -365,11 +670,11 @@ func strMapEquals(a, b map[string]string) bool {
 func validateDev(config *latest.Config) error {
 	for index, rp := range config.Dev.ReplacePods {
 		if rp.ContainerName != "" && len(rp.LabelSelector) == 0 {
-			return errors.Errorf("Error in config: containerName is defined but label selector is nil in replace pods at index %d", index)
+			return errors.Errorf("Error in config: dev.replacePods[%d].containerName is defined but dev.replacePods[%d].labelSelector is not defined", index, index)
 		}
 
 		if len(rp.LabelSelector) == 0 && rp.ImageSelector == "" {
-			return errors.Errorf("Error in config: image selector and label selector are nil in replace pods at index %d", index)
+			return errors.Errorf("Error in config: dev.replacePods[%d].imageSelector or dev.replacePods[%d].labelSelector is required", index, index)
 		}
 
 		definedSelectors := 0
@@ -380,10 +685,10 @@ func validateDev(config *latest.Config) error {
 			definedSelectors++
 		}
 		if definedSelectors > 1 {
-			return errors.Errorf("Error in config: image selector and label selector cannot both be defined in replace pods at index %d", index)
+			return errors.Errorf("Error in config: dev.replacePods[%d].imageSelector and dev.replacePods[%d].labelSelector cannot be used together", index, index)
 		}
 		if !isReplacePodsUnique(index, rp, config.Dev.ReplacePods) {
-			return errors.Errorf("Error in config: image selector or label selector is not unique in replace pods at index %d", index)
+			return errors.Errorf("Error in config: dev.replacePods[%d].imageSelector or dev.replacePods[%d].labelSelector is not unique", index, index)
 		}
 		for j, p := range rp.PersistPaths {
 			if p.Path == "" {
@@ -396,18 +701,18 @@ func validateDev(config *latest.Config) error {
 		for index, port := range config.Dev.Ports {
 			// Validate imageName and label selector
 			if port.ContainerName != "" && len(port.LabelSelector) == 0 {
-				return errors.Errorf("Error in config: containerName is defined but label selector is nil in ports config at index %d", index)
+				return errors.Errorf("Error in config: dev.ports[%d].containerName is defined but dev.ports[%d].labelSelector is not defined", index, index)
 			}
 
 			if len(port.LabelSelector) == 0 && port.ImageSelector == "" {
-				return errors.Errorf("Error in config: image selector and label selector are nil in ports config at index %d", index)
+				return errors.Errorf("Error in config: dev.ports[%d].imageSelector or dev.ports[%d].labelSelector is required", index, index)
 			}
 
 			if len(port.PortMappings) == 0 && len(port.PortMappingsReverse) == 0 {
-				return errors.Errorf("Error in config: portMappings is empty in port config at index %d", index)
+				return errors.Errorf("Error in config: dev.ports[%d].forward or dev.ports[%d].reverseForward is required", index, index)
 			}
 			if !ValidContainerArch(port.Arch) {
-				return errors.Errorf("Error in config: ports.arch is not valid '%s' at index %d", port.Arch, index)
+				return errors.Errorf("Error in config: dev.ports[%d].arch is not valid '%s'", index, port.Arch)
 			}
 		}
 	}
@@ -416,24 +721,24 @@ func validateDev(config *latest.Config) error {
 		for index, sync := range config.Dev.Sync {
 			// Validate imageName and label selector
 			if sync.ContainerName != "" && len(sync.LabelSelector) == 0 {
-				return errors.Errorf("Error in config: containerName is defined but label selector is nil in sync config at index %d", index)
+				return errors.Errorf("Error in config: dev.sync[%d].containerName is defined but dev.sync[%d].labelSelector is not defined", index, index)
 			}
 
 			if len(sync.LabelSelector) == 0 && sync.ImageSelector == "" {
-				return errors.Errorf("Error in config: image selector and label selector are nil in sync config at index %d", index)
+				return errors.Errorf("Error in config: dev.sync[%d].imageSelector or dev.sync[%d].labelSelector is required", index, index)
 			}
 
 			// Validate initial sync strategy
 			if !ValidInitialSyncStrategy(sync.InitialSync) {
-				return errors.Errorf("Error in config: sync.initialSync is not valid '%s' at index %d", sync.InitialSync, index)
+				return errors.Errorf("Error in config: dev.sync[%d].initialSync is not valid '%s'", index, sync.InitialSync)
 			}
 			if !ValidContainerArch(sync.Arch) {
-				return errors.Errorf("Error in config: sync.arch is not valid '%s' at index %d", sync.Arch, index)
+				return errors.Errorf("Error in config: dev.sync[%d].arch is not valid '%s'", index, sync.Arch)
 			}
 			if sync.OnUpload != nil {
 				for j, e := range sync.OnUpload.Exec {
 					if e.Command == "" {
-						return errors.Errorf("Error in config: dev.sync[%d].exec[%d].command is required", index, j)
+						return errors.Errorf("Error in config: dev.sync[%d].onUpload.exec[%d].command is required", index, j)
 					}
 				}
 			}
@@ -443,7 +748,7 @@ func validateDev(config *latest.Config) error {
 	if config.Dev.InteractiveImages != nil {
 		for index, imageConf := range config.Dev.InteractiveImages {
 			if imageConf.Name == "" {
-				return errors.Errorf("Error in config: Unnamed interactive image config at index %d", index)
+				return errors.Errorf("Error in config: dev.deprecatedInteractiveImages[%d].name is required", index)
 			}
 		}
 	}
@@ -457,6 +762,16 @@ func validateDev(config *latest.Config) error {
 				return errors.Errorf("Error in config: dev.logs.selectors[%d].imageSelector and dev.logs.selectors[%d].containerName cannot be used together", index, index)
 			}
 		}
+		for index, expression := range config.Dev.Logs.Include {
+			if _, err := regexp.Compile(expression); err != nil {
+				return errors.Errorf("Error in config: dev.logs.include[%d] is not a valid regular expression: %v", index, err)
+			}
+		}
+		for index, expression := range config.Dev.Logs.Exclude {
+			if _, err := regexp.Compile(expression); err != nil {
+				return errors.Errorf("Error in config: dev.logs.exclude[%d] is not a valid regular expression: %v", index, err)
+			}
+		}
 	}
 
 	return nil
@@ -66,6 +66,19 @@ func TestValidateHooks(t *testing.T) {
 
 	err = validateHooks(config)
 	assert.Error(t, err, "hooks[0].container.containerName is defined but hooks[0].container.labelSelector is not defined")
+
+	config = &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events:  []string{"after:deploy"},
+				Command: "doSomething",
+				Retry:   &latest.HookRetryConfig{Count: -1},
+			},
+		},
+	}
+
+	err = validateHooks(config)
+	assert.Error(t, err, "hooks[0].retry.count and hooks[0].retry.backoff cannot be negative")
 }
 
 func TestValidateDev(t *testing.T) {
@@ -114,7 +127,7 @@ func TestValidateDev(t *testing.T) {
 	}
 
 	err = validateDev(config)
-	assert.Error(t, err, "Error in config: containerName is defined but label selector is nil in ports config at index 0")
+	assert.Error(t, err, "Error in config: dev.ports[0].containerName is defined but dev.ports[0].labelSelector is not defined")
 
 	// test sync
 	config = &latest.Config{
@@ -147,7 +160,7 @@ func TestValidateDev(t *testing.T) {
 	}
 
 	err = validateDev(config)
-	assert.Error(t, err, "Error in config: containerName is defined but label selector is nil in sync config at index 0")
+	assert.Error(t, err, "Error in config: dev.sync[0].containerName is defined but dev.sync[0].labelSelector is not defined")
 
 	// test replace pods
 	config = &latest.Config{
@@ -177,5 +190,171 @@ func TestValidateDev(t *testing.T) {
 	}
 
 	err = validateDev(config)
-	assert.Error(t, err, "Error in config: containerName is defined but label selector is nil in replace pods at index 0")
+	assert.Error(t, err, "Error in config: dev.replacePods[0].containerName is defined but dev.replacePods[0].labelSelector is not defined")
+}
+
+func TestValidateOutputs(t *testing.T) {
+	config := &latest.Config{
+		Outputs: []*latest.OutputConfig{
+			{
+				Name:  "host",
+				Value: "postgres.default",
+			},
+			{
+				Name:    "password",
+				Command: "echo secret",
+			},
+		},
+	}
+	err := validateOutputs(config)
+	assert.NilError(t, err)
+
+	config.Outputs = append(config.Outputs, &latest.OutputConfig{Name: "host", Value: "other"})
+	err = validateOutputs(config)
+	assert.Error(t, err, "multiple definitions for output host found")
+
+	config.Outputs = []*latest.OutputConfig{{Name: "host.name", Value: "postgres"}}
+	err = validateOutputs(config)
+	assert.Error(t, err, "outputs[0].name host.name can only contain letters, numbers, '-' and '_'")
+
+	config.Outputs = []*latest.OutputConfig{{Name: "host", Value: "postgres", Command: "echo postgres"}}
+	err = validateOutputs(config)
+	assert.Error(t, err, "outputs[0].value and outputs[0].command cannot be used together")
+}
+
+func TestValidateVarTypes(t *testing.T) {
+	vars := []*latest.Variable{
+		{
+			Name:    "REPLICAS",
+			Type:    latest.VariableTypeInt,
+			Default: 2,
+			Options: []string{"1", "2", "3"},
+		},
+		{
+			Name:    "FEATURES",
+			Type:    latest.VariableTypeList,
+			Default: []interface{}{"a", "b"},
+			Options: []string{"a", "b", "c"},
+		},
+		{
+			Name:    "LABELS",
+			Type:    latest.VariableTypeMap,
+			Default: "${OTHER}",
+		},
+	}
+	err := validateVars(vars)
+	assert.NilError(t, err)
+
+	err = validateVars([]*latest.Variable{{Name: "A", Type: "float"}})
+	assert.Error(t, err, "vars[0].type: unsupported type float, please use one of: string, int, bool, list, map")
+
+	err = validateVars([]*latest.Variable{{Name: "A", Type: latest.VariableTypeInt, Default: "abc"}})
+	assert.Error(t, err, `vars[0].default: expected an integer, but got "abc"`)
+
+	err = validateVars([]*latest.Variable{{Name: "A", Type: latest.VariableTypeInt, Options: []string{"1", "two"}}})
+	assert.Error(t, err, `vars[0].options[1]: expected an integer, but got "two"`)
+
+	err = validateVars([]*latest.Variable{{Name: "A", Type: latest.VariableTypeList, Value: []interface{}{"a", "d"}, Options: []string{"a", "b"}}})
+	assert.Error(t, err, "vars[0].value: unsupported value d, please use one of: a, b")
+
+	err = validateVars([]*latest.Variable{{Name: "A", Type: latest.VariableTypeMap, Options: []string{"a"}}})
+	assert.Error(t, err, "vars[0].options: options cannot be used with type map")
+}
+
+func TestValidateCommands(t *testing.T) {
+	config := &latest.Config{
+		Commands: []*latest.CommandConfig{
+			{
+				Name: "db",
+				Commands: []*latest.CommandConfig{
+					{
+						Name:    "migrate",
+						Command: "migrate up",
+						Flags:   []*latest.CommandFlag{{Name: "steps", Type: latest.CommandArgTypeInt, Default: 1}},
+					},
+				},
+			},
+		},
+	}
+	err := validateCommands(config)
+	assert.NilError(t, err)
+
+	config.Commands[0].Commands = append(config.Commands[0].Commands, &latest.CommandConfig{Name: "migrate", Command: "migrate down"})
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[1].name: multiple definitions for command migrate found")
+
+	config.Commands[0].Commands = []*latest.CommandConfig{{Name: "seed"}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[0].command is required")
+
+	config.Commands[0].Commands = []*latest.CommandConfig{{
+		Name:    "seed",
+		Command: "seed",
+		Flags:   []*latest.CommandFlag{{Name: "count", Type: latest.CommandArgTypeInt, Default: "many"}},
+	}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[0].flags[0].default: expected an integer, but got many")
+
+	config.Commands[0].Commands = []*latest.CommandConfig{{
+		Name:    "seed",
+		Command: "seed",
+		PositionalArgs: []*latest.CommandArg{
+			{Name: "source"},
+			{Name: "target", Required: true},
+		},
+	}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[0].positionalArgs[1].required: required arguments cannot follow optional arguments")
+
+	config.Commands[0].Commands = []*latest.CommandConfig{{
+		Name:      "seed",
+		Command:   "seed",
+		Container: &latest.CommandContainer{ContainerName: "api"},
+	}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[0].container: imageSelector, labelSelector or pod is required")
+
+	config.Commands[0].Commands[0].Container.LabelSelector = map[string]string{"app": "api"}
+	err = validateCommands(config)
+	assert.NilError(t, err)
+
+	config.Commands[0].Commands[0].DependsOn = []string{"generate"}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[0].commands[0].dependsOn: dependencies are only supported for top level commands")
+}
+
+func TestValidateCommandDependencies(t *testing.T) {
+	config := &latest.Config{
+		Commands: []*latest.CommandConfig{
+			{Name: "generate", Command: "go generate ./..."},
+			{Name: "lint", Command: "golangci-lint run", DependsOn: []string{"generate"}},
+			{Name: "test", Command: "go test ./...", DependsOn: []string{"generate", "lint"}},
+		},
+	}
+	err := validateCommands(config)
+	assert.NilError(t, err)
+
+	config.Commands[2].DependsOn = []string{"build"}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[2].dependsOn[0]: command build does not exist")
+
+	config.Commands[2].DependsOn = []string{"lint"}
+	config.Commands[0].DependsOn = []string{"test"}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands: cyclic dependency found: generate -> test -> lint -> generate")
+
+	// dependencies are executed without arguments
+	config.Commands[0].DependsOn = nil
+	config.Commands[0].PositionalArgs = []*latest.CommandArg{{Name: "package", Required: true}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[1].dependsOn[0]: command generate cannot be a dependency, because it requires the argument package")
+
+	config.Commands[0].PositionalArgs = []*latest.CommandArg{{Name: "package"}}
+	config.Commands[0].Flags = []*latest.CommandFlag{{Name: "tags", Required: true}}
+	err = validateCommands(config)
+	assert.Error(t, err, "commands[1].dependsOn[0]: command generate cannot be a dependency, because it requires the flag --tags")
+
+	config.Commands[0].Flags[0].Required = false
+	err = validateCommands(config)
+	assert.NilError(t, err)
 }
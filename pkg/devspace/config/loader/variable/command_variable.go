@@ -67,5 +67,5 @@ func execCommand(varName string, definition *latest.Variable, cmd string, args [
 		return definition.Default, nil
 	}
 
-	return convertStringValue(strings.TrimSpace(writer.String())), nil
+	return convertDefinitionValue(strings.TrimSpace(writer.String()), definition), nil
 }
@@ -33,12 +33,12 @@ func (d *defaultVariable) Load(definition *latest.Variable) (interface{}, error)
 
 	// Did we find it in the environment variables?
 	if definition.Source != latest.VariableSourceInput && value != "" {
-		return valueByType(value, definition.Default)
+		return valueByType(value, definition)
 	}
 
 	// Is cached
 	if value, ok := d.cache[d.name]; !definition.NoCache && ok {
-		return valueByType(value, definition.Default)
+		return valueByType(value, definition)
 	}
 
 	// Now ask the question
@@ -50,15 +50,18 @@ func (d *defaultVariable) Load(definition *latest.Variable) (interface{}, error)
 	if !definition.NoCache {
 		d.cache[d.name] = value
 	}
-	return valueByType(value, definition.Default)
+	return valueByType(value, definition)
 }
 
-func valueByType(value string, defaultValue interface{}) (interface{}, error) {
-	if defaultValue == nil {
+func valueByType(value string, definition *latest.Variable) (interface{}, error) {
+	// typed variables are converted by the resolver
+	if definition.Type != latest.VariableTypeAuto {
+		return value, nil
+	} else if definition.Default == nil {
 		return convertStringValue(value), nil
 	}
 
-	switch defaultValue.(type) {
+	switch definition.Default.(type) {
 	case int:
 		r, err := strconv.Atoi(value)
 		return r, err
@@ -0,0 +1,24 @@
+package variable
+
+import (
+	"regexp"
+)
+
+// dependencyOutputRegEx matches variables that reference an output of a dependency such as dep.postgres.outputs.host
+var dependencyOutputRegEx = regexp.MustCompile(`^dep\.([a-zA-Z0-9\-\_]+)\.outputs\.([a-zA-Z0-9\-\_]+)$`)
+
+// ParseDependencyOutput checks if the given variable name references a dependency output and
+// returns the dependency and the output name
+func ParseDependencyOutput(name string) (string, string, bool) {
+	matches := dependencyOutputRegEx.FindStringSubmatch(name)
+	if matches == nil {
+		return "", "", false
+	}
+
+	return matches[1], matches[2], true
+}
+
+// DependencyOutputVariable returns the variable that references the given dependency output
+func DependencyOutputVariable(dependency, output string) string {
+	return "${dep." + dependency + ".outputs." + output + "}"
+}
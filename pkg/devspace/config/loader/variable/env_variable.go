@@ -31,5 +31,5 @@ func (e *envVariable) Load(definition *latest.Variable) (interface{}, error) {
 		return definition.Default, nil
 	}
 
-	return convertStringValue(value), nil
+	return convertDefinitionValue(value, definition), nil
 }
@@ -113,6 +113,19 @@ func AddPredefinedVars(plugins []plugin.Metadata) {
 		pluginFolder := p.PluginFolder
 		for _, variable := range p.Vars {
 			v := variable
+			if plugin.IsGRPC(p) {
+				metadata := p
+				predefinedVars[variable.Name] = func(options *PredefinedVariableOptions) (interface{}, error) {
+					grpcPlugin, err := plugin.GetGRPCPlugin(metadata)
+					if err != nil {
+						return "", err
+					}
+
+					return grpcPlugin.ResolveVariable(v.Name)
+				}
+				continue
+			}
+
 			predefinedVars[variable.Name] = func(options *PredefinedVariableOptions) (interface{}, error) {
 				args, err := json.Marshal(os.Args)
 				if err != nil {
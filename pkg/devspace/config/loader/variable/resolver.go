@@ -15,6 +15,7 @@ import (
 func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedVariableOptions, log log.Logger) Resolver {
 	return &resolver{
 		memoryCache:     map[string]interface{}{},
+		flagValues:      map[string]string{},
 		persistentCache: cache,
 		options:         predefinedVariableOptions,
 		log:             log,
@@ -23,6 +24,7 @@ func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedV
 
 type resolver struct {
 	memoryCache     map[string]interface{}
+	flagValues      map[string]string
 	persistentCache map[string]string
 	options         *PredefinedVariableOptions
 	log             log.Logger
@@ -54,6 +56,15 @@ func (r *resolver) ReplaceString(str string) (interface{}, error) {
 			return "", err
 		}
 
+		// lists and maps can only replace a whole value
+		if isStructured(val) {
+			if str != "${"+v+"}" {
+				return "", errors.Errorf("variable %s is %s and can only be used as a whole value, but is used within '%s'", v, describe(val), str)
+			}
+
+			return normalize(val), nil
+		}
+
 		return val, nil
 	})
 }
@@ -94,6 +105,7 @@ func (r *resolver) ConvertFlags(flags []string) (map[string]interface{}, error)
 
 		name := strings.TrimSpace(cmdVar[:idx])
 		value := convertStringValue(strings.TrimSpace(cmdVar[idx+1:]))
+		r.flagValues[name] = strings.TrimSpace(cmdVar[idx+1:])
 		r.memoryCache[name] = value
 		retVariables[name] = value
 	}
@@ -104,9 +116,26 @@ func (r *resolver) ConvertFlags(flags []string) (map[string]interface{}, error)
 func (r *resolver) Resolve(name string, definition *latest.Variable) (interface{}, error) {
 	name = strings.TrimSpace(name)
 
+	// dependency outputs are only known after the dependency was deployed, so we keep
+	// them as they are and they are filled in by the dependency manager later
+	if dependency, output, ok := ParseDependencyOutput(name); ok {
+		return DependencyOutputVariable(dependency, output), nil
+	}
+
 	// check if in vars already
 	v, ok := r.memoryCache[name]
 	if ok {
+		// flags are converted without knowing the variable type, so we convert them again
+		if flagValue, ok := r.flagValues[name]; ok && definition != nil && definition.Type != latest.VariableTypeAuto {
+			v, err := r.convertValue(name, flagValue, definition)
+			if err != nil {
+				return nil, err
+			}
+
+			r.memoryCache[name] = v
+			return v, nil
+		}
+
 		return v, nil
 	}
 
@@ -122,6 +151,18 @@ func (r *resolver) Resolve(name string, definition *latest.Variable) (interface{
 		return nil, err
 	}
 
+	// secret values are never cached and redacted from all output
+	if definition != nil && IsSecretSource(definition.Source) {
+		delete(r.persistentCache, name)
+		addSecret(value)
+	}
+
+	// convert the value to the type of the variable
+	value, err = r.convertValue(name, value, definition)
+	if err != nil {
+		return nil, err
+	}
+
 	// set variable so that we don't ask again
 	r.memoryCache[name] = value
 	return value, nil
@@ -163,6 +204,14 @@ func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[st
 		})
 	}
 
+	// check secret sources
+	for _, field := range secretSourceFields(definition) {
+		_, _ = varspkg.ParseString(*field, func(v string) (interface{}, error) {
+			varsUsed[v] = true
+			return "", nil
+		})
+	}
+
 	// check commands
 	for _, osDef := range definition.Commands {
 		// check command
@@ -224,6 +273,14 @@ func (r *resolver) fillVariableDefinition(definition *latest.Variable) error {
 		}
 	}
 
+	// resolve secret sources
+	for _, field := range secretSourceFields(definition) {
+		*field, err = r.resolveDefinitionStringToString(*field, definition)
+		if err != nil {
+			return err
+		}
+	}
+
 	// resolve commands
 	for ci := range definition.Commands {
 		definition.Commands[ci].Command, err = r.resolveDefinitionStringToString(definition.Commands[ci].Command, definition)
@@ -252,6 +309,10 @@ func (r *resolver) resolveDefinitionStringToString(str string, definition *lates
 
 func (r *resolver) resolveDefinitionString(str string, definition *latest.Variable) (interface{}, error) {
 	return varspkg.ParseString(str, func(varName string) (interface{}, error) {
+		if dependency, output, ok := ParseDependencyOutput(varName); ok {
+			return DependencyOutputVariable(dependency, output), nil
+		}
+
 		v, ok := r.memoryCache[varName]
 		if !ok {
 			// check if its a predefined variable
@@ -277,6 +338,30 @@ func (r *resolver) resolveDefaultValue(definition *latest.Variable) (interface{}
 	return r.resolveDefinitionString(defaultString, definition)
 }
 
+// convertValue converts the value to the type of the variable definition and validates it. Cached
+// values are stored in their canonical form, so that they can be loaded again without losing information
+func (r *resolver) convertValue(name string, value interface{}, definition *latest.Variable) (interface{}, error) {
+	if definition == nil || definition.Type == latest.VariableTypeAuto {
+		return value, nil
+	}
+
+	converted, err := ConvertValue(value, definition.Type)
+	if err != nil {
+		return nil, errors.Wrapf(err, "variable ${%s}", name)
+	}
+
+	err = ValidateValue(converted, definition)
+	if err != nil {
+		return nil, errors.Wrapf(err, "variable ${%s}", name)
+	}
+
+	if _, ok := r.persistentCache[name]; ok && !definition.NoCache {
+		r.persistentCache[name] = EncodeValue(converted)
+	}
+
+	return converted, nil
+}
+
 func (r *resolver) fillVariable(name string, definition *latest.Variable) (interface{}, error) {
 	// is predefined variable?
 	variable, err := NewPredefinedVariable(name, r.persistentCache, r.options)
@@ -302,7 +387,13 @@ func (r *resolver) fillVariable(name string, definition *latest.Variable) (inter
 		return NewNoneVariable(name).Load(definition)
 	case latest.VariableSourceCommand:
 		return NewCommandVariable(name).Load(definition)
+	case latest.VariableSourceSecret:
+		return NewSecretVariable(name, newKubeClientFn(r.options)).Load(definition)
+	case latest.VariableSourceSOPS:
+		return NewSOPSVariable(name).Load(definition)
+	case latest.VariableSourceVault:
+		return NewVaultVariable(name).Load(definition)
 	default:
-		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env' or 'none'", name)
+		return nil, errors.Errorf("unrecognized variable source '%s', please choose one of 'all', 'input', 'env', 'command', 'secret', 'sops', 'vault' or 'none'", name)
 	}
 }
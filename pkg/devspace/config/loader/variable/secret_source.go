@@ -0,0 +1,95 @@
+package variable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+)
+
+// IsSecretSource returns true if values of the source are secret and should never be
+// persisted or printed
+func IsSecretSource(source latest.VariableSource) bool {
+	return source == latest.VariableSourceSecret || source == latest.VariableSourceSOPS || source == latest.VariableSourceVault
+}
+
+// secretSourceFields returns the fields of the secret sources that can contain variables
+func secretSourceFields(definition *latest.Variable) []*string {
+	fields := []*string{}
+	if definition.Secret != nil {
+		fields = append(fields, &definition.Secret.Name, &definition.Secret.Namespace, &definition.Secret.Key)
+	}
+	if definition.SOPS != nil {
+		fields = append(fields, &definition.SOPS.File, &definition.SOPS.Key)
+	}
+	if definition.Vault != nil {
+		fields = append(fields, &definition.Vault.Address, &definition.Vault.Path, &definition.Vault.Key)
+	}
+
+	return fields
+}
+
+// minSecretLength is the minimum length of a secret value that is redacted. Redacting shorter
+// values would replace common substrings of the output, e.g. every "1" or "on".
+const minSecretLength = 4
+
+// addSecret registers all string and number values of a secret variable for redaction. Secret
+// values that look like numbers are converted before, so numbers are registered in their string
+// form. Booleans are skipped, because redacting them would make the output unreadable.
+func addSecret(value interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			addSecret(item)
+		}
+	case map[interface{}]interface{}:
+		for _, item := range v {
+			addSecret(item)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			addSecret(item)
+		}
+	case int, int64, float64:
+		addSecret(fmt.Sprint(v))
+	case string:
+		if len(strings.TrimSpace(v)) >= minSecretLength {
+			log.AddSecret(v)
+		}
+	}
+}
+
+// RedactValue returns a copy of the value with all registered secrets redacted. Values should be
+// redacted before they are encoded, because an encoded secret might not match the registered one
+// anymore, e.g. if json escapes quotes within the secret.
+func RedactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		out := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			out = append(out, RedactValue(item))
+		}
+		return out
+	case map[interface{}]interface{}:
+		out := map[interface{}]interface{}{}
+		for key, item := range v {
+			out[key] = RedactValue(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for key, item := range v {
+			out[key] = RedactValue(item)
+		}
+		return out
+	case string:
+		return log.Redact(v)
+	case int, int64, float64:
+		if redacted := log.Redact(fmt.Sprint(v)); redacted != fmt.Sprint(v) {
+			return redacted
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,173 @@
+package variable
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretVariable(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			"password": []byte("s3cret"),
+			"user":     []byte("admin"),
+		},
+	})
+	clientFn := func() (kubernetes.Interface, string, error) {
+		return client, "test", nil
+	}
+
+	value, err := NewSecretVariable("DB_PASSWORD", clientFn).Load(&latest.Variable{
+		Source: latest.VariableSourceSecret,
+		Secret: &latest.VariableSecretSource{Name: "db", Key: "password"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "s3cret")
+
+	value, err = NewSecretVariable("DB", clientFn).Load(&latest.Variable{
+		Source: latest.VariableSourceSecret,
+		Secret: &latest.VariableSecretSource{Name: "db"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, map[interface{}]interface{}{"password": "s3cret", "user": "admin"})
+
+	_, err = NewSecretVariable("DB_HOST", clientFn).Load(&latest.Variable{
+		Source: latest.VariableSourceSecret,
+		Secret: &latest.VariableSecretSource{Name: "db", Key: "host"},
+	})
+	assert.Error(t, err, "couldn't set variable 'DB_HOST', because secret test/db has no key host")
+}
+
+func TestVaultVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/app":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"abc123"},"metadata":{"version":1}}}`))
+		case "/v1/kv/app":
+			_, _ = w.Write([]byte(`{"data":{"token":"def456"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	oldToken := os.Getenv("VAULT_TOKEN")
+	defer os.Setenv("VAULT_TOKEN", oldToken)
+	os.Setenv("VAULT_TOKEN", "root")
+
+	value, err := NewVaultVariable("TOKEN").Load(&latest.Variable{
+		Source: latest.VariableSourceVault,
+		Vault:  &latest.VariableVaultSource{Address: server.URL, Path: "secret/data/app", Key: "token"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "abc123")
+
+	value, err = NewVaultVariable("TOKEN").Load(&latest.Variable{
+		Source: latest.VariableSourceVault,
+		Vault:  &latest.VariableVaultSource{Address: server.URL, Path: "kv/app"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, value, map[interface{}]interface{}{"token": "def456"})
+
+	_, err = NewVaultVariable("TOKEN").Load(&latest.Variable{
+		Source: latest.VariableSourceVault,
+		Vault:  &latest.VariableVaultSource{Address: server.URL, Path: "kv/other", Key: "token"},
+	})
+	assert.Error(t, err, "couldn't set variable 'TOKEN', because reading vault secret kv/other failed: 404 Not Found")
+
+	os.Setenv("VAULT_TOKEN", "wrong")
+	_, err = NewVaultVariable("TOKEN").Load(&latest.Variable{
+		Source: latest.VariableSourceVault,
+		Vault:  &latest.VariableVaultSource{Address: server.URL, Path: "kv/app", Key: "token"},
+	})
+	assert.Error(t, err, "couldn't set variable 'TOKEN', because reading vault secret kv/app failed: permission denied")
+}
+
+func TestSecretVariableNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"token":"not-persisted-token"}}`))
+	}))
+	defer server.Close()
+
+	oldToken := os.Getenv("VAULT_TOKEN")
+	defer os.Setenv("VAULT_TOKEN", oldToken)
+	os.Setenv("VAULT_TOKEN", "root")
+
+	cache := map[string]string{"TOKEN": "old"}
+	value, err := NewResolver(cache, &PredefinedVariableOptions{}, log.Discard).Resolve("TOKEN", &latest.Variable{
+		Name:   "TOKEN",
+		Source: latest.VariableSourceVault,
+		Vault:  &latest.VariableVaultSource{Address: server.URL, Path: "kv/app", Key: "token"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, value, "not-persisted-token")
+	assert.DeepEqual(t, cache, map[string]string{})
+	assert.Equal(t, log.Redact("token=not-persisted-token"), "token="+log.RedactedValue)
+}
+
+func TestLookupKey(t *testing.T) {
+	data := map[string]interface{}{
+		"database": map[string]interface{}{
+			"hosts": []interface{}{"a", "b"},
+		},
+	}
+
+	value, err := lookupKey(data, "database.hosts.1")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "b")
+
+	_, err = lookupKey(data, "database.password")
+	assert.Error(t, err, "key database.password not found")
+}
+
+func TestAddSecret(t *testing.T) {
+	addSecret(map[interface{}]interface{}{
+		"password": "long-secret-password",
+		"short":    "abc",
+		"port":     5432,
+		"enabled":  true,
+		"pin":      123456,
+		"list":     []interface{}{"another-secret", 1},
+	})
+
+	assert.Equal(t, log.Redact("password=long-secret-password"), "password="+log.RedactedValue)
+	assert.Equal(t, log.Redact("list=another-secret"), "list="+log.RedactedValue)
+	assert.Equal(t, log.Redact("pin=123456"), "pin="+log.RedactedValue)
+	assert.Equal(t, log.Redact("abcdef listening on 5432 true"), "abcdef listening on "+log.RedactedValue+" true")
+	assert.Equal(t, log.Redact("replicas 1"), "replicas 1")
+}
+
+func TestRedactValue(t *testing.T) {
+	log.AddSecret(`quoted"secret\\value`)
+	log.AddSecret("987654")
+
+	assert.DeepEqual(t, RedactValue(map[string]interface{}{
+		"password": `quoted"secret\\value`,
+		"pin":      987654,
+		"list":     []interface{}{"public", 1},
+	}), map[string]interface{}{
+		"password": log.RedactedValue,
+		"pin":      log.RedactedValue,
+		"list":     []interface{}{"public", 1},
+	})
+}
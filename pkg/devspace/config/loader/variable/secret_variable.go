@@ -0,0 +1,88 @@
+package variable
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KubeClientFn returns a kubernetes client and the current namespace
+type KubeClientFn func() (kubernetes.Interface, string, error)
+
+// NewSecretVariable creates a new variable that is read from a kubernetes secret
+func NewSecretVariable(name string, client KubeClientFn) Variable {
+	return &secretVariable{
+		name:   name,
+		client: client,
+	}
+}
+
+type secretVariable struct {
+	name   string
+	client KubeClientFn
+}
+
+func (s *secretVariable) Load(definition *latest.Variable) (interface{}, error) {
+	if definition.Secret == nil || definition.Secret.Name == "" {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no secret.name is specified", s.name, latest.VariableSourceSecret)
+	}
+
+	client, namespace, err := s.client()
+	if err != nil {
+		return nil, errors.Wrapf(err, "create kubernetes client for variable '%s'", s.name)
+	}
+	if definition.Secret.Namespace != "" {
+		namespace = definition.Secret.Namespace
+	}
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(context.TODO(), definition.Secret.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't set variable '%s'", s.name)
+	}
+
+	if definition.Secret.Key == "" {
+		data := map[interface{}]interface{}{}
+		for key, value := range secret.Data {
+			data[key] = string(value)
+		}
+
+		return data, nil
+	}
+
+	value, ok := secret.Data[definition.Secret.Key]
+	if !ok {
+		return nil, errors.Errorf("couldn't set variable '%s', because secret %s/%s has no key %s", s.name, namespace, definition.Secret.Name, definition.Secret.Key)
+	}
+
+	return convertDefinitionValue(string(value), definition), nil
+}
+
+// newKubeClientFn returns a function that creates a kubernetes client from the predefined variable options
+func newKubeClientFn(options *PredefinedVariableOptions) KubeClientFn {
+	return func() (kubernetes.Interface, string, error) {
+		if options == nil || options.KubeConfigLoader == nil {
+			return nil, "", errors.New("no kube config loader available")
+		}
+
+		clientConfig, _, namespace, _, err := util.NewClientByContext(options.KubeContextFlag, options.NamespaceFlag, false, options.KubeConfigLoader)
+		if err != nil {
+			return nil, "", err
+		}
+
+		restConfig, err := clientConfig.ClientConfig()
+		if err != nil {
+			return nil, "", err
+		}
+
+		client, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return client, namespace, nil
+	}
+}
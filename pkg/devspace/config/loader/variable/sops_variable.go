@@ -0,0 +1,88 @@
+package variable
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	"github.com/pkg/errors"
+)
+
+// sopsBinary is the sops executable that is used to decrypt files
+var sopsBinary = "sops"
+
+// NewSOPSVariable creates a new variable that is read from a sops encrypted file
+func NewSOPSVariable(name string) Variable {
+	return &sopsVariable{
+		name: name,
+	}
+}
+
+type sopsVariable struct {
+	name string
+}
+
+func (s *sopsVariable) Load(definition *latest.Variable) (interface{}, error) {
+	if definition.SOPS == nil || definition.SOPS.File == "" {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no sops.file is specified", s.name, latest.VariableSourceSOPS)
+	}
+
+	// decrypted values are only kept in memory
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := command.ExecuteCommand(sopsBinary, []string{"--decrypt", "--output-type", "json", definition.SOPS.File}, stdout, stderr)
+	if err != nil {
+		return nil, errors.Errorf("couldn't set variable '%s', because decrypting %s failed: %v %s", s.name, definition.SOPS.File, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var data interface{}
+	err = json.Unmarshal(stdout.Bytes(), &data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse decrypted file %s", definition.SOPS.File)
+	}
+
+	value, err := lookupKey(data, definition.SOPS.Key)
+	if err != nil {
+		return nil, errors.Errorf("couldn't set variable '%s', because %s: %v", s.name, definition.SOPS.File, err)
+	}
+
+	if str, ok := value.(string); ok {
+		return convertDefinitionValue(str, definition), nil
+	}
+
+	return normalize(value), nil
+}
+
+// lookupKey returns the value at the given path, e.g. database.password or hosts.0
+func lookupKey(data interface{}, key string) (interface{}, error) {
+	if key == "" {
+		return data, nil
+	}
+
+	current := data
+	for _, segment := range strings.Split(key, ".") {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[segment]
+			if !ok {
+				return nil, errors.Errorf("key %s not found", key)
+			}
+
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil, errors.Errorf("key %s not found", key)
+			}
+
+			current = value[index]
+		default:
+			return nil, errors.Errorf("key %s not found", key)
+		}
+	}
+
+	return current, nil
+}
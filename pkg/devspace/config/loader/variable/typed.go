@@ -0,0 +1,272 @@
+package variable
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidTypes are all supported variable types
+var ValidTypes = []latest.VariableType{
+	latest.VariableTypeString,
+	latest.VariableTypeInt,
+	latest.VariableTypeBool,
+	latest.VariableTypeList,
+	latest.VariableTypeMap,
+}
+
+// IsValidType checks if the given type is a supported variable type
+func IsValidType(varType latest.VariableType) bool {
+	if varType == latest.VariableTypeAuto {
+		return true
+	}
+	for _, validType := range ValidTypes {
+		if validType == varType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConvertValue converts the value to the given variable type. Strings are parsed, so that
+// list and map variables can be specified via environment variables, flags or questions,
+// e.g. a,b,c or [a, b, c] for lists and {a: b} for maps
+func ConvertValue(value interface{}, varType latest.VariableType) (interface{}, error) {
+	switch varType {
+	case latest.VariableTypeAuto:
+		if str, ok := value.(string); ok {
+			return convertStringValue(str), nil
+		}
+
+		return value, nil
+	case latest.VariableTypeString:
+		switch value.(type) {
+		case []interface{}, map[interface{}]interface{}, map[string]interface{}:
+			return nil, errors.Errorf("expected a string, but got %s", describe(value))
+		}
+
+		return fmt.Sprintf("%v", value), nil
+	case latest.VariableTypeInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		case string:
+			i, err := strconv.Atoi(strings.TrimSpace(v))
+			if err == nil {
+				return i, nil
+			}
+		}
+
+		return nil, errors.Errorf("expected an integer, but got %s", describe(value))
+	case latest.VariableTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err == nil {
+				return b, nil
+			}
+		}
+
+		return nil, errors.Errorf("expected a boolean, but got %s", describe(value))
+	case latest.VariableTypeList:
+		switch v := value.(type) {
+		case []interface{}:
+			return normalizeList(v), nil
+		case string:
+			return parseList(v)
+		}
+
+		return nil, errors.Errorf("expected a list, but got %s", describe(value))
+	case latest.VariableTypeMap:
+		switch v := value.(type) {
+		case map[interface{}]interface{}, map[string]interface{}:
+			return normalizeMap(v), nil
+		case string:
+			return parseMap(v)
+		}
+
+		return nil, errors.Errorf("expected a map, but got %s", describe(value))
+	}
+
+	return nil, errors.Errorf("unsupported variable type %s", varType)
+}
+
+func parseList(value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []interface{}{}, nil
+	} else if !strings.HasPrefix(value, "[") {
+		list := []interface{}{}
+		for _, item := range strings.Split(value, ",") {
+			list = append(list, strings.TrimSpace(item))
+		}
+
+		return list, nil
+	}
+
+	list := []interface{}{}
+	err := yaml.Unmarshal([]byte(value), &list)
+	if err != nil {
+		return nil, errors.Errorf("expected a list, but got %s", strconv.Quote(value))
+	}
+
+	return normalizeList(list), nil
+}
+
+func parseMap(value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return map[interface{}]interface{}{}, nil
+	}
+
+	m := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(value), &m)
+	if err != nil {
+		return nil, errors.Errorf("expected a map, but got %s", strconv.Quote(value))
+	}
+
+	return normalizeMap(m), nil
+}
+
+// normalizeMap returns a copy of the map with all keys converted to strings, so that the value
+// can be used within the config and encoded as json
+func normalizeMap(value interface{}) map[interface{}]interface{} {
+	ret := map[interface{}]interface{}{}
+	switch m := value.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			ret[fmt.Sprintf("%v", k)] = normalize(v)
+		}
+	case map[string]interface{}:
+		for k, v := range m {
+			ret[k] = normalize(v)
+		}
+	}
+
+	return ret
+}
+
+func normalizeList(list []interface{}) []interface{} {
+	ret := make([]interface{}, 0, len(list))
+	for _, v := range list {
+		ret = append(ret, normalize(v))
+	}
+
+	return ret
+}
+
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}, map[string]interface{}:
+		return normalizeMap(v)
+	case []interface{}:
+		return normalizeList(v)
+	}
+
+	return value
+}
+
+// ValidateValue checks that the already converted value matches the options and the validation
+// pattern of the variable. For lists every item has to match.
+func ValidateValue(value interface{}, definition *latest.Variable) error {
+	items := []interface{}{value}
+	switch v := value.(type) {
+	case map[interface{}]interface{}, map[string]interface{}:
+		return nil
+	case []interface{}:
+		items = v
+	}
+
+	var pattern *regexp.Regexp
+	if definition.ValidationPattern != "" {
+		var err error
+		pattern, err = regexp.Compile(definition.ValidationPattern)
+		if err != nil {
+			return errors.Wrap(err, "compile validation pattern")
+		}
+	}
+
+	for _, item := range items {
+		str := fmt.Sprintf("%v", item)
+		if len(definition.Options) > 0 && !contains(definition.Options, str) {
+			return errors.Errorf("unsupported value %s, please use one of: %s", str, strings.Join(definition.Options, ", "))
+		}
+		if pattern != nil && !pattern.MatchString(str) {
+			if definition.ValidationMessage != "" {
+				return errors.New(definition.ValidationMessage)
+			}
+
+			return errors.Errorf("value %s does not match pattern %s", str, definition.ValidationPattern)
+		}
+	}
+
+	return nil
+}
+
+// EncodeValue returns the string representation of a variable value. Lists and maps are
+// encoded as json, which can be parsed again by ConvertValue without losing information.
+func EncodeValue(value interface{}) string {
+	if isStructured(value) {
+		out, err := json.Marshal(JSONValue(value))
+		if err == nil {
+			return string(out)
+		}
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// JSONValue returns a copy of the value that can be encoded as json
+func JSONValue(value interface{}) interface{} {
+	return yamlutil.Convert(normalize(value))
+}
+
+func isStructured(value interface{}) bool {
+	switch value.(type) {
+	case []interface{}, map[interface{}]interface{}, map[string]interface{}:
+		return true
+	}
+
+	return false
+}
+
+func describe(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		return "a list"
+	case map[interface{}]interface{}, map[string]interface{}:
+		return "a map"
+	case string:
+		return strconv.Quote(v)
+	case nil:
+		return "an empty value"
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package variable
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type convertTestCase struct {
+	value         interface{}
+	varType       latest.VariableType
+	expected      interface{}
+	expectedError string
+}
+
+func TestConvertValue(t *testing.T) {
+	testCases := map[string]convertTestCase{
+		"Keep numeric string": {
+			value:    "0123",
+			varType:  latest.VariableTypeString,
+			expected: "0123",
+		},
+		"Int to string": {
+			value:    5,
+			varType:  latest.VariableTypeString,
+			expected: "5",
+		},
+		"Parse int": {
+			value:    " 42 ",
+			varType:  latest.VariableTypeInt,
+			expected: 42,
+		},
+		"Invalid int": {
+			value:         "abc",
+			varType:       latest.VariableTypeInt,
+			expectedError: `expected an integer, but got "abc"`,
+		},
+		"Parse bool": {
+			value:    "true",
+			varType:  latest.VariableTypeBool,
+			expected: true,
+		},
+		"Comma separated list": {
+			value:    "a, b,c",
+			varType:  latest.VariableTypeList,
+			expected: []interface{}{"a", "b", "c"},
+		},
+		"Flow list": {
+			value:    "[a, 1, {b: c}]",
+			varType:  latest.VariableTypeList,
+			expected: []interface{}{"a", 1, map[interface{}]interface{}{"b": "c"}},
+		},
+		"Empty list": {
+			value:    "",
+			varType:  latest.VariableTypeList,
+			expected: []interface{}{},
+		},
+		"Parse map": {
+			value:    `{"a": "b", "1": [c]}`,
+			varType:  latest.VariableTypeMap,
+			expected: map[interface{}]interface{}{"a": "b", "1": []interface{}{"c"}},
+		},
+		"Map keys to string": {
+			value:    map[interface{}]interface{}{1: "a"},
+			varType:  latest.VariableTypeMap,
+			expected: map[interface{}]interface{}{"1": "a"},
+		},
+		"List to string": {
+			value:         []interface{}{"a"},
+			varType:       latest.VariableTypeString,
+			expectedError: "expected a string, but got a list",
+		},
+	}
+
+	for name, testCase := range testCases {
+		value, err := ConvertValue(testCase.value, testCase.varType)
+		if testCase.expectedError != "" {
+			assert.Error(t, err, testCase.expectedError, name)
+			continue
+		}
+
+		assert.NilError(t, err, name)
+		assert.DeepEqual(t, value, testCase.expected)
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	definition := &latest.Variable{
+		Name:              "FEATURES",
+		Type:              latest.VariableTypeList,
+		Options:           []string{"a", "b"},
+		ValidationPattern: "^[a-z]+$",
+	}
+
+	assert.NilError(t, ValidateValue([]interface{}{"a", "b"}, definition))
+	assert.Error(t, ValidateValue([]interface{}{"a", "c"}, definition), "unsupported value c, please use one of: a, b")
+
+	definition.Options = nil
+	definition.ValidationMessage = "only lowercase letters are allowed"
+	assert.Error(t, ValidateValue([]interface{}{"A"}, definition), "only lowercase letters are allowed")
+}
+
+func TestEncodeValue(t *testing.T) {
+	value := map[interface{}]interface{}{"a": []interface{}{"b", 1}}
+	encoded := EncodeValue(value)
+	assert.Equal(t, encoded, `{"a":["b",1]}`)
+
+	decoded, err := ConvertValue(encoded, latest.VariableTypeMap)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decoded, value)
+
+	assert.Equal(t, EncodeValue(true), "true")
+}
+
+func TestFillTypedVariables(t *testing.T) {
+	cache := map[string]string{
+		"FEATURES": "a,b",
+	}
+	resolver := NewResolver(cache, &PredefinedVariableOptions{}, log.Discard)
+	_, err := resolver.Resolve("FEATURES", &latest.Variable{
+		Name: "FEATURES",
+		Type: latest.VariableTypeList,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, cache["FEATURES"], `["a","b"]`)
+
+	_, err = resolver.Resolve("LABELS", &latest.Variable{
+		Name:   "LABELS",
+		Type:   latest.VariableTypeMap,
+		Source: latest.VariableSourceNone,
+		Value:  map[interface{}]interface{}{"app": "web"},
+	})
+	assert.NilError(t, err)
+
+	config := map[interface{}]interface{}{
+		"features": "${FEATURES}",
+		"labels":   "${LABELS}",
+	}
+	err = resolver.FillVariables(config)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, config, map[interface{}]interface{}{
+		"features": []interface{}{"a", "b"},
+		"labels":   map[interface{}]interface{}{"app": "web"},
+	})
+
+	err = resolver.FillVariables(map[interface{}]interface{}{"name": "web-${FEATURES}"})
+	assert.Error(t, err, "variable FEATURES is a list and can only be used as a whole value, but is used within 'web-${FEATURES}'")
+}
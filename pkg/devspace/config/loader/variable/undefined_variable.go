@@ -1,7 +1,6 @@
 package variable
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 
@@ -59,6 +58,16 @@ func convertStringValue(value string) interface{} {
 	return value
 }
 
+// convertDefinitionValue guesses the type of the value if the variable has no type. Values of typed
+// variables are converted by the resolver
+func convertDefinitionValue(value string, definition *latest.Variable) interface{} {
+	if definition != nil && definition.Type != latest.VariableTypeAuto {
+		return value
+	}
+
+	return convertStringValue(value)
+}
+
 func askQuestion(variable *latest.Variable, log log.Logger) (string, error) {
 	params := &survey.QuestionOptions{}
 
@@ -79,16 +88,28 @@ func askQuestion(variable *latest.Variable, log log.Logger) (string, error) {
 			params.IsPassword = true
 		}
 
-		if variable.Default != "" {
-			params.DefaultValue = fmt.Sprintf("%v", variable.Default)
+		if variable.Default != "" && variable.Default != nil {
+			params.DefaultValue = EncodeValue(variable.Default)
+		}
+
+		// typed variables are validated the same way as values from other sources
+		if variable.Type != latest.VariableTypeAuto {
+			params.ValidationFunc = func(value string) error {
+				converted, err := ConvertValue(value, variable.Type)
+				if err != nil {
+					return err
+				}
+
+				return ValidateValue(converted, variable)
+			}
 		}
 
-		if len(variable.Options) > 0 {
+		if len(variable.Options) > 0 && variable.Type != latest.VariableTypeList {
 			params.Options = variable.Options
 			if variable.Default == nil {
 				params.DefaultValue = params.Options[0]
 			}
-		} else if variable.ValidationPattern != "" {
+		} else if variable.ValidationPattern != "" && variable.Type == latest.VariableTypeAuto {
 			params.ValidationRegexPattern = variable.ValidationPattern
 
 			if variable.ValidationMessage != "" {
@@ -0,0 +1,127 @@
+package variable
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// DefaultVaultAddress is the vault address that is used if neither address nor VAULT_ADDR is set
+const DefaultVaultAddress = "https://127.0.0.1:8200"
+
+// NewVaultVariable creates a new variable that is read from a vault secret
+func NewVaultVariable(name string) Variable {
+	return &vaultVariable{
+		name:   name,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type vaultVariable struct {
+	name   string
+	client *http.Client
+}
+
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+func (v *vaultVariable) Load(definition *latest.Variable) (interface{}, error) {
+	if definition.Vault == nil || definition.Vault.Path == "" {
+		return nil, errors.Errorf("couldn't set variable '%s', because source is '%s' but no vault.path is specified", v.name, latest.VariableSourceVault)
+	}
+
+	address := definition.Vault.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		address = DefaultVaultAddress
+	}
+
+	token, err := vaultToken()
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't set variable '%s'", v.name)
+	}
+
+	url := strings.TrimSuffix(address, "/") + "/v1/" + strings.TrimPrefix(definition.Vault.Path, "/")
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		request.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	response, err := v.client.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't set variable '%s'", v.name)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &vaultResponse{}
+	err = json.Unmarshal(body, secret)
+	if response.StatusCode != http.StatusOK {
+		message := response.Status
+		if err == nil && len(secret.Errors) > 0 {
+			message = strings.Join(secret.Errors, ", ")
+		}
+
+		return nil, errors.Errorf("couldn't set variable '%s', because reading vault secret %s failed: %s", v.name, definition.Vault.Path, message)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "parse vault response")
+	}
+
+	// the kv version 2 engine wraps the secret data together with its metadata
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok && data["metadata"] != nil {
+		data = nested
+	}
+
+	if definition.Vault.Key == "" {
+		return normalize(data), nil
+	}
+
+	value, ok := data[definition.Vault.Key]
+	if !ok {
+		return nil, errors.Errorf("couldn't set variable '%s', because vault secret %s has no key %s", v.name, definition.Vault.Path, definition.Vault.Key)
+	} else if str, ok := value.(string); ok {
+		return convertDefinitionValue(str, definition), nil
+	}
+
+	return normalize(value), nil
+}
+
+// vaultToken returns the token of the VAULT_TOKEN environment variable or the token helper file
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return "", errors.New("no vault token found, please set VAULT_TOKEN or login via vault login")
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
@@ -0,0 +1,321 @@
+// Code generated by hack/genschema. DO NOT EDIT.
+
+package schema
+
+var typeDescriptions = map[string]string{
+	"AutoReloadConfig":            "AutoReloadConfig defines the struct for auto reloading devspace with additional paths",
+	"AutoScalingConfig":           "AutoScalingConfig holds the autoscaling config of a component",
+	"AutoScalingHorizontalConfig": "AutoScalingHorizontalConfig holds the horizontal autoscaling config of a component",
+	"BandwidthLimits":             "BandwidthLimits defines the struct for specifying the sync bandwidth limits",
+	"BuildConfig":                 "BuildConfig defines the build process for an image. Only one of the options below\ncan be specified.",
+	"BuildKitConfig":              "BuildKitConfig tells the DevSpace CLI to",
+	"BuildKitInClusterConfig":     "BuildKitInClusterConfig holds the buildkit builder config",
+	"BuildOptions":                "BuildOptions defines options for building Docker images",
+	"ChartConfig":                 "ChartConfig defines the helm chart options",
+	"CommandArg":                  "CommandArg defines a positional argument of a command",
+	"CommandArgType":              "CommandArgType is the type of a command flag or argument",
+	"CommandConfig":               "CommandConfig defines the command specification",
+	"CommandContainer":            "CommandContainer defines the container a command is executed in",
+	"CommandFlag":                 "CommandFlag defines a flag of a command",
+	"ComponentConfig":             "ComponentConfig holds the component information",
+	"Config":                      "Config defines the configuration",
+	"ContainerConfig":             "ContainerConfig holds the configurations of a container",
+	"CustomConfig":                "CustomConfig tells the DevSpace CLI to build with a custom build script",
+	"CustomConfigCommand":         "CustomConfigCommand holds the information about a command on a specific operating system",
+	"DependencyConfig":            "DependencyConfig defines the devspace dependency",
+	"DependencyDev":               "DependencyDev specifies which parts of the dependency dev config should\nbe reused",
+	"DependencyVar":               "DependencyVar holds an override value for a config variable",
+	"DeploymentConfig":            "DeploymentConfig defines the configuration how the devspace should be deployed",
+	"DevConfig":                   "DevConfig defines the devspace deployment",
+	"DockerConfig":                "DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost",
+	"HelmConfig":                  "HelmConfig defines the specific helm options used during deployment",
+	"HookConfig":                  "HookConfig defines a hook",
+	"HookContainer":               "HookContainer defines how to select one or more containers to execute a hook in",
+	"HookJobConfig":               "HookJobConfig defines a job that is created by a hook",
+	"HookLogsConfig":              "HookLogsConfig defines a hook logs config",
+	"HookRetryConfig":             "HookRetryConfig defines how often a failed hook is retried",
+	"HookSyncConfig":              "HookSyncConfig defines a hook upload config",
+	"HookWaitConfig":              "HookWaitConfig defines a hook wait config",
+	"HookWebhookConfig":           "HookWebhookConfig defines a http request that is sent by a hook",
+	"ImageConfig":                 "ImageConfig defines the image specification",
+	"IngressConfig":               "IngressConfig holds the configuration of a component ingress",
+	"IngressRuleConfig":           "IngressRuleConfig holds the port configuration of a component service",
+	"InitialSyncCompareBy":        "InitialSyncCompareBy is the type of how a change should be determined during the initial sync",
+	"InitialSyncStrategy":         "InitialSyncStrategy is the type of a initial sync strategy",
+	"InteractiveImageConfig":      "InteractiveImageConfig describes the interactive mode options for an image",
+	"KanikoAdditionalMount":       "KanikoAdditionalMount tells devspace how the additional mount of the kaniko pod should look like",
+	"KanikoConfig":                "KanikoConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost",
+	"KanikoPodResources":          "KanikoPodResources describes the resources section of the started kaniko pod",
+	"KubectlConfig":               "KubectlConfig defines the specific kubectl options used during deployment",
+	"LogsConfig":                  "LogsConfig specifies the logs options for devspace dev",
+	"LogsPersistConfig":           "LogsPersistConfig defines how streamed logs are stored on disk",
+	"LogsSelector":                "LogsSelector holds configuration how to select a log target",
+	"OpenConfig":                  "OpenConfig defines what to open after services have been started",
+	"OutputConfig":                "OutputConfig defines a single dependency output",
+	"PatchConfig":                 "PatchConfig describes a config patch and how it should be applied",
+	"PluginBuildConfig":           "PluginBuildConfig tells the DevSpace CLI to build with a builder of a plugin",
+	"PluginDeploymentConfig":      "PluginDeploymentConfig tells the DevSpace CLI to deploy with a deployer of a plugin",
+	"PodPatch":                    "PodPatch will patch a pod's owning ReplicaSet, Deployment or StatefulSet with the givens patches or image",
+	"PortForwardingConfig":        "PortForwardingConfig defines the ports for a port forwarding to a DevSpace",
+	"PortMapping":                 "PortMapping defines the ports for a PortMapping",
+	"ProfileActivation":           "ProfileActivation defines rules that automatically activate a profile when evaluated to true",
+	"ProfileConfig":               "ProfileConfig defines a profile config",
+	"ProfileConfigStructure":      "ProfileConfigStructure is the base structure used to validate profiles",
+	"ProfileParent":               "ProfileParent defines where to load the profile from",
+	"PullSecretConfig":            "PullSecretConfig defines a pull secret that should be created by DevSpace",
+	"RebuildStrategy":             "RebuildStrategy is the type of a image rebuild strategy",
+	"ReplacePod":                  "ReplacePod will replace the selected target pod/container with a new image and optionally apply\npod patches.",
+	"RollingUpdateConfig":         "RollingUpdateConfig holds the configuration for rolling updates",
+	"SSH":                         "SSH describes the ssh server options",
+	"ServiceConfig":               "ServiceConfig holds the configuration of a component service",
+	"ServicePortConfig":           "ServicePortConfig holds the port configuration of a component service",
+	"SourceConfig":                "SourceConfig defines the dependency source",
+	"SyncCommand":                 "SyncCommand holds a command definition",
+	"SyncConfig":                  "SyncConfig defines the paths for a SyncFolder",
+	"SyncExecCommand":             "SyncExecCommand holds the configuration of commands that should be executed when files / folders are change",
+	"SyncOnDownload":              "SyncOnDownload defines the struct for the command that should be executed when files / folders are downloaded",
+	"SyncOnUpload":                "SyncOnUpload defines the struct for the command that should be executed when files / folders are uploaded",
+	"Terminal":                    "Terminal describes the terminal options",
+	"TerminalDebug":               "TerminalDebug describes the ephemeral debug container options",
+	"Variable":                    "Variable describes the var definition",
+	"VariableSOPSSource":          "VariableSOPSSource defines a value within a SOPS encrypted file",
+	"VariableSecretSource":        "VariableSecretSource defines a key of a Kubernetes secret",
+	"VariableSource":              "VariableSource is type of a variable source",
+	"VariableType":                "VariableType is the type of a variable value",
+	"VariableVaultSource":         "VariableVaultSource defines a key of a Vault secret. The token is read from the VAULT_TOKEN\nenvironment variable or ~/.vault-token",
+	"VolumeConfig":                "VolumeConfig holds the configuration for a specific volume",
+	"VolumeMountConfig":           "VolumeMountConfig holds the configuration for a specific mount path",
+	"VolumeMountVolumeConfig":     "VolumeMountVolumeConfig holds the configuration for a specific mount path volume",
+}
+
+var fieldDescriptions = map[string]string{
+	"BuildConfig.BuildKit":                       "If buildKit is specified, DevSpace will build the image either in-cluster or locally with BuildKit",
+	"BuildConfig.Custom":                         "If custom is specified, DevSpace will build the image with the help of\na custom script.",
+	"BuildConfig.Disabled":                       "This overrides other options and is able to disable the build for this image.\nUseful if you just want to select the image in a sync path or via devspace enter --image",
+	"BuildConfig.Docker":                         "If docker is specified, DevSpace will build the image using the local docker daemon",
+	"BuildConfig.Kaniko":                         "If kaniko is specified, DevSpace will build the image in-cluster with kaniko",
+	"BuildConfig.Plugin":                         "If plugin is specified, DevSpace will build the image with a builder that is\nprovided by an installed grpc plugin",
+	"BuildKitConfig.Args":                        "Additional arguments to call docker buildx build with",
+	"BuildKitConfig.Command":                     "Override the base command to create a builder and build images. Defaults to [\"docker\", \"buildx\"]",
+	"BuildKitConfig.InCluster":                   "If specified, DevSpace will use BuildKit to build the image within the cluster",
+	"BuildKitConfig.Options":                     "Additional build options",
+	"BuildKitConfig.PreferMinikube":              "If false, will not try to use the minikube docker daemon to build the image",
+	"BuildKitConfig.SkipPush":                    "If this is true, DevSpace will not push any images",
+	"BuildKitInClusterConfig.CreateArgs":         "Additional args to create the builder with.",
+	"BuildKitInClusterConfig.Image":              "The docker image to use for the BuildKit deployment",
+	"BuildKitInClusterConfig.Name":               "Name is the name of the builder to use. If omitted, DevSpace will try to create\nor reuse a builder in the form devspace-$NAMESPACE",
+	"BuildKitInClusterConfig.Namespace":          "Namespace where to create the builder deployment in. Defaults to the current\nactive namespace.",
+	"BuildKitInClusterConfig.NoCreate":           "By default, DevSpace will try to create a new builder if it cannot be found.\nIf this is true, DevSpace will fail if the specified builder cannot be found.",
+	"BuildKitInClusterConfig.NoLoad":             "If enabled, DevSpace will not try to load the built image into the local docker\ndaemon if skip push is defined",
+	"BuildKitInClusterConfig.NoRecreate":         "By default, DevSpace will try to recreate the builder if the builder configuration\nin the devspace.yaml differs from the actual builder configuration. If this is\ntrue, DevSpace will not try to do that.",
+	"BuildKitInClusterConfig.NodeSelector":       "The node selector to use for the BuildKit deployment",
+	"BuildKitInClusterConfig.Rootless":           "If enabled will create a rootless builder deployment.",
+	"CommandArg.Default":                         "Default is the value of the argument if it is not specified",
+	"CommandArg.Description":                     "Description is shown in the help of the command",
+	"CommandArg.Name":                            "Name of the argument",
+	"CommandArg.Required":                        "Required marks the argument as required",
+	"CommandArg.Type":                            "Type of the argument. Defaults to string. Only the last argument can be a list, which\nreceives all remaining arguments.",
+	"CommandConfig.AppendArgs":                   "AppendArgs will append arguments passed to the DevSpace command automatically to\nthe specified command.",
+	"CommandConfig.Args":                         "Args are optional and if defined, command is not executed within a shell\nand rather directly.",
+	"CommandConfig.Command":                      "Command is the command that should be executed. For example: 'echo 123'",
+	"CommandConfig.Commands":                     "Commands are sub-commands of this command, e.g. `devspace run db migrate`. If command is\nomitted the command is only a group for its sub-commands.",
+	"CommandConfig.Container":                    "Container defines the container the command should be executed in instead of locally",
+	"CommandConfig.DependsOn":                    "DependsOn are the names of other commands that are executed before this command.\nDependencies are executed without arguments, so they cannot require flags or arguments.\nCommands that do not depend on each other are executed in parallel, their output is\nprefixed with the command name and they don't receive any input.",
+	"CommandConfig.Description":                  "Description describes what the command is doing and can be seen in `devspace list commands`",
+	"CommandConfig.Flags":                        "Flags are the flags the command accepts, e.g. `devspace run mycommand --replicas 3`. Flag values\nare exposed to the command as DEVSPACE_FLAG_REPLICAS environment variable.",
+	"CommandConfig.Inputs":                       "Inputs are glob patterns of the files the command depends on. If the inputs and outputs\nhave not changed since the last successful execution, the command is skipped",
+	"CommandConfig.Name":                         "Name is the name of a command that is used via `devspace run NAME`",
+	"CommandConfig.Outputs":                      "Outputs are glob patterns of the files the command creates. The command is executed\nagain if one of the outputs was changed or removed",
+	"CommandConfig.PositionalArgs":               "PositionalArgs are the positional arguments the command accepts, e.g. `devspace run mycommand my-arg`.\nArgument values are exposed to the command as DEVSPACE_ARG_NAME environment variable.",
+	"CommandContainer.WorkingDir":                "WorkingDir is the directory within the container the command is executed in",
+	"CommandFlag.Default":                        "Default is the value of the flag if it is not specified",
+	"CommandFlag.Description":                    "Description is shown in the help of the command",
+	"CommandFlag.Name":                           "Name of the flag, e.g. replicas for --replicas",
+	"CommandFlag.Required":                       "Required marks the flag as required",
+	"CommandFlag.Short":                          "Short is the optional one letter shorthand of the flag, e.g. r for -r",
+	"CommandFlag.Type":                           "Type of the flag. Defaults to string",
+	"Config.Commands":                            "Commands are custom commands that can be executed via 'devspace run COMMAND'",
+	"Config.Dependencies":                        "Dependencies are sub devspace projects that lie in a local folder or can be accessed via git",
+	"Config.Deployments":                         "Deployments is an ordered list of deployments to deploy via helm, kustomize or kubectl.",
+	"Config.Dev":                                 "Dev holds development configuration for the 'devspace dev' command.",
+	"Config.Hooks":                               "Hooks are actions that are executed at certain points within the pipeline. Hooks are ordered and are executed\nin the order they are specified.",
+	"Config.Images":                              "Images holds configuration of how devspace should build images",
+	"Config.Imports":                             "Imports are config fragments from local paths or git repositories that are merged into this config\nbefore variables are resolved. Values of this config take precedence over imported ones.",
+	"Config.Outputs":                             "Outputs are values this project exposes to a parent project that uses it as dependency. They are\nresolved after the project was deployed and can be referenced in the parent as ${dep.NAME.outputs.OUTPUT}.\nIf the project is only built or rendered, the outputs are resolved when the parent references them.\nReferencing an output of a skipped dependency is an error.",
+	"Config.Profiles":                            "Profiles can be used to change the current configuration and change the behavior of devspace",
+	"Config.PullSecrets":                         "PullSecrets are image pull secrets that will be created by devspace in the target namespace\nduring devspace dev or devspace deploy",
+	"Config.Require":                             "Require defines what DevSpace, plugins and command versions are needed to use this config",
+	"Config.Vars":                                "Vars are config variables that can be used inside other config sections to replace certain values dynamically",
+	"Config.Version":                             "Version holds the config version",
+	"DependencyDev.Ports":                        "If ports is true, DevSpace will forward and reverse forward the\nspecified ports in the dependency's dev.ports config.",
+	"DependencyDev.ReplacePods":                  "If replacePods is true, DevSpace will replace the specified pods\nfrom the dependency's dev.replacePods config",
+	"DependencyDev.Sync":                         "If sync is true, DevSpace will run the specified sync paths\nfrom the dependency's dev.sync config",
+	"DependencyVar.Name":                         "Name is the name of the variable",
+	"DependencyVar.Value":                        "Value is the value to override",
+	"DevConfig.InteractiveEnabled":               "DEPRECATED: Only used for backwards compatibility with older config versions",
+	"DevConfig.InteractiveImages":                "DEPRECATED: Only used for backwards compatibility with older config versions",
+	"DevConfig.ReplacePods":                      "Replace pods will replace the selected target pod/container with a new image and optionally apply\npod patches.",
+	"DevConfig.SSH":                              "SSH starts an ssh server in the selected container and adds a host entry to the\nlocal ~/.ssh/config, so that IDEs can connect directly into the container. The sftp\nsubsystem is served with the sftp-server binary of the container (package openssh-sftp-server\nor openssh-server), sftp and scp are unavailable if the image does not contain it",
+	"HookConfig.Args":                            "Args are additional arguments passed together with the command to execute.",
+	"HookConfig.Background":                      "If true, the hook will be executed in the background.",
+	"HookConfig.Command":                         "Command is the base command that is either executed locally or in a remote container.\nCommand is mutually exclusive with other hook actions. In the case this is defined\ntogether with where.container, DevSpace will until the target container is running and\nonly then execute the command. If the container does not start in time, DevSpace will fail.",
+	"HookConfig.Container":                       "Container specifies where the hook should be run. If this is omitted DevSpace expects a\nlocal command hook.",
+	"HookConfig.Download":                        "Same as Upload, but with this option DevSpace will download files or folders from\na remote container.",
+	"HookConfig.Events":                          "Events are the events when the hook should be executed",
+	"HookConfig.Job":                             "If job is defined, DevSpace will create a kubernetes job from the given pod template, print\nits logs and wait until it has completed. This is useful for tasks like database migrations.",
+	"HookConfig.Logs":                            "If logs is defined will print the logs of the target container. This is useful for containers\nthat should finish like init containers or job pods. Otherwise this hook will never terminate.",
+	"HookConfig.Name":                            "Name is the name of the hook",
+	"HookConfig.OperatingSystem":                 "If an operating system is defined, the hook will only be executed for the given os.\nAll supported golang OS types are supported and multiple can be combined with ','.",
+	"HookConfig.Retry":                           "Retry defines how often the hook is executed again if it fails",
+	"HookConfig.Silent":                          "If true, the hook will not output anything to the standard out of DevSpace except\nfor the case when the hook fails, where DevSpace will show the error including\nthe captured output streams of the hook.",
+	"HookConfig.Timeout":                         "Timeout is the amount of seconds after which a single execution of the hook fails. The hook is\nstopped after the timeout, commands in containers are killed. A retry only starts after the\nprevious execution has stopped. If 0, the hook has no timeout.",
+	"HookConfig.Upload":                          "If Upload is specified, DevSpace will upload certain local files or folders into a\nremote container.",
+	"HookConfig.Wait":                            "If wait is defined the hook will wait until the matched pod or container is running or is terminated\nwith a certain exit code.",
+	"HookConfig.Webhook":                         "If webhook is defined, DevSpace will send a http request with the event and the hook data,\nsuch as the deployment, image or error, to the given url.",
+	"HookConfig.When":                            "When is a shell expression that is evaluated before the hook is executed. The hook is only executed\nif the expression exits with code 0. The expression can use the config variables, the environment\nvariables of the hook such as DEVSPACE_HOOK_EVENT or DEVSPACE_HOOK_DEPLOY_NAME and DEVSPACE_PROFILE,\ne.g. [ \"$DEVSPACE_PROFILE\" = \"production\" ] && [ \"$DEVSPACE_HOOK_DEPLOY_NAME\" = \"backend\" ]",
+	"HookJobConfig.Delete":                       "If true, the job and its pods are deleted after the job has finished",
+	"HookJobConfig.Name":                         "Name is the prefix of the job name, which is completed by a random suffix. Defaults to devspace-hook",
+	"HookJobConfig.Namespace":                    "Namespace is the namespace the job is created in. Defaults to the current namespace",
+	"HookJobConfig.Template":                     "Template is the pod template of the job. Container images that reference an image of the\nimages section, e.g. image(api), are replaced with the built image.",
+	"HookJobConfig.Timeout":                      "The amount of seconds to wait until the job has completed. Defaults to 300 seconds. The job\nis terminated by kubernetes and deleted when the timeout or the timeout of the hook is reached.",
+	"HookLogsConfig.TailLines":                   "If set, the number of lines from the end of the logs to show. If not specified,\nlogs are shown from the creation of the container",
+	"HookRetryConfig.Backoff":                    "Backoff is the amount of seconds to wait before the first retry, which is doubled for every\nfollowing retry. Defaults to 2 seconds.",
+	"HookRetryConfig.Count":                      "Count is the maximum amount of retries after the first execution has failed",
+	"HookWaitConfig.Running":                     "If running is true, will wait until the matched containers are running. Can be used together with terminatedWithCode.",
+	"HookWaitConfig.TerminatedWithCode":          "If terminatedWithCode is not nil, will wait until the matched containers are terminated with the given exit code.\nIf the container has exited with a different exit code, the hook will fail. Can be used together with running.",
+	"HookWaitConfig.Timeout":                     "The amount of seconds to wait until the hook will fail. Defaults to 150 seconds.",
+	"HookWebhookConfig.Body":                     "Body is a go template of the request body. The template can use .Event, .Data and the json\nfunction, which should be used for every value to escape it, e.g.\n{\"text\": {{ json (printf \"deployed %s\" .Data.deploy_name) }}}. Defaults to a json object with the event and the data.",
+	"HookWebhookConfig.Headers":                  "Headers are additional headers of the request, e.g. Authorization: Bearer ${TOKEN}",
+	"HookWebhookConfig.Method":                   "Method is the http method of the request. Defaults to POST",
+	"HookWebhookConfig.URL":                      "URL is the url the request is sent to",
+	"ImageConfig.AppendDockerfileInstructions":   "These instructions will be appended to the Dockerfile that is build at the current build target\nand are appended before the entrypoint and cmd instructions",
+	"ImageConfig.Build":                          "Specific build options how to build the specified image",
+	"ImageConfig.Cmd":                            "Cmd specifies the arguments for the entrypoint that will be appended\nduring build in memory to the dockerfile",
+	"ImageConfig.Context":                        "The context path to build with",
+	"ImageConfig.CreatePullSecret":               "CreatePullSecret specifies if a pull secret should be created for this image in the\ntarget namespace. Defaults to true",
+	"ImageConfig.Dockerfile":                     "Specifies a path (relative or absolute) to the dockerfile",
+	"ImageConfig.Entrypoint":                     "Entrypoint specifies an entrypoint that will be appended to the dockerfile during\nimage build in memory. Example: [\"sleep\", \"99999\"]",
+	"ImageConfig.Image":                          "Image is the complete image name including registry and repository\nfor example myregistry.com/mynamespace/myimage",
+	"ImageConfig.InjectRestartHelper":            "If true injects a small restart script into the container and wraps the entrypoint of that\ncontainer, so that devspace is able to restart the complete container during sync.\nPlease make sure you either have an Entrypoint defined in the devspace config or in the\ndockerfile for this image, otherwise devspace will fail.",
+	"ImageConfig.RebuildStrategy":                "RebuildStrategy is used to determine when DevSpace should rebuild an image. By default, devspace will\nrebuild an image if one of the following conditions is true:\n- The dockerfile has changed\n- The configuration within the devspace.yaml for the image has changed\n- A file within the docker context (excluding .dockerignore rules) has changed\nThis option is ignored for custom builds.",
+	"ImageConfig.RestartHelperPath":              "If specified DevSpace will load the restart helper from this location instead of using the bundled\none within DevSpace. Can be either a local path or an URL where to find the restart helper.",
+	"ImageConfig.Tags":                           "Tags is an array that specifies all tags that should be build during\nthe build process. If this is empty, devspace will generate a random tag",
+	"IngressRuleConfig.TLS":                      "DEPRECATED",
+	"KanikoAdditionalMount.ConfigMap":            "The configMap that should be mounted",
+	"KanikoAdditionalMount.MountPath":            "Path within the container at which the volume should be mounted.  Must\nnot contain ':'.",
+	"KanikoAdditionalMount.ReadOnly":             "Mounted read-only if true, read-write otherwise (false or unspecified).\nDefaults to false.\n+optional",
+	"KanikoAdditionalMount.Secret":               "The secret that should be mounted",
+	"KanikoAdditionalMount.SubPath":              "Path within the volume from which the container's volume should be mounted.\nDefaults to \"\" (volume's root).\n+optional",
+	"KanikoAdditionalMountConfigMap.DefaultMode": "Optional: mode bits to use on created files by default. Must be a\nvalue between 0 and 0777. Defaults to 0644.\nDirectories within the path are not affected by this setting.\nThis might be in conflict with other options that affect the file\nmode, like fsGroup, and the result can be other mode bits set.\n+optional",
+	"KanikoAdditionalMountConfigMap.Items":       "If unspecified, each key-value pair in the Data field of the referenced\nConfigMap will be projected into the volume as a file whose name is the\nkey and content is the value. If specified, the listed keys will be\nprojected into the specified paths, and unlisted keys will not be\npresent. If a key is specified which is not present in the ConfigMap,\nthe volume setup will error unless it is marked optional. Paths must be\nrelative and may not contain the '..' path or start with '..'.\n+optional",
+	"KanikoAdditionalMountConfigMap.Name":        "Name of the configmap\n+optional",
+	"KanikoAdditionalMountKeyToPath.Key":         "The key to project.",
+	"KanikoAdditionalMountKeyToPath.Mode":        "Optional: mode bits to use on this file, must be a value between 0\nand 0777. If not specified, the volume defaultMode will be used.\nThis might be in conflict with other options that affect the file\nmode, like fsGroup, and the result can be other mode bits set.\n+optional",
+	"KanikoAdditionalMountKeyToPath.Path":        "The relative path of the file to map the key to.\nMay not be an absolute path.\nMay not contain the path element '..'.\nMay not start with the string '..'.",
+	"KanikoAdditionalMountSecret.DefaultMode":    "Optional: mode bits to use on created files by default. Must be a\nvalue between 0 and 0777. Defaults to 0644.\nDirectories within the path are not affected by this setting.\nThis might be in conflict with other options that affect the file\nmode, like fsGroup, and the result can be other mode bits set.\n+optional",
+	"KanikoAdditionalMountSecret.Items":          "If unspecified, each key-value pair in the Data field of the referenced\nSecret will be projected into the volume as a file whose name is the\nkey and content is the value. If specified, the listed keys will be\nprojected into the specified paths, and unlisted keys will not be\npresent. If a key is specified which is not present in the Secret,\nthe volume setup will error unless it is marked optional. Paths must be\nrelative and may not contain the '..' path or start with '..'.\n+optional",
+	"KanikoAdditionalMountSecret.Name":           "Name of the secret in the pod's namespace to use.\nMore info: https://kubernetes.io/docs/concepts/storage/volumes#secret\n+optional",
+	"KanikoConfig.AdditionalMounts":              "additional mounts that will be added to the build pod",
+	"KanikoConfig.Annotations":                   "extra annotations that will be added to the build pod",
+	"KanikoConfig.Args":                          "additional arguments that should be passed to kaniko",
+	"KanikoConfig.Cache":                         "if a cache repository should be used. defaults to true",
+	"KanikoConfig.Command":                       "replace the starting command for the kaniko container",
+	"KanikoConfig.Env":                           "extra environment variables that will be added to the build kaniko container\nWill populate the env.value field.",
+	"KanikoConfig.EnvFrom":                       "extra environment variables from configmap or secret that will be added to the build kaniko container\nWill populate the env.valueFrom field.",
+	"KanikoConfig.Image":                         "the image name of the kaniko pod to use",
+	"KanikoConfig.InitEnv":                       "extra environment variables that will be added to the build init container",
+	"KanikoConfig.InitImage":                     "the image to init the kaniko pod",
+	"KanikoConfig.Insecure":                      "if true pushing to insecure registries is allowed",
+	"KanikoConfig.Labels":                        "extra labels that will be added to the build pod",
+	"KanikoConfig.Namespace":                     "the namespace where the kaniko pod should be run",
+	"KanikoConfig.NodeSelector":                  "the node selector to use for the kaniko pod",
+	"KanikoConfig.Options":                       "other build options that will be passed to the kaniko pod",
+	"KanikoConfig.PullSecret":                    "the pull secret to mount by default",
+	"KanikoConfig.Resources":                     "the resources that should be set on the kaniko pod",
+	"KanikoConfig.ServiceAccount":                "the service account to use for the kaniko pod",
+	"KanikoConfig.SkipPullSecretMount":           "If true will skip mounting the pull secret",
+	"KanikoConfig.SnapshotMode":                  "the snapshot mode kaniko should use. defaults to time",
+	"KanikoConfig.Tolerations":                   "tolerations list to use for the kaniko pod",
+	"KanikoPodResources.Limits":                  "The limits part of the resources",
+	"KanikoPodResources.Requests":                "The requests part of the resources",
+	"LogsConfig.Exclude":                         "Exclude are regular expressions that drop a log line if any of them match",
+	"LogsConfig.Fields":                          "Fields are the json fields that should be printed, e.g. msg and trace_id. Implies json",
+	"LogsConfig.Include":                         "Include are regular expressions of which at least one has to match for a log line to be printed",
+	"LogsConfig.JSON":                            "JSON parses json log lines and colors them based on their log level",
+	"LogsConfig.Persist":                         "Persist writes the streamed logs of every container to .devspace/logs, so that they\ncan be searched with 'devspace logs --since / --grep' even after the pod is gone",
+	"LogsPersistConfig.MaxFiles":                 "MaxFiles is the amount of rotated log files kept per container. Defaults to 5",
+	"LogsPersistConfig.MaxSize":                  "MaxSize is the size in megabytes after which a log file is rotated. Defaults to 10",
+	"OutputConfig.Args":                          "Args are optional arguments for the command. If they are omitted the command is executed\nwithin a shell",
+	"OutputConfig.Command":                       "Command is executed after the deployment and its trimmed stdout is used as value",
+	"OutputConfig.Name":                          "Name is the name of the output",
+	"OutputConfig.Value":                         "Value is a static value that can reference variables, e.g. postgres.${DEVSPACE_NAMESPACE}",
+	"PluginBuildConfig.Name":                     "Name is the name of the builder the plugin provides",
+	"PluginBuildConfig.Options":                  "Options are passed to the builder as part of the image config",
+	"PluginDeploymentConfig.Name":                "Name is the name of the deployer the plugin provides",
+	"PluginDeploymentConfig.Options":             "Options are passed to the deployer as part of the deployment config",
+	"PodPatch.Image":                             "If image is specified, DevSpace will replace the target image",
+	"PodPatch.Patches":                           "Regular JSON patches that will be applied to the target Deployment, StatefulSet or ReplicaSet",
+	"PortForwardingConfig.Arch":                  "Target Container architecture to use for the devspacehelper (currently amd64 or arm64). Defaults to amd64",
+	"ProfileActivation.Environment":              "Environment defines key/value pairs where the key is the name of the environment variable and the value is a regular expression used to match the variable's value.\nWhen multiple keys are specified, they must all evaluate to true to activate the profile.",
+	"PullSecretConfig.Email":                     "The optional email to use",
+	"PullSecretConfig.Password":                  "The password to use for the registry. If this is empty, devspace will\ntry to receive the auth data from the local docker",
+	"PullSecretConfig.Registry":                  "The registry to create the image pull secret for.\ne.g. gcr.io",
+	"PullSecretConfig.Secret":                    "The secret to create",
+	"PullSecretConfig.ServiceAccounts":           "The service account to add the secret to",
+	"PullSecretConfig.Username":                  "The username of the registry. If this is empty, devspace will try\nto receive the auth data from the local docker",
+	"RequireCommand.Name":                        "Name is the name of the command that should be installed",
+	"RequireCommand.Version":                     "Version constraint of the command that should be installed",
+	"RequireCommand.VersionArgs":                 "VersionArgs are the arguments to retrieve the version of the command",
+	"RequireCommand.VersionRegEx":                "VersionRegEx is the regex that is used to parse the version",
+	"RequireConfig.Commands":                     "Commands specifies an array of commands that need to be installed locally to use this config",
+	"RequireConfig.DevSpace":                     "DevSpace specifies the DevSpace version constraint that is needed to use this config",
+	"RequireConfig.Plugins":                      "Plugins specifies an array of plugins that need to be installed locally",
+	"RequirePlugin.Name":                         "Name of the plugin that should be installed",
+	"RequirePlugin.Version":                      "Version constraint of the plugin that should be installed",
+	"SSH.Disabled":                               "If disabled is true, DevSpace will not start the ssh server",
+	"SSH.LocalHostname":                          "LocalHostname is the host name of the entry in ~/.ssh/config. Defaults to PROJECT_DIR.devspace",
+	"SSH.LocalPort":                              "LocalPort is the local port the ssh connections are accepted on. Defaults to a random free port",
+	"SourceConfig.Checksum":                      "Checksum is the expected sha256 checksum (e.g. sha256:abc...) of the tarball or\nthe oci artifact manifest",
+	"SourceConfig.OCI":                           "OCI is an oci artifact reference (e.g. ghcr.io/org/environment:1.0.0) that\ncontains the dependency files",
+	"SourceConfig.Tarball":                       "Tarball is a https url to a .tar.gz or .tar archive that contains the dependency files",
+	"SyncConfig.Arch":                            "Target Container architecture to use for the devspacehelper (currently amd64 or arm64). Defaults to amd64",
+	"SyncConfig.ThrottleChangeDetection":         "If greater zero, describes the amount of milliseconds to wait after each checked 100 files",
+	"SyncExecCommand.OnBatch":                    "OnBatch executes the given command after a batch of changes has been processed. DevSpace will wait for the command to finish\nand then will continue execution. This is useful for commands\nthat shouldn't be executed after every single change that may take a little bit longer like recompiling etc.",
+	"SyncExecCommand.OnDirCreate":                "OnDirCreate is invoked after every directory that is created. DevSpace will wait for the command to successfully finish\nand then will continue to upload files & create folders",
+	"SyncExecCommand.OnFileChange":               "OnFileChange is invoked after every file change. DevSpace will wait for the command to successfully finish\nand then will continue to upload files & create folders",
+	"SyncOnUpload.Exec":                          "Exec will execute the given commands in order after a sync operation",
+	"SyncOnUpload.ExecRemote":                    "Defines what commands should be executed on the container side if a change is uploaded and applied in the target\ncontainer",
+	"SyncOnUpload.RestartContainer":              "If true restart container will try to restart the container after a change has been made. Make sure that\nimages.*.injectRestartHelper is enabled for the container that should be restarted or the devspace-restart-helper\nscript is present in the container root folder.",
+	"Terminal.Debug":                             "Debug opens the terminal in an ephemeral debug container that shares the process\nnamespace of the selected container, e.g. for distroless images without a shell",
+	"Terminal.Disabled":                          "If disabled is true, DevSpace will not use the terminal",
+	"Terminal.Session":                           "Session is the name of a persistent terminal session within the container. If set,\nDevSpace will reattach to the same shell after reconnects instead of starting a new one",
+	"TerminalDebug.Image":                        "Image is the image of the debug container. Defaults to busybox:latest",
+	"Variable.Args":                              "Args are optional args that will be used for the command",
+	"Variable.Command":                           "Command is the command how to retrieve the variable. If args is omitted, command is parsed as a shell\ncommand.",
+	"Variable.Commands":                          "Commands are additional commands that can be used to run a different command on a different operating\nsystem.",
+	"Variable.Default":                           "Default is the default value the variable should have if not set by the user",
+	"Variable.SOPS":                              "SOPS is the SOPS encrypted file the variable is read from if source is sops",
+	"Variable.Secret":                            "Secret is the Kubernetes secret the variable is read from if source is secret",
+	"Variable.Source":                            "Source defines where the variable should be taken from",
+	"Variable.Type":                              "Type is the type of the variable. If omitted, the type is guessed from the value",
+	"Variable.Value":                             "Value is a shortcut for using source: none and default: my-value",
+	"Variable.Vault":                             "Vault is the Vault secret the variable is read from if source is vault",
+	"VariableSOPSSource.File":                    "File is the path to the encrypted file",
+	"VariableSOPSSource.Key":                     "Key is the path of the value within the decrypted file, e.g. database.password. If omitted,\nthe whole file is returned",
+	"VariableSecretSource.Key":                   "Key within the secret data. If omitted, all keys are returned as map",
+	"VariableSecretSource.Name":                  "Name of the secret",
+	"VariableSecretSource.Namespace":             "Namespace of the secret. If omitted, the current namespace is used",
+	"VariableVaultSource.Address":                "Address of the Vault server. Defaults to the VAULT_ADDR environment variable",
+	"VariableVaultSource.Key":                    "Key within the secret data. If omitted, all keys are returned as map",
+	"VariableVaultSource.Path":                   "Path of the secret, e.g. secret/data/my-app for the kv version 2 engine",
+}
+
+var enumValues = map[string][]string{
+	"CommandArgType":        {"", "string", "int", "bool", "list"},
+	"ContainerArchitecture": {"amd64", "arm64"},
+	"InitialSyncCompareBy":  {"mtime", "size"},
+	"InitialSyncStrategy":   {"mirrorLocal", "mirrorRemote", "preferLocal", "preferRemote", "preferNewest", "keepAll"},
+	"RebuildStrategy":       {"", "always", "ignoreContextChanges"},
+	"VariableSource":        {"", "all", "env", "input", "command", "none", "secret", "sops", "vault"},
+	"VariableType":          {"", "string", "int", "bool", "list", "map"},
+}
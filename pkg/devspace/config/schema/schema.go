@@ -0,0 +1,138 @@
+package schema
+
+//go:generate go run ../../../../hack/genschema ../versions/latest/schema.go docs_generated.go
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+)
+
+// Draft is the json schema draft the generated schema uses
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a json schema. Only the subset that is needed to describe the devspace.yaml is supported
+type Schema struct {
+	SchemaURI   string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+var (
+	latestOnce   sync.Once
+	latestSchema *Schema
+)
+
+// Latest returns the json schema of the latest config version
+func Latest() *Schema {
+	latestOnce.Do(func() {
+		latestSchema = Generate(reflect.TypeOf(latest.Config{}))
+		latestSchema.Title = "DevSpace config " + latest.Version
+	})
+
+	return latestSchema
+}
+
+// Generate generates a json schema from the given go struct type. Field names are taken from
+// the yaml tags and descriptions from the doc comments of the latest config
+func Generate(t reflect.Type) *Schema {
+	g := &generator{definitions: map[string]*Schema{}}
+	root := g.definition(t)
+	root.SchemaURI = Draft
+	root.Definitions = g.definitions
+	return root
+}
+
+type generator struct {
+	definitions map[string]*Schema
+}
+
+func (g *generator) definition(t reflect.Type) *Schema {
+	ret := &Schema{
+		Type:                 "object",
+		Description:          typeDescriptions[t.Name()],
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		} else if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		property := g.schema(field.Type)
+		if description := fieldDescriptions[t.Name()+"."+field.Name]; description != "" {
+			property.Description = description
+			property.Deprecated = isDeprecated(description)
+		}
+
+		ret.Properties[name] = property
+	}
+
+	return ret
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			// reserve the name first in case the type references itself
+			g.definitions[t.Name()] = nil
+			g.definitions[t.Name()] = g.definition(t)
+		}
+
+		return &Schema{Ref: "#/definitions/" + t.Name()}
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &Schema{Type: "object"}
+		}
+
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.String:
+		ret := &Schema{Type: "string"}
+		for _, value := range enumValues[t.Name()] {
+			ret.Enum = append(ret.Enum, value)
+		}
+
+		return ret
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+
+	return &Schema{}
+}
+
+func isDeprecated(description string) bool {
+	return strings.HasPrefix(description, "DEPRECATED") || strings.HasPrefix(description, "Deprecated:")
+}
+
+// JSON returns the indented json representation of the schema
+func (s *Schema) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestLatest(t *testing.T) {
+	latest := Latest()
+	assert.Equal(t, latest.Properties["images"].AdditionalProperties.(*Schema).Ref, "#/definitions/ImageConfig")
+	assert.Equal(t, latest.Properties["dev"].Description, "Dev holds development configuration for the 'devspace dev' command.")
+
+	syncConfig := latest.Definitions["SyncConfig"]
+	assert.Assert(t, syncConfig != nil)
+	assert.DeepEqual(t, syncConfig.Properties["initialSync"].Enum, []interface{}{"mirrorLocal", "mirrorRemote", "preferLocal", "preferRemote", "preferNewest", "keepAll"})
+
+	devConfig := latest.Definitions["DevConfig"]
+	assert.Equal(t, devConfig.Properties["deprecatedInteractiveEnabled"].Deprecated, true)
+	assert.Equal(t, devConfig.Properties["ports"].Deprecated, false)
+}
+
+func TestValidate(t *testing.T) {
+	source := `version: v1beta11
+images:
+  default:
+    image: nginx
+    tags: latest
+deployments:
+- name: test
+  helm:
+    valuez:
+      test: test
+dev:
+  sync:
+  - imageSelector: nginx
+    initialSync: mirror
+    waitInitialSync: "yes"
+  ports:
+  - imageSelector: nginx
+    forward:
+    - port: 8080
+      remotePort: 80
+`
+	data := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(source), &data)
+	assert.NilError(t, err)
+
+	validationErrors := ValidationErrors(Latest().Validate(data))
+	messages := []string{}
+	for _, validationError := range validationErrors {
+		messages = append(messages, validationError.Error())
+	}
+	assert.DeepEqual(t, messages, []string{
+		"deployments[0].helm.valuez: unknown field valuez, did you mean values?",
+		"dev.sync[0].initialSync: unsupported value mirror, please use one of: mirrorLocal, mirrorRemote, preferLocal, preferRemote, preferNewest, keepAll",
+		"dev.sync[0].waitInitialSync: expected a boolean, but got the string \"yes\"",
+		"images.default.tags: expected an array, but got the string \"latest\"",
+	})
+
+	validationErrors.Locate(sourcemap.Parse("devspace.yaml", []byte(source)))
+	assert.Error(t, validationErrors, `invalid config:
+devspace.yaml:9:5: deployments[0].helm.valuez: unknown field valuez, did you mean values?
+devspace.yaml:14:5: dev.sync[0].initialSync: unsupported value mirror, please use one of: mirrorLocal, mirrorRemote, preferLocal, preferRemote, preferNewest, keepAll
+devspace.yaml:15:5: dev.sync[0].waitInitialSync: expected a boolean, but got the string "yes"
+devspace.yaml:5:5: images.default.tags: expected an array, but got the string "latest"`)
+}
@@ -0,0 +1,256 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/sourcemap"
+)
+
+// ValidationError is a single violation of the schema
+type ValidationError struct {
+	// Path is the path of the invalid value, e.g. dev.sync[2].containerPath
+	Path []interface{}
+
+	// Message describes the violation
+	Message string
+
+	// Position of the invalid value in the config, if known
+	Position *sourcemap.Position
+}
+
+// Field returns the path of the invalid value as string
+func (e *ValidationError) Field() string {
+	out := ""
+	for _, segment := range e.Path {
+		switch segment := segment.(type) {
+		case int:
+			out += "[" + strconv.Itoa(segment) + "]"
+		default:
+			if out != "" {
+				out += "."
+			}
+			out += fmt.Sprintf("%v", segment)
+		}
+	}
+
+	return out
+}
+
+func (e *ValidationError) Error() string {
+	message := e.Message
+	if len(e.Path) > 0 {
+		message = e.Field() + ": " + e.Message
+	}
+	if e.Position != nil && e.Position.String() != "" {
+		message = e.Position.String() + ": " + message
+	}
+
+	return message
+}
+
+// ValidationErrors are all violations of the schema found in a config
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := []string{}
+	for _, validationError := range e {
+		messages = append(messages, validationError.Error())
+	}
+
+	return "invalid config:\n" + strings.Join(messages, "\n")
+}
+
+// Locate sets the positions of the invalid values by looking up their paths in the source map
+func (e ValidationErrors) Locate(sourceMap sourcemap.SourceMap) {
+	for _, validationError := range e {
+		validationError.Position = sourceMap.LookupPath(validationError.Path)
+	}
+}
+
+// Validate validates the given yaml data against the schema and returns all violations
+func (s *Schema) Validate(data interface{}) []*ValidationError {
+	errs := []*ValidationError{}
+	s.validate(s, data, []interface{}{}, &errs)
+	return errs
+}
+
+func (s *Schema) validate(root *Schema, data interface{}, path []interface{}, errs *[]*ValidationError) {
+	if s == nil || data == nil {
+		return
+	}
+	if s.Ref != "" {
+		root.definition(s.Ref).validate(root, data, path, errs)
+		return
+	}
+
+	addError := func(path []interface{}, format string, args ...interface{}) {
+		*errs = append(*errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	value := reflect.ValueOf(data)
+	switch s.Type {
+	case "object":
+		if value.Kind() != reflect.Map {
+			addError(path, "expected an object, but got %s", typeName(data))
+			return
+		}
+
+		keys := []string{}
+		values := map[string]interface{}{}
+		for _, key := range value.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			keys = append(keys, name)
+			values[name] = value.MapIndex(key).Interface()
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := appendPath(path, key)
+			if property, ok := s.Properties[key]; ok {
+				property.validate(root, values[key], childPath, errs)
+			} else if additional, ok := s.AdditionalProperties.(*Schema); ok {
+				additional.validate(root, values[key], childPath, errs)
+			} else if s.AdditionalProperties == false {
+				addError(childPath, "unknown field %s%s", key, suggestion(key, s.Properties))
+			}
+		}
+	case "array":
+		if value.Kind() != reflect.Slice {
+			addError(path, "expected an array, but got %s", typeName(data))
+			return
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			s.Items.validate(root, value.Index(i).Interface(), appendPath(path, i), errs)
+		}
+	case "string":
+		// like the yaml parser we accept every scalar as string
+		if value.Kind() == reflect.Map || value.Kind() == reflect.Slice {
+			addError(path, "expected a string, but got %s", typeName(data))
+			return
+		}
+		if len(s.Enum) > 0 {
+			str := fmt.Sprintf("%v", data)
+			if str == "" {
+				return
+			}
+			for _, allowed := range s.Enum {
+				if allowed == str {
+					return
+				}
+			}
+
+			allowed := []string{}
+			for _, value := range s.Enum {
+				if value != "" {
+					allowed = append(allowed, fmt.Sprintf("%v", value))
+				}
+			}
+			addError(path, "unsupported value %s, please use one of: %s", str, strings.Join(allowed, ", "))
+		}
+	case "boolean":
+		if value.Kind() != reflect.Bool {
+			addError(path, "expected a boolean, but got %s", typeName(data))
+		}
+	case "integer":
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Float32, reflect.Float64:
+			if value.Float() != float64(int64(value.Float())) {
+				addError(path, "expected an integer, but got %v", data)
+			}
+		default:
+			addError(path, "expected an integer, but got %s", typeName(data))
+		}
+	case "number":
+		switch value.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		default:
+			addError(path, "expected a number, but got %s", typeName(data))
+		}
+	}
+}
+
+func (s *Schema) definition(ref string) *Schema {
+	return s.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+}
+
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	newPath := make([]interface{}, 0, len(path)+1)
+	newPath = append(newPath, path...)
+	return append(newPath, segment)
+}
+
+func typeName(data interface{}) string {
+	switch reflect.ValueOf(data).Kind() {
+	case reflect.Map:
+		return "an object"
+	case reflect.Slice:
+		return "an array"
+	case reflect.String:
+		return "the string " + strconv.Quote(data.(string))
+	case reflect.Bool:
+		return "a boolean"
+	}
+
+	return fmt.Sprintf("%v", data)
+}
+
+// suggestion returns a hint for a mistyped field, e.g. valuez -> values
+func suggestion(key string, properties map[string]*Schema) string {
+	lowerKey := strings.ToLower(key)
+	for name := range properties {
+		if strings.ToLower(name) == lowerKey {
+			return fmt.Sprintf(", did you mean %s?", name)
+		}
+	}
+
+	best, bestDistance := "", 3
+	for name := range properties {
+		distance := levenshtein(lowerKey, strings.ToLower(name))
+		if distance < bestDistance || (distance == bestDistance && best != "" && name < best) {
+			best, bestDistance = name, distance
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf(", did you mean %s?", best)
+	}
+
+	return ""
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minimum(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minimum(values ...int) int {
+	ret := values[0]
+	for _, value := range values[1:] {
+		if value < ret {
+			ret = value
+		}
+	}
+
+	return ret
+}
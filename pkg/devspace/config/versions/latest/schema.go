@@ -34,6 +34,10 @@ type Config struct {
 	// Require defines what DevSpace, plugins and command versions are needed to use this config
 	Require RequireConfig `yaml:"require,omitempty" json:"require,omitempty"`
 
+	// Imports are config fragments from local paths or git repositories that are merged into this config
+	// before variables are resolved. Values of this config take precedence over imported ones.
+	Imports []*SourceConfig `yaml:"imports,omitempty" json:"imports,omitempty"`
+
 	// Vars are config variables that can be used inside other config sections to replace certain values dynamically
 	Vars []*Variable `yaml:"vars,omitempty" json:"vars,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
@@ -58,10 +62,32 @@ type Config struct {
 	Commands []*CommandConfig `yaml:"commands,omitempty" json:"commands,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// Profiles can be used to change the current configuration and change the behavior of devspace
-	Profiles []*ProfileConfig `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+	Profiles []*ProfileConfig `yaml:"profiles,omitempty" json:"profiles,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
 	// Dependencies are sub devspace projects that lie in a local folder or can be accessed via git
 	Dependencies []*DependencyConfig `yaml:"dependencies,omitempty" json:"dependencies,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Outputs are values this project exposes to a parent project that uses it as dependency. They are
+	// resolved after the project was deployed and can be referenced in the parent as ${dep.NAME.outputs.OUTPUT}.
+	// If the project is only built or rendered, the outputs are resolved when the parent references them.
+	// Referencing an output of a skipped dependency is an error.
+	Outputs []*OutputConfig `yaml:"outputs,omitempty" json:"outputs,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+// OutputConfig defines a single dependency output
+type OutputConfig struct {
+	// Name is the name of the output
+	Name string `yaml:"name" json:"name"`
+
+	// Value is a static value that can reference variables, e.g. postgres.${DEVSPACE_NAMESPACE}
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
+
+	// Command is executed after the deployment and its trimmed stdout is used as value
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are optional arguments for the command. If they are omitted the command is executed
+	// within a shell
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
 }
 
 type RequireConfig struct {
@@ -177,11 +203,24 @@ type BuildConfig struct {
 	// a custom script.
 	Custom *CustomConfig `yaml:"custom,omitempty" json:"custom,omitempty"`
 
+	// If plugin is specified, DevSpace will build the image with a builder that is
+	// provided by an installed grpc plugin
+	Plugin *PluginBuildConfig `yaml:"plugin,omitempty" json:"plugin,omitempty"`
+
 	// This overrides other options and is able to disable the build for this image.
 	// Useful if you just want to select the image in a sync path or via devspace enter --image
 	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
 }
 
+// PluginBuildConfig tells the DevSpace CLI to build with a builder of a plugin
+type PluginBuildConfig struct {
+	// Name is the name of the builder the plugin provides
+	Name string `yaml:"name" json:"name"`
+
+	// Options are passed to the builder as part of the image config
+	Options map[interface{}]interface{} `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
 // DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
 type DockerConfig struct {
 	PreferMinikube  *bool         `yaml:"preferMinikube,omitempty" json:"preferMinikube,omitempty"`
@@ -446,10 +485,20 @@ type BuildOptions struct {
 
 // DeploymentConfig defines the configuration how the devspace should be deployed
 type DeploymentConfig struct {
-	Name      string         `yaml:"name" json:"name"`
-	Namespace string         `yaml:"namespace,omitempty" json:"namespace,omitempty"`
-	Helm      *HelmConfig    `yaml:"helm,omitempty" json:"helm,omitempty"`
-	Kubectl   *KubectlConfig `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+	Name      string                  `yaml:"name" json:"name"`
+	Namespace string                  `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Helm      *HelmConfig             `yaml:"helm,omitempty" json:"helm,omitempty"`
+	Kubectl   *KubectlConfig          `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+	Plugin    *PluginDeploymentConfig `yaml:"plugin,omitempty" json:"plugin,omitempty"`
+}
+
+// PluginDeploymentConfig tells the DevSpace CLI to deploy with a deployer of a plugin
+type PluginDeploymentConfig struct {
+	// Name is the name of the deployer the plugin provides
+	Name string `yaml:"name" json:"name"`
+
+	// Options are passed to the deployer as part of the deployment config
+	Options map[interface{}]interface{} `yaml:"options,omitempty" json:"options,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -684,6 +733,12 @@ type DevConfig struct {
 	AutoReload *AutoReloadConfig       `yaml:"autoReload,omitempty" json:"autoReload,omitempty"`
 	Terminal   *Terminal               `yaml:"terminal,omitempty" json:"terminal,omitempty"`
 
+	// SSH starts an ssh server in the selected container and adds a host entry to the
+	// local ~/.ssh/config, so that IDEs can connect directly into the container. The sftp
+	// subsystem is served with the sftp-server binary of the container (package openssh-sftp-server
+	// or openssh-server), sftp and scp are unavailable if the image does not contain it
+	SSH *SSH `yaml:"ssh,omitempty" json:"ssh,omitempty"`
+
 	// Replace pods will replace the selected target pod/container with a new image and optionally apply
 	// pod patches.
 	ReplacePods []*ReplacePod `yaml:"replacePods,omitempty" json:"replacePods,omitempty"`
@@ -883,6 +938,29 @@ type LogsConfig struct {
 	ShowLast  *int           `yaml:"showLast,omitempty" json:"showLast,omitempty"`
 	Sync      *bool          `yaml:"sync,omitempty" json:"sync,omitempty"`
 	Selectors []LogsSelector `yaml:"selectors,omitempty" json:"selectors,omitempty"`
+
+	// Include are regular expressions of which at least one has to match for a log line to be printed
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+	// Exclude are regular expressions that drop a log line if any of them match
+	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	// JSON parses json log lines and colors them based on their log level
+	JSON bool `yaml:"json,omitempty" json:"json,omitempty"`
+	// Fields are the json fields that should be printed, e.g. msg and trace_id. Implies json
+	Fields []string `yaml:"fields,omitempty" json:"fields,omitempty"`
+
+	// Persist writes the streamed logs of every container to .devspace/logs, so that they
+	// can be searched with 'devspace logs --since / --grep' even after the pod is gone
+	Persist *LogsPersistConfig `yaml:"persist,omitempty" json:"persist,omitempty"`
+}
+
+// LogsPersistConfig defines how streamed logs are stored on disk
+type LogsPersistConfig struct {
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// MaxSize is the size in megabytes after which a log file is rotated. Defaults to 10
+	MaxSize int `yaml:"maxSize,omitempty" json:"maxSize,omitempty"`
+	// MaxFiles is the amount of rotated log files kept per container. Defaults to 5
+	MaxFiles int `yaml:"maxFiles,omitempty" json:"maxFiles,omitempty"`
 }
 
 // LogsSelector holds configuration how to select a log target
@@ -916,10 +994,44 @@ type Terminal struct {
 	Command       []string          `yaml:"command,omitempty" json:"command,omitempty"`
 	WorkDir       string            `yaml:"workDir,omitempty" json:"workDir,omitempty"`
 
+	// Session is the name of a persistent terminal session within the container. If set,
+	// DevSpace will reattach to the same shell after reconnects instead of starting a new one
+	Session string `yaml:"session,omitempty" json:"session,omitempty"`
+
+	// Debug opens the terminal in an ephemeral debug container that shares the process
+	// namespace of the selected container, e.g. for distroless images without a shell
+	Debug *TerminalDebug `yaml:"debug,omitempty" json:"debug,omitempty"`
+
 	// If disabled is true, DevSpace will not use the terminal
 	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
 }
 
+// TerminalDebug describes the ephemeral debug container options
+type TerminalDebug struct {
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Image is the image of the debug container. Defaults to busybox:latest
+	Image string `yaml:"image,omitempty" json:"image,omitempty"`
+}
+
+// SSH describes the ssh server options
+type SSH struct {
+	ImageSelector string                `yaml:"imageSelector,omitempty" json:"imageSelector,omitempty"`
+	LabelSelector map[string]string     `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+	ContainerName string                `yaml:"containerName,omitempty" json:"containerName,omitempty"`
+	Namespace     string                `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Arch          ContainerArchitecture `yaml:"arch,omitempty" json:"arch,omitempty"`
+
+	// LocalHostname is the host name of the entry in ~/.ssh/config. Defaults to PROJECT_DIR.devspace
+	LocalHostname string `yaml:"localHostname,omitempty" json:"localHostname,omitempty"`
+
+	// LocalPort is the local port the ssh connections are accepted on. Defaults to a random free port
+	LocalPort int `yaml:"localPort,omitempty" json:"localPort,omitempty"`
+
+	// If disabled is true, DevSpace will not start the ssh server
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
 // PodPatch will patch a pod's owning ReplicaSet, Deployment or StatefulSet with the givens patches or image
 type PodPatch struct {
 	ImageSelector string            `yaml:"imageSelector,omitempty" json:"imageSelector,omitempty"`
@@ -987,6 +1099,17 @@ type SourceConfig struct {
 	ConfigName     string   `yaml:"configName,omitempty" json:"configName,omitempty"`
 
 	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// OCI is an oci artifact reference (e.g. ghcr.io/org/environment:1.0.0) that
+	// contains the dependency files
+	OCI string `yaml:"oci,omitempty" json:"oci,omitempty"`
+
+	// Tarball is a https url to a .tar.gz or .tar archive that contains the dependency files
+	Tarball string `yaml:"tarball,omitempty" json:"tarball,omitempty"`
+
+	// Checksum is the expected sha256 checksum (e.g. sha256:abc...) of the tarball or
+	// the oci artifact manifest
+	Checksum string `yaml:"checksum,omitempty" json:"checksum,omitempty"`
 }
 
 // HookConfig defines a hook
@@ -1021,6 +1144,12 @@ type HookConfig struct {
 	// If wait is defined the hook will wait until the matched pod or container is running or is terminated
 	// with a certain exit code.
 	Wait *HookWaitConfig `yaml:"wait,omitempty" json:"wait,omitempty"`
+	// If job is defined, DevSpace will create a kubernetes job from the given pod template, print
+	// its logs and wait until it has completed. This is useful for tasks like database migrations.
+	Job *HookJobConfig `yaml:"job,omitempty" json:"job,omitempty"`
+	// If webhook is defined, DevSpace will send a http request with the event and the hook data,
+	// such as the deployment, image or error, to the given url.
+	Webhook *HookWebhookConfig `yaml:"webhook,omitempty" json:"webhook,omitempty"`
 
 	// If true, the hook will be executed in the background.
 	Background bool `yaml:"background,omitempty" json:"background,omitempty"`
@@ -1029,11 +1158,32 @@ type HookConfig struct {
 	// the captured output streams of the hook.
 	Silent bool `yaml:"silent,omitempty" json:"silent,omitempty"`
 
+	// Timeout is the amount of seconds after which a single execution of the hook fails. The hook is
+	// stopped after the timeout, commands in containers are killed. A retry only starts after the
+	// previous execution has stopped. If 0, the hook has no timeout.
+	Timeout int64 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// Retry defines how often the hook is executed again if it fails
+	Retry *HookRetryConfig `yaml:"retry,omitempty" json:"retry,omitempty"`
+	// When is a shell expression that is evaluated before the hook is executed. The hook is only executed
+	// if the expression exits with code 0. The expression can use the config variables, the environment
+	// variables of the hook such as DEVSPACE_HOOK_EVENT or DEVSPACE_HOOK_DEPLOY_NAME and DEVSPACE_PROFILE,
+	// e.g. [ "$DEVSPACE_PROFILE" = "production" ] && [ "$DEVSPACE_HOOK_DEPLOY_NAME" = "backend" ]
+	When string `yaml:"when,omitempty" json:"when,omitempty"`
+
 	// Container specifies where the hook should be run. If this is omitted DevSpace expects a
 	// local command hook.
 	Container *HookContainer `yaml:"container,omitempty" json:"container,omitempty"`
 }
 
+// HookRetryConfig defines how often a failed hook is retried
+type HookRetryConfig struct {
+	// Count is the maximum amount of retries after the first execution has failed
+	Count int `yaml:"count,omitempty" json:"count,omitempty"`
+	// Backoff is the amount of seconds to wait before the first retry, which is doubled for every
+	// following retry. Defaults to 2 seconds.
+	Backoff int64 `yaml:"backoff,omitempty" json:"backoff,omitempty"`
+}
+
 // HookWaitConfig defines a hook wait config
 type HookWaitConfig struct {
 	// If running is true, will wait until the matched containers are running. Can be used together with terminatedWithCode.
@@ -1054,6 +1204,36 @@ type HookLogsConfig struct {
 	TailLines *int64 `yaml:"tailLines,omitempty" json:"tailLines,omitempty"`
 }
 
+// HookJobConfig defines a job that is created by a hook
+type HookJobConfig struct {
+	// Name is the prefix of the job name, which is completed by a random suffix. Defaults to devspace-hook
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Namespace is the namespace the job is created in. Defaults to the current namespace
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	// Template is the pod template of the job. Container images that reference an image of the
+	// images section, e.g. image(api), are replaced with the built image.
+	Template map[interface{}]interface{} `yaml:"template" json:"template"`
+	// The amount of seconds to wait until the job has completed. Defaults to 300 seconds. The job
+	// is terminated by kubernetes and deleted when the timeout or the timeout of the hook is reached.
+	Timeout int64 `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// If true, the job and its pods are deleted after the job has finished
+	Delete bool `yaml:"delete,omitempty" json:"delete,omitempty"`
+}
+
+// HookWebhookConfig defines a http request that is sent by a hook
+type HookWebhookConfig struct {
+	// URL is the url the request is sent to
+	URL string `yaml:"url" json:"url"`
+	// Method is the http method of the request. Defaults to POST
+	Method string `yaml:"method,omitempty" json:"method,omitempty"`
+	// Headers are additional headers of the request, e.g. Authorization: Bearer ${TOKEN}
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Body is a go template of the request body. The template can use .Event, .Data and the json
+	// function, which should be used for every value to escape it, e.g.
+	// {"text": {{ json (printf "deployed %s" .Data.deploy_name) }}}. Defaults to a json object with the event and the data.
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+}
+
 // HookSyncConfig defines a hook upload config
 type HookSyncConfig struct {
 	LocalPath     string `yaml:"localPath,omitempty" json:"localPath,omitempty"`
@@ -1091,11 +1271,108 @@ type CommandConfig struct {
 
 	// Description describes what the command is doing and can be seen in `devspace list commands`
 	Description string `yaml:"description" json:"description"`
+
+	// Flags are the flags the command accepts, e.g. `devspace run mycommand --replicas 3`. Flag values
+	// are exposed to the command as DEVSPACE_FLAG_REPLICAS environment variable.
+	Flags []*CommandFlag `yaml:"flags,omitempty" json:"flags,omitempty"`
+
+	// PositionalArgs are the positional arguments the command accepts, e.g. `devspace run mycommand my-arg`.
+	// Argument values are exposed to the command as DEVSPACE_ARG_NAME environment variable.
+	PositionalArgs []*CommandArg `yaml:"positionalArgs,omitempty" json:"positionalArgs,omitempty"`
+
+	// Commands are sub-commands of this command, e.g. `devspace run db migrate`. If command is
+	// omitted the command is only a group for its sub-commands.
+	Commands []*CommandConfig `yaml:"commands,omitempty" json:"commands,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Container defines the container the command should be executed in instead of locally
+	Container *CommandContainer `yaml:"container,omitempty" json:"container,omitempty"`
+
+	// DependsOn are the names of other commands that are executed before this command.
+	// Dependencies are executed without arguments, so they cannot require flags or arguments.
+	// Commands that do not depend on each other are executed in parallel, their output is
+	// prefixed with the command name and they don't receive any input.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
+	// Inputs are glob patterns of the files the command depends on. If the inputs and outputs
+	// have not changed since the last successful execution, the command is skipped
+	Inputs []string `yaml:"inputs,omitempty" json:"inputs,omitempty"`
+
+	// Outputs are glob patterns of the files the command creates. The command is executed
+	// again if one of the outputs was changed or removed
+	Outputs []string `yaml:"outputs,omitempty" json:"outputs,omitempty"`
+}
+
+// CommandContainer defines the container a command is executed in
+type CommandContainer struct {
+	LabelSelector map[string]string `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+	Pod           string            `yaml:"pod,omitempty" json:"pod,omitempty"`
+	Namespace     string            `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	ImageSelector string            `yaml:"imageSelector,omitempty" json:"imageSelector,omitempty"`
+	ContainerName string            `yaml:"containerName,omitempty" json:"containerName,omitempty"`
+
+	// WorkingDir is the directory within the container the command is executed in
+	WorkingDir string `yaml:"workingDir,omitempty" json:"workingDir,omitempty"`
+}
+
+// CommandFlag defines a flag of a command
+type CommandFlag struct {
+	// Name of the flag, e.g. replicas for --replicas
+	Name string `yaml:"name" json:"name"`
+
+	// Short is the optional one letter shorthand of the flag, e.g. r for -r
+	Short string `yaml:"short,omitempty" json:"short,omitempty"`
+
+	// Type of the flag. Defaults to string
+	Type CommandArgType `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Default is the value of the flag if it is not specified
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+
+	// Required marks the flag as required
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+
+	// Description is shown in the help of the command
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// CommandArg defines a positional argument of a command
+type CommandArg struct {
+	// Name of the argument
+	Name string `yaml:"name" json:"name"`
+
+	// Type of the argument. Defaults to string. Only the last argument can be a list, which
+	// receives all remaining arguments.
+	Type CommandArgType `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Default is the value of the argument if it is not specified
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+
+	// Required marks the argument as required
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+
+	// Description is shown in the help of the command
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 }
 
+// CommandArgType is the type of a command flag or argument
+type CommandArgType string
+
+// List of values that type can take
+const (
+	CommandArgTypeDefault CommandArgType = ""
+	CommandArgTypeString  CommandArgType = "string"
+	CommandArgTypeInt     CommandArgType = "int"
+	CommandArgTypeBool    CommandArgType = "bool"
+	CommandArgTypeList    CommandArgType = "list"
+)
+
 // Variable describes the var definition
 type Variable struct {
-	Name              string   `yaml:"name" json:"name"`
+	Name string `yaml:"name" json:"name"`
+
+	// Type is the type of the variable. If omitted, the type is guessed from the value
+	Type VariableType `yaml:"type,omitempty" json:"type,omitempty"`
+
 	Question          string   `yaml:"question,omitempty" json:"question,omitempty"`
 	Options           []string `yaml:"options,omitempty" json:"options,omitempty"`
 	Password          bool     `yaml:"password,omitempty" json:"password,omitempty"`
@@ -1121,6 +1398,50 @@ type Variable struct {
 	// Commands are additional commands that can be used to run a different command on a different operating
 	// system.
 	Commands []VariableCommand `yaml:"commands,omitempty" json:"commands,omitempty"`
+
+	// Secret is the Kubernetes secret the variable is read from if source is secret
+	Secret *VariableSecretSource `yaml:"secret,omitempty" json:"secret,omitempty"`
+
+	// SOPS is the SOPS encrypted file the variable is read from if source is sops
+	SOPS *VariableSOPSSource `yaml:"sops,omitempty" json:"sops,omitempty"`
+
+	// Vault is the Vault secret the variable is read from if source is vault
+	Vault *VariableVaultSource `yaml:"vault,omitempty" json:"vault,omitempty"`
+}
+
+// VariableSecretSource defines a key of a Kubernetes secret
+type VariableSecretSource struct {
+	// Name of the secret
+	Name string `yaml:"name" json:"name"`
+
+	// Namespace of the secret. If omitted, the current namespace is used
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Key within the secret data. If omitted, all keys are returned as map
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+// VariableSOPSSource defines a value within a SOPS encrypted file
+type VariableSOPSSource struct {
+	// File is the path to the encrypted file
+	File string `yaml:"file" json:"file"`
+
+	// Key is the path of the value within the decrypted file, e.g. database.password. If omitted,
+	// the whole file is returned
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+// VariableVaultSource defines a key of a Vault secret. The token is read from the VAULT_TOKEN
+// environment variable or ~/.vault-token
+type VariableVaultSource struct {
+	// Address of the Vault server. Defaults to the VAULT_ADDR environment variable
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+
+	// Path of the secret, e.g. secret/data/my-app for the kv version 2 engine
+	Path string `yaml:"path" json:"path"`
+
+	// Key within the secret data. If omitted, all keys are returned as map
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
 }
 
 type VariableCommand struct {
@@ -1140,6 +1461,22 @@ const (
 	VariableSourceInput   VariableSource = "input"
 	VariableSourceCommand VariableSource = "command"
 	VariableSourceNone    VariableSource = "none"
+	VariableSourceSecret  VariableSource = "secret"
+	VariableSourceSOPS    VariableSource = "sops"
+	VariableSourceVault   VariableSource = "vault"
+)
+
+// VariableType is the type of a variable value
+type VariableType string
+
+// List of values that type can take
+const (
+	VariableTypeAuto   VariableType = ""
+	VariableTypeString VariableType = "string"
+	VariableTypeInt    VariableType = "int"
+	VariableTypeBool   VariableType = "bool"
+	VariableTypeList   VariableType = "list"
+	VariableTypeMap    VariableType = "map"
 )
 
 // ProfileConfig defines a profile config
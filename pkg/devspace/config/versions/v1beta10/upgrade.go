@@ -12,7 +12,17 @@ import (
 // Upgrade upgrades the config
 func (c *Config) Upgrade(log log.Logger) (config.Config, error) {
 	nextConfig := &next.Config{}
+
+	// hook.when is converted to hook.events below and cannot be converted to the new hook.when
+	whens := make([]*HookWhenConfig, len(c.Hooks))
+	for i, h := range c.Hooks {
+		whens[i] = h.When
+		h.When = nil
+	}
 	err := util.Convert(c, nextConfig)
+	for i, h := range c.Hooks {
+		h.When = whens[i]
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/loft-sh/devspace/pkg/devspace/command"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
@@ -104,7 +105,7 @@ type ResolveOptions struct {
 }
 
 func (m *manager) ResolveAll(options ResolveOptions) ([]types.Dependency, error) {
-	dependencies, err := m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, options.Silent, options.Verbose, "Resolve", func(dependency *Dependency, log log.Logger) error {
+	dependencies, err := m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, options.Silent, options.Verbose, 1, "Resolve", func(dependency *Dependency, log log.Logger) error {
 		return nil
 	})
 	if err != nil {
@@ -121,25 +122,37 @@ type CommandOptions struct {
 	Args               []string
 	UpdateDependencies bool
 	Verbose            bool
+
+	// Stdout, Stderr and Stdin default to the ones of the current process
+	Stdout io.Writer
+	Stderr io.Writer
+	Stdin  io.Reader
+
+	// NewContainerExecuter creates the executer for commands that are executed in a container with
+	// the config of the dependency. It is only called if such a command is executed.
+	NewContainerExecuter func(config config.Config) (command.ContainerExecuter, error)
 }
 
 // Command will execute a dependency command
 func (m *manager) Command(options CommandOptions) error {
 	found := false
-	_, err := m.handleDependencies(nil, []string{options.Dependency}, false, options.UpdateDependencies, true, options.Verbose, "Command", func(dependency *Dependency, log log.Logger) error {
+	_, err := m.handleDependencies(nil, []string{options.Dependency}, false, options.UpdateDependencies, true, options.Verbose, 1, "Command", func(dependency *Dependency, log log.Logger) error {
 		// Switch current working directory
-		currentWorkingDirectory, err := dependency.prepare(true)
+		_, err := dependency.prepare(true)
+		if err != nil {
+			return err
+		}
+
+		release, err := dependency.switchWorkingDirectory()
 		if err != nil {
 			return err
-		} else if currentWorkingDirectory == "" {
-			return nil
 		}
 
 		// Change back to original working directory
-		defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+		defer release()
 
 		found = true
-		return ExecuteCommand(dependency.localConfig.Config().Commands, options.Command, options.Args, os.Stdout, os.Stderr)
+		return executeCommand(dependency, options, log)
 	})
 	if !found {
 		return fmt.Errorf("couldn't find dependency %s", options.Dependency)
@@ -148,9 +161,53 @@ func (m *manager) Command(options CommandOptions) error {
 	return err
 }
 
+// executeCommand executes a command of the dependency. Commands that are executed in a container use
+// an executer that is created with the config of the dependency, so that its image selectors are used.
+func executeCommand(dependency *Dependency, options CommandOptions, log log.Logger) error {
+	commands := dependency.localConfig.Config().Commands
+	commandOptions := &command.Options{
+		Stdout: options.Stdout,
+		Stderr: options.Stderr,
+		Stdin:  options.Stdin,
+		Log:    log,
+	}
+	if commandOptions.Stdout == nil {
+		commandOptions.Stdout = os.Stdout
+	}
+	if commandOptions.Stderr == nil {
+		commandOptions.Stderr = os.Stderr
+	}
+	if commandOptions.Stdin == nil {
+		commandOptions.Stdin = os.Stdin
+	}
+
+	for _, c := range command.Dependencies(commands, options.Command) {
+		if command.NeedsContainer(c) && options.NewContainerExecuter != nil {
+			var err error
+			commandOptions.ContainerExecuter, err = options.NewContainerExecuter(dependency.localConfig)
+			if err != nil {
+				return err
+			}
+
+			break
+		}
+	}
+
+	return ExecuteCommandWithOptions(commands, options.Command, options.Args, commandOptions)
+}
+
 // ExecuteCommand executes a given command from the available commands
 func ExecuteCommand(commands []*latest.CommandConfig, cmd string, args []string, stdout io.Writer, stderr io.Writer) error {
-	err := command.ExecuteCommand(commands, cmd, args, stdout, stderr)
+	return ExecuteCommandWithOptions(commands, cmd, args, &command.Options{
+		Stdout: stdout,
+		Stderr: stderr,
+		Stdin:  os.Stdin,
+	})
+}
+
+// ExecuteCommandWithOptions executes a given command from the available commands with the given options
+func ExecuteCommandWithOptions(commands []*latest.CommandConfig, cmd string, args []string, options *command.Options) error {
+	err := command.ExecuteCommandWithOptions(commands, cmd, args, options)
 	if err != nil {
 		if status, ok := interp.IsExitStatus(err); ok {
 			return &exit.ReturnCodeError{
@@ -173,13 +230,32 @@ type BuildOptions struct {
 	UpdateDependencies      bool
 	ForceDeployDependencies bool
 	Verbose                 bool
+
+	// MaxConcurrency is the maximum amount of dependencies that are built concurrently
+	MaxConcurrency int
 }
 
 // BuildAll will build all dependencies if there are any
 func (m *manager) BuildAll(options BuildOptions) ([]types.Dependency, error) {
-	return m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, "Build", func(dependency *Dependency, log log.Logger) error {
+	dependencies, err := m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, options.MaxConcurrency, "Build", func(dependency *Dependency, log log.Logger) error {
+		// fill in the outputs of the dependencies of this dependency
+		err := dependency.fillOutputs()
+		if err != nil {
+			return err
+		}
+
 		return dependency.Build(options.ForceDeployDependencies, &options.BuildOptions, log)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.fillOutputs(dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
 }
 
 // DeployOptions has all options for deploying all dependencies
@@ -194,6 +270,9 @@ type DeployOptions struct {
 	SkipDeploy              bool
 	ForceDeploy             bool
 	Verbose                 bool
+
+	// MaxConcurrency is the maximum amount of dependencies that are deployed concurrently
+	MaxConcurrency int
 }
 
 // DeployAll will deploy all dependencies if there are any
@@ -203,13 +282,19 @@ func (m *manager) DeployAll(options DeployOptions) ([]types.Dependency, error) {
 		return nil, pluginErr
 	}
 
-	dependencies, err := m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, "Deploy", func(dependency *Dependency, log log.Logger) error {
-		err := dependency.Deploy(options.ForceDeployDependencies, options.SkipBuild, options.SkipDeploy, options.ForceDeploy, &options.BuildOptions, log)
+	dependencies, err := m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, options.MaxConcurrency, "Deploy", func(dependency *Dependency, log log.Logger) error {
+		// fill in the outputs of the dependencies of this dependency
+		err := dependency.fillOutputs()
 		if err != nil {
 			return err
 		}
 
-		return nil
+		err = dependency.Deploy(options.ForceDeployDependencies, options.SkipBuild, options.SkipDeploy, options.ForceDeploy, &options.BuildOptions, log)
+		if err != nil {
+			return err
+		}
+
+		return dependency.resolveOutputs()
 	})
 	if err != nil {
 		pluginErr := hook.ExecuteHooks(m.client, m.config, nil, map[string]interface{}{"error": err}, m.log, "error:deployDependencies")
@@ -220,6 +305,12 @@ func (m *manager) DeployAll(options DeployOptions) ([]types.Dependency, error) {
 		return nil, err
 	}
 
+	// fill in the outputs of the direct dependencies into the root config
+	err = m.fillOutputs(dependencies)
+	if err != nil {
+		return nil, err
+	}
+
 	pluginErr = hook.ExecuteHooks(m.client, m.config, dependencies, nil, m.log, "after:deployDependencies")
 	if pluginErr != nil {
 		return nil, pluginErr
@@ -233,11 +324,14 @@ type PurgeOptions struct {
 	SkipDependencies []string
 	Dependencies     []string
 	Verbose          bool
+
+	// MaxConcurrency is the maximum amount of dependencies that are purged concurrently
+	MaxConcurrency int
 }
 
 // PurgeAll purges all dependencies in reverse order
 func (m *manager) PurgeAll(options PurgeOptions) ([]types.Dependency, error) {
-	return m.handleDependencies(options.SkipDependencies, options.Dependencies, true, false, false, options.Verbose, "Purge", func(dependency *Dependency, log log.Logger) error {
+	return m.handleDependencies(options.SkipDependencies, options.Dependencies, true, false, false, options.Verbose, options.MaxConcurrency, "Purge", func(dependency *Dependency, log log.Logger) error {
 		return dependency.Purge(log)
 	})
 }
@@ -251,16 +345,99 @@ type RenderOptions struct {
 	SkipBuild          bool
 	Writer             io.Writer
 
+	// MaxConcurrency is the maximum amount of dependencies that are rendered concurrently
+	MaxConcurrency int
+
 	BuildOptions build.Options
 }
 
 func (m *manager) RenderAll(options RenderOptions) ([]types.Dependency, error) {
-	return m.handleDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, "Render", func(dependency *Dependency, log log.Logger) error {
-		return dependency.Render(options.SkipBuild, &options.BuildOptions, options.Writer, log)
+	// dependencies might be rendered concurrently, so the output of every dependency is buffered
+	// and written in execution order afterwards, which keeps the manifests of a dependency together
+	var (
+		outputsMutex sync.Mutex
+		outputs      = map[string]*bytes.Buffer{}
+	)
+	executedDependencies, err := m.executeDependencies(options.SkipDependencies, options.Dependencies, false, options.UpdateDependencies, false, options.Verbose, options.MaxConcurrency, "Render", func(dependency *Dependency, log log.Logger) error {
+		// fill in the outputs of the dependencies of this dependency
+		err := dependency.fillOutputs()
+		if err != nil {
+			return err
+		}
+
+		out := &bytes.Buffer{}
+		outputsMutex.Lock()
+		outputs[dependency.ID()] = out
+		outputsMutex.Unlock()
+		return dependency.Render(options.SkipBuild, &options.BuildOptions, out, log)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Writer != nil {
+		for _, dependency := range executedDependencies {
+			if out, ok := outputs[dependency.ID()]; ok {
+				_, err = options.Writer.Write(out.Bytes())
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	dependencies := rootDependencies(executedDependencies)
+	err = m.fillOutputs(dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+// fillOutputs fills in the outputs of the dependencies of this dependency
+func (d *Dependency) fillOutputs() error {
+	if d.localConfig == nil {
+		return nil
+	}
+
+	return FillOutputs(d.localConfig.Config(), d.children)
+}
+
+// fillOutputs fills the outputs of the given root dependencies into the root config. Outputs of
+// dependencies that were skipped cannot be resolved and return an error if they are referenced.
+func (m *manager) fillOutputs(dependencies []types.Dependency) error {
+	if m.config == nil {
+		return nil
+	}
+
+	return FillOutputs(m.config.Config(), rootDependencies(dependencies))
+}
+
+func rootDependencies(dependencies []types.Dependency) []types.Dependency {
+	rootDependencies := []types.Dependency{}
+	for _, dependency := range dependencies {
+		if dependency.Root() {
+			rootDependencies = append(rootDependencies, dependency)
+		}
+	}
+
+	return rootDependencies
 }
 
-func (m *manager) handleDependencies(skipDependencies, filterDependencies []string, reverse, updateDependencies, silent, verbose bool, actionName string, action func(dependency *Dependency, log log.Logger) error) ([]types.Dependency, error) {
+func (m *manager) handleDependencies(skipDependencies, filterDependencies []string, reverse, updateDependencies, silent, verbose bool, concurrency int, actionName string, action func(dependency *Dependency, log log.Logger) error) ([]types.Dependency, error) {
+	executedDependencies, err := m.executeDependencies(skipDependencies, filterDependencies, reverse, updateDependencies, silent, verbose, concurrency, actionName, action)
+	if err != nil {
+		return nil, err
+	}
+
+	// we only return the root executed dependencies (you could get the others via traversing the graph and children)
+	return rootDependencies(executedDependencies), nil
+}
+
+// executeDependencies executes the action for all dependencies and returns the executed
+// dependencies in execution order
+func (m *manager) executeDependencies(skipDependencies, filterDependencies []string, reverse, updateDependencies, silent, verbose bool, concurrency int, actionName string, action func(dependency *Dependency, log log.Logger) error) ([]types.Dependency, error) {
 	if m.config == nil || m.config.Config() == nil || len(m.config.Config().Dependencies) == 0 {
 		return nil, nil
 	}
@@ -284,91 +461,72 @@ func (m *manager) handleDependencies(skipDependencies, filterDependencies []stri
 		m.log.Infof("To display the complete dependency execution log run with the '--verbose-dependencies' flag")
 	}
 
-	// Execute all dependencies
-	i := 0
-	if reverse {
-		i = len(dependencies) - 1
-	}
-
 	numDependencies := len(dependencies)
 	if len(filterDependencies) > 0 {
 		numDependencies = len(filterDependencies)
 	}
 
-	executedDependencies := []types.Dependency{}
 	if !silent && !verbose {
 		m.log.StartWait(fmt.Sprintf("%s %d dependencies", actionName, numDependencies))
 	}
-	for i >= 0 && i < len(dependencies) {
-		var (
-			dependency       = dependencies[i]
-			buff             = &bytes.Buffer{}
-			dependencyLogger = m.log
-		)
-
-		// Increase / Decrease counter
-		if reverse {
-			i--
-		} else {
-			i++
-		}
 
+	// Execute all dependencies, independent ones are executed concurrently
+	var (
+		executedMutex = sync.Mutex{}
+		executed      = map[string]bool{}
+	)
+	err = schedule(dependencies, reverse, concurrency, func(dependency *Dependency) error {
 		// Check if we should act on this dependency
 		if !foundDependency(dependency.Name(), filterDependencies) {
-			continue
+			return nil
 		} else if skipDependency(dependency.Name(), skipDependencies) {
 			m.log.Infof("Skip dependency %s", dependency.Name())
-			continue
+			return nil
 		}
 
-		// If not verbose log to a stream
-		if !verbose {
+		// If not verbose or running concurrently log to a stream, so the output
+		// of the dependencies is not mixed up
+		buff := &bytes.Buffer{}
+		dependencyLogger := m.log
+		if !verbose || concurrency > 1 {
 			dependencyLogger = log.NewStreamLogger(buff, logrus.InfoLevel)
 		}
 
-		if dependency.Config() != nil {
-			pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
-				"dependency_name":        dependency.Name(),
-				"dependency_config":      dependency.Config().Config(),
-				"dependency_config_path": dependency.Config().Path(),
-			}, hook.EventsForSingle("before:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.before"+actionName)...)
-			if pluginErr != nil {
-				return nil, pluginErr
-			}
+		err := m.executeDependency(dependency, actionName, action, dependencyLogger)
+		if verbose && concurrency > 1 {
+			m.log.WriteString(buff.String())
 		}
-
-		err := action(dependency, dependencyLogger)
 		if err != nil {
-			if dependency.Config() != nil {
-				pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
-					"dependency_name":        dependency.Name(),
-					"dependency_config":      dependency.Config().Config(),
-					"dependency_config_path": dependency.Config().Path(),
-				}, hook.EventsForSingle("error:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.error"+actionName)...)
-				if pluginErr != nil {
-					return nil, pluginErr
-				}
+			if _, ok := err.(*pluginError); ok {
+				return err
 			}
 
-			return nil, errors.Wrapf(err, "%s dependency %s error %s", actionName, dependency.Name(), buff.String())
+			return errors.Wrapf(err, "%s dependency %s error %s", actionName, dependency.Name(), buff.String())
 		}
 
-		if dependency.Config() != nil {
-			pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
-				"dependency_name":        dependency.Name(),
-				"dependency_config":      dependency.Config().Config(),
-				"dependency_config_path": dependency.Config().Path(),
-			}, hook.EventsForSingle("after:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.after"+actionName)...)
-			if pluginErr != nil {
-				return nil, pluginErr
-			}
-		}
-
-		executedDependencies = append(executedDependencies, dependency)
+		executedMutex.Lock()
+		executed[dependency.ID()] = true
+		executedMutex.Unlock()
 		if !silent {
 			m.log.Donef("%s dependency %s completed", actionName, dependency.Name())
 		}
+		return nil
+	})
+	if err != nil {
+		if pluginErr, ok := err.(*pluginError); ok {
+			return nil, pluginErr.err
+		}
+
+		return nil, err
+	}
+
+	executedDependencies := []types.Dependency{}
+	for _, dependency := range dependencies {
+		if executed[dependency.ID()] {
+			executedDependencies = append(executedDependencies, dependency)
+		}
 	}
+
 	m.log.StopWait()
 	if !silent {
 		if len(executedDependencies) > 0 {
@@ -378,17 +536,64 @@ func (m *manager) handleDependencies(skipDependencies, filterDependencies []stri
 		}
 	}
 
-	// we only return the root executed dependencies (you could get the others via traversing the graph and children)
-	retDependencies := []types.Dependency{}
-	for _, d := range executedDependencies {
-		if d.Root() {
-			retDependencies = append(retDependencies, d)
+	return executedDependencies, nil
+}
+
+// pluginError wraps an error returned by a plugin hook, which is returned as is
+type pluginError struct {
+	err error
+}
+
+func (p *pluginError) Error() string {
+	return p.err.Error()
+}
+
+// executeDependency executes the action for a single dependency including the plugin hooks
+func (m *manager) executeDependency(dependency *Dependency, actionName string, action func(dependency *Dependency, log log.Logger) error, log log.Logger) error {
+	if dependency.Config() != nil {
+		pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
+			"dependency_name":        dependency.Name(),
+			"dependency_config":      dependency.Config().Config(),
+			"dependency_config_path": dependency.Config().Path(),
+		}, hook.EventsForSingle("before:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.before"+actionName)...)
+		if pluginErr != nil {
+			return &pluginError{err: pluginErr}
+		}
+	}
+
+	err := action(dependency, log)
+	if err != nil {
+		if dependency.Config() != nil {
+			pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
+				"dependency_name":        dependency.Name(),
+				"dependency_config":      dependency.Config().Config(),
+				"dependency_config_path": dependency.Config().Path(),
+			}, hook.EventsForSingle("error:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.error"+actionName)...)
+			if pluginErr != nil {
+				return &pluginError{err: pluginErr}
+			}
+		}
+
+		return err
+	}
+
+	if dependency.Config() != nil {
+		pluginErr := plugin.ExecutePluginHookWithContext(map[string]interface{}{
+			"dependency_name":        dependency.Name(),
+			"dependency_config":      dependency.Config().Config(),
+			"dependency_config_path": dependency.Config().Path(),
+		}, hook.EventsForSingle("after:"+strings.ToLower(actionName)+"Dependency", dependency.Name()).With("dependencies.after"+actionName)...)
+		if pluginErr != nil {
+			return &pluginError{err: pluginErr}
 		}
 	}
 
-	return retDependencies, nil
+	return nil
 }
 
+// dependencyCacheLock guards the dependency cache that is shared by all dependencies
+var dependencyCacheLock sync.Mutex
+
 // Dependency holds the dependency config and has an id
 type Dependency struct {
 	id          string
@@ -396,6 +601,7 @@ type Dependency struct {
 	localConfig config.Config
 
 	builtImages map[string]string
+	outputs     map[string]string
 
 	children []types.Dependency
 	root     bool
@@ -429,18 +635,29 @@ func (d *Dependency) Root() bool { return d.root }
 
 func (d *Dependency) BuiltImages() map[string]string { return d.builtImages }
 
+func (d *Dependency) Outputs() map[string]string {
+	outputsMutex.Lock()
+	defer outputsMutex.Unlock()
+
+	return d.outputs
+}
+
 // Build builds and pushes all defined images
 func (d *Dependency) Build(forceDependencies bool, buildOptions *build.Options, log log.Logger) error {
-	// Switch current working directory
-	currentWorkingDirectory, err := d.prepare(forceDependencies)
+	// Check if the dependency has changed
+	changed, err := d.prepare(forceDependencies)
 	if err != nil {
 		return err
-	} else if currentWorkingDirectory == "" {
+	} else if !changed {
 		return nil
 	}
 
-	// Change back to original working directory
-	defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+	// Switch current working directory if necessary
+	release, err := d.lockWorkingDirectory()
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	// Check if image build is enabled
 	_, err = d.buildImages(false, buildOptions, log)
@@ -452,16 +669,20 @@ func (d *Dependency) Build(forceDependencies bool, buildOptions *build.Options,
 
 // Deploy deploys the dependency if necessary
 func (d *Dependency) Deploy(forceDependencies, skipBuild, skipDeploy, forceDeploy bool, buildOptions *build.Options, log log.Logger) error {
-	// Switch current working directory
-	currentWorkingDirectory, err := d.prepare(forceDependencies)
+	// Check if the dependency has changed
+	changed, err := d.prepare(forceDependencies)
 	if err != nil {
 		return err
-	} else if currentWorkingDirectory == "" {
+	} else if !changed {
 		return nil
 	}
 
-	// Change back to original working directory
-	defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+	// Switch current working directory if necessary
+	release, err := d.lockWorkingDirectory()
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	// Create namespace if necessary
 	err = d.kubeClient.EnsureDeployNamespaces(d.localConfig.Config(), log)
@@ -503,13 +724,12 @@ func (d *Dependency) Deploy(forceDependencies, skipBuild, skipDeploy, forceDeplo
 
 // Render renders the dependency
 func (d *Dependency) Render(skipBuild bool, buildOptions *build.Options, out io.Writer, log log.Logger) error {
-	// Switch current working directory
-	currentWorkingDirectory, err := d.changeWorkingDirectory()
+	// Switch current working directory if necessary
+	release, err := d.lockWorkingDirectory()
 	if err != nil {
-		return errors.Wrap(err, "getwd")
+		return err
 	}
-
-	defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+	defer release()
 
 	// Check if image build is enabled
 	builtImages, err := d.buildImages(skipBuild, buildOptions, log)
@@ -525,12 +745,12 @@ func (d *Dependency) Render(skipBuild bool, buildOptions *build.Options, out io.
 
 // Purge purges the dependency
 func (d *Dependency) Purge(log log.Logger) error {
-	// Switch current working directory
-	currentWorkingDirectory, err := d.changeWorkingDirectory()
+	// Switch current working directory if necessary
+	release, err := d.lockWorkingDirectory()
 	if err != nil {
-		return errors.Wrap(err, "getwd")
+		return err
 	}
-	defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+	defer release()
 
 	// Purge the deployments
 	err = d.deployController.Purge(nil, log)
@@ -545,16 +765,18 @@ func (d *Dependency) Purge(log log.Logger) error {
 		}
 	}
 
+	dependencyCacheLock.Lock()
 	delete(d.dependencyCache.GetActive().Dependencies, d.id)
+	dependencyCacheLock.Unlock()
 	return nil
 }
 
 func (d *Dependency) StartSync(client kubectl.Client, interrupt chan error, printSyncLog, verboseSync bool, logger log.Logger) error {
-	currentWorkingDirectory, err := d.changeWorkingDirectory()
+	release, err := d.switchWorkingDirectory()
 	if err != nil {
-		return errors.Wrap(err, "getwd")
+		return err
 	}
-	defer func() { _ = os.Chdir(currentWorkingDirectory) }()
+	defer release()
 
 	err = services.NewClient(d.localConfig, d.children, client, logger).StartSync(interrupt, printSyncLog, verboseSync, services.DependencyPrefixFn(d.Name()))
 	if err != nil {
@@ -620,20 +842,52 @@ func (d *Dependency) changeWorkingDirectory() (string, error) {
 	return currentWorkingDirectory, nil
 }
 
-func (d *Dependency) prepare(forceDependencies bool) (string, error) {
+// lockWorkingDirectory locks the working directory for the execution of the dependency and
+// switches into the dependency directory if the dependency needs it. The returned function
+// switches back to the original working directory and releases the lock.
+func (d *Dependency) lockWorkingDirectory() (func(), error) {
+	if d.localConfig == nil || d.localConfig.Config() == nil || !needsWorkingDirectory(d.localConfig.Config()) {
+		workingDirectoryLock.RLock()
+		return workingDirectoryLock.RUnlock, nil
+	}
+
+	return d.switchWorkingDirectory()
+}
+
+// switchWorkingDirectory switches into the dependency directory while holding the write lock of
+// the working directory. The returned function switches back and releases the lock.
+func (d *Dependency) switchWorkingDirectory() (func(), error) {
+	workingDirectoryLock.Lock()
+	currentWorkingDirectory, err := d.changeWorkingDirectory()
+	if err != nil {
+		workingDirectoryLock.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		_ = os.Chdir(currentWorkingDirectory)
+		workingDirectoryLock.Unlock()
+	}, nil
+}
+
+// prepare returns true if the dependency directory has changed since the last execution
+func (d *Dependency) prepare(forceDependencies bool) (bool, error) {
 	// Check if we should redeploy
 	directoryHash, err := hash.DirectoryExcludes(d.localPath, []string{".git", ".devspace"}, true)
 	if err != nil {
-		return "", errors.Wrap(err, "hash directory")
+		return false, errors.Wrap(err, "hash directory")
 	}
 
+	dependencyCacheLock.Lock()
+	defer dependencyCacheLock.Unlock()
+
 	// Check if we skip the dependency deploy
 	if !forceDependencies && directoryHash == d.dependencyCache.GetActive().Dependencies[d.id] {
-		return "", nil
+		return false, nil
 	}
 
 	d.dependencyCache.GetActive().Dependencies[d.id] = directoryHash
-	return d.changeWorkingDirectory()
+	return true, nil
 }
 
 func skipDependency(name string, skipDependencies []string) bool {
@@ -1,19 +1,26 @@
 package dependency
 
 import (
+	"bytes"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	fakebuild "github.com/loft-sh/devspace/pkg/devspace/build/testing"
+	"github.com/loft-sh/devspace/pkg/devspace/command"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	fakegeneratedloader "github.com/loft-sh/devspace/pkg/devspace/config/generated/testing"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
 	fakedeploy "github.com/loft-sh/devspace/pkg/devspace/deploy/testing"
 	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	fakeregistry "github.com/loft-sh/devspace/pkg/devspace/pullsecrets/testing"
@@ -49,6 +56,32 @@ func (r *fakeResolver) Resolve(update bool) ([]*Dependency, error) {
 	return r.resolvedDependencies, nil
 }
 
+// staticResolver returns the dependencies as they are
+type staticResolver struct {
+	dependencies []*Dependency
+}
+
+func (r *staticResolver) Resolve(update bool) ([]*Dependency, error) {
+	return r.dependencies, nil
+}
+
+// renderController writes the manifests of a dependency after a delay
+type renderController struct {
+	fakedeploy.FakeController
+
+	manifests string
+	delay     time.Duration
+}
+
+func (r *renderController) Render(options *deploy.Options, out io.Writer, log log.Logger) error {
+	for _, line := range strings.SplitAfter(r.manifests, "\n") {
+		time.Sleep(r.delay)
+		_, _ = out.Write([]byte(line))
+	}
+
+	return nil
+}
+
 type updateAllTestCase struct {
 	name             string
 	files            map[string]string
@@ -699,3 +732,86 @@ func TestDeploy(t *testing.T) {
 		}
 	}
 }
+
+type fakeContainerExecuter struct {
+	commands [][]string
+}
+
+func (f *fakeContainerExecuter) ExecuteCommandInContainer(container *latest.CommandContainer, command []string, stdout io.Writer, stderr io.Writer, stdin io.Reader) error {
+	f.commands = append(f.commands, command)
+	return nil
+}
+
+func TestExecuteCommand(t *testing.T) {
+	dependencyConfig := config.NewConfig(nil, &latest.Config{
+		Commands: []*latest.CommandConfig{
+			{Name: "local", Command: "echo local"},
+			{Name: "remote", Command: "echo remote", Container: &latest.CommandContainer{ImageSelector: "image(api)"}},
+		},
+	}, nil, nil, "")
+	dependency := &Dependency{
+		dependencyConfig: &latest.DependencyConfig{Name: "dep"},
+		localConfig:      dependencyConfig,
+	}
+
+	executer := &fakeContainerExecuter{}
+	var executerConfig config.Config
+	stdout := &bytes.Buffer{}
+	options := CommandOptions{
+		Dependency: "dep",
+		Stdout:     stdout,
+		Stderr:     stdout,
+		Stdin:      &bytes.Buffer{},
+		NewContainerExecuter: func(config config.Config) (command.ContainerExecuter, error) {
+			executerConfig = config
+			return executer, nil
+		},
+	}
+
+	options.Command = "local"
+	err := executeCommand(dependency, options, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, stdout.String(), "local\n")
+	assert.Assert(t, executerConfig == nil, "container executer created for a local command")
+
+	options.Command = "remote"
+	err = executeCommand(dependency, options, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, executerConfig == dependencyConfig, "container executer not created with the dependency config")
+	assert.Equal(t, len(executer.commands), 1)
+}
+
+func TestRenderAllConcurrent(t *testing.T) {
+	newRenderDependency := func(name string, delay time.Duration, children ...types.Dependency) *Dependency {
+		return &Dependency{
+			id:               name,
+			root:             len(children) > 0,
+			children:         children,
+			dependencyConfig: &latest.DependencyConfig{Name: name},
+			deployController: &renderController{manifests: "kind: " + name + "\nname: " + name + "\n", delay: delay},
+		}
+	}
+	slow := newRenderDependency("slow", time.Millisecond*50)
+	fast := newRenderDependency("fast", time.Millisecond*10)
+	top := newRenderDependency("top", 0, slow, fast)
+
+	manager := &manager{
+		config: config.Ensure(config.NewConfig(nil, &latest.Config{
+			Dependencies: []*latest.DependencyConfig{{Name: "top"}},
+		}, nil, nil, "")),
+		log: log.Discard,
+		resolver: &staticResolver{
+			dependencies: []*Dependency{slow, fast, top},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	dependencies, err := manager.RenderAll(RenderOptions{
+		SkipBuild:      true,
+		Writer:         out,
+		MaxConcurrency: 2,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(dependencies), 1)
+	assert.Equal(t, out.String(), "kind: slow\nname: slow\nkind: fast\nname: fast\nkind: top\nname: top\n")
+}
@@ -0,0 +1,288 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/pkg/errors"
+)
+
+// Supported output formats
+const (
+	FormatTree    = "tree"
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Graph is the resolved dependency graph of a project
+type Graph struct {
+	// Root is the name of the project
+	Root string `json:"root"`
+
+	// Dependencies are the ids of the direct dependencies of the project
+	Dependencies []string `json:"dependencies"`
+
+	// Nodes are all resolved dependencies
+	Nodes []*Node `json:"nodes"`
+}
+
+// Node is a single resolved dependency
+type Node struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Source      string   `json:"source"`
+	Profile     string   `json:"profile,omitempty"`
+	Namespace   string   `json:"namespace,omitempty"`
+	Images      []string `json:"images,omitempty"`
+	Deployments []string `json:"deployments,omitempty"`
+
+	// Dependencies are the ids of the dependencies of this dependency
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// New creates a new graph from the resolved root dependencies
+func New(root string, dependencies []types.Dependency) *Graph {
+	graph := &Graph{
+		Root:         root,
+		Dependencies: []string{},
+		Nodes:        []*Node{},
+	}
+
+	visited := map[string]bool{}
+	for _, dependency := range dependencies {
+		graph.Dependencies = append(graph.Dependencies, dependency.ID())
+		graph.add(dependency, visited)
+	}
+
+	return graph
+}
+
+func (g *Graph) add(dependency types.Dependency, visited map[string]bool) {
+	if visited[dependency.ID()] {
+		return
+	}
+	visited[dependency.ID()] = true
+
+	node := &Node{
+		ID:           dependency.ID(),
+		Name:         dependency.Name(),
+		Dependencies: []string{},
+	}
+	if dependencyConfig := dependency.DependencyConfig(); dependencyConfig != nil {
+		node.Source = Source(dependencyConfig.Source)
+		node.Namespace = dependencyConfig.Namespace
+		node.Profile = strings.Join(Profiles(dependencyConfig), ",")
+	}
+	if dependency.Config() != nil && dependency.Config().Config() != nil {
+		config := dependency.Config().Config()
+		for name := range config.Images {
+			node.Images = append(node.Images, name)
+		}
+		sort.Strings(node.Images)
+		for _, deployment := range config.Deployments {
+			node.Deployments = append(node.Deployments, deployment.Name)
+		}
+	}
+
+	g.Nodes = append(g.Nodes, node)
+	for _, child := range dependency.Children() {
+		node.Dependencies = append(node.Dependencies, child.ID())
+		g.add(child, visited)
+	}
+}
+
+// Source returns a human readable description of a dependency source
+func Source(source *latest.SourceConfig) string {
+	if source == nil {
+		return ""
+	}
+
+	out := ""
+	if source.Git != "" {
+		out = "git: " + util.GetSource(source)
+		if source.Tag != "" {
+			out += "@" + source.Tag
+		} else if source.Branch != "" {
+			out += "@" + source.Branch
+		} else if source.Revision != "" {
+			out += "@" + source.Revision
+		}
+	} else if source.OCI != "" {
+		out = "oci: " + util.GetSource(source)
+	} else if source.Tarball != "" {
+		out = "tarball: " + util.GetSource(source)
+	} else {
+		out = "path: " + source.Path
+	}
+	if source.SubPath != "" {
+		out += ":" + source.SubPath
+	}
+
+	return out
+}
+
+// Profiles returns all profiles that are activated for a dependency
+func Profiles(dependencyConfig *latest.DependencyConfig) []string {
+	profiles := []string{}
+	profiles = append(profiles, dependencyConfig.ProfileParents...)
+	if dependencyConfig.Profile != "" {
+		profiles = append(profiles, dependencyConfig.Profile)
+	}
+
+	return append(profiles, dependencyConfig.Profiles...)
+}
+
+// Print prints the graph in the given format to the writer
+func (g *Graph) Print(format string, writer io.Writer) error {
+	switch format {
+	case FormatTree, "":
+		return g.Tree(writer)
+	case FormatDOT:
+		return g.DOT(writer)
+	case FormatMermaid:
+		return g.Mermaid(writer)
+	case FormatJSON:
+		return g.JSON(writer)
+	}
+
+	return errors.Errorf("unsupported output format %s, please use one of %s, %s, %s or %s", format, FormatTree, FormatDOT, FormatMermaid, FormatJSON)
+}
+
+// Tree prints the graph as tree
+func (g *Graph) Tree(writer io.Writer) error {
+	out := &strings.Builder{}
+	out.WriteString(g.Root + "\n")
+	g.tree(out, g.Dependencies, "", map[string]bool{})
+
+	_, err := io.WriteString(writer, out.String())
+	return err
+}
+
+func (g *Graph) tree(out *strings.Builder, ids []string, indent string, path map[string]bool) {
+	for i, id := range ids {
+		node := g.node(id)
+		if node == nil {
+			continue
+		}
+
+		branch, childIndent := "├── ", indent+"│   "
+		if i == len(ids)-1 {
+			branch, childIndent = "└── ", indent+"    "
+		}
+
+		out.WriteString(indent + branch + node.label())
+		if path[id] {
+			out.WriteString(" (cyclic)\n")
+			continue
+		}
+		out.WriteString("\n")
+
+		path[id] = true
+		g.tree(out, node.Dependencies, childIndent, path)
+		delete(path, id)
+	}
+}
+
+// DOT prints the graph in the graphviz dot format
+func (g *Graph) DOT(writer io.Writer) error {
+	out := &strings.Builder{}
+	out.WriteString("digraph dependencies {\n")
+	out.WriteString("  node [shape=box];\n")
+	out.WriteString(fmt.Sprintf("  root [label=%s];\n", strconv.Quote(g.Root)))
+	for _, node := range g.Nodes {
+		out.WriteString(fmt.Sprintf("  %s [label=%s];\n", strconv.Quote(node.ID), strconv.Quote(strings.Join(node.details(), "\n"))))
+	}
+	for _, id := range g.Dependencies {
+		out.WriteString(fmt.Sprintf("  root -> %s;\n", strconv.Quote(id)))
+	}
+	for _, node := range g.Nodes {
+		for _, id := range node.Dependencies {
+			out.WriteString(fmt.Sprintf("  %s -> %s;\n", strconv.Quote(node.ID), strconv.Quote(id)))
+		}
+	}
+	out.WriteString("}\n")
+
+	_, err := io.WriteString(writer, out.String())
+	return err
+}
+
+// Mermaid prints the graph as mermaid flowchart
+func (g *Graph) Mermaid(writer io.Writer) error {
+	ids := map[string]string{}
+	for i, node := range g.Nodes {
+		ids[node.ID] = "dep" + strconv.Itoa(i)
+	}
+
+	out := &strings.Builder{}
+	out.WriteString("graph TD\n")
+	out.WriteString(fmt.Sprintf("  root[%s]\n", mermaidLabel([]string{g.Root})))
+	for _, node := range g.Nodes {
+		out.WriteString(fmt.Sprintf("  %s[%s]\n", ids[node.ID], mermaidLabel(node.details())))
+	}
+	for _, id := range g.Dependencies {
+		out.WriteString(fmt.Sprintf("  root --> %s\n", ids[id]))
+	}
+	for _, node := range g.Nodes {
+		for _, id := range node.Dependencies {
+			out.WriteString(fmt.Sprintf("  %s --> %s\n", ids[node.ID], ids[id]))
+		}
+	}
+
+	_, err := io.WriteString(writer, out.String())
+	return err
+}
+
+// JSON prints the graph as json
+func (g *Graph) JSON(writer io.Writer) error {
+	out, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(append(out, '\n'))
+	return err
+}
+
+func (g *Graph) node(id string) *Node {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func (n *Node) label() string {
+	return n.Name + " (" + strings.Join(n.details()[1:], "; ") + ")"
+}
+
+func (n *Node) details() []string {
+	details := []string{n.Name, n.Source}
+	if n.Profile != "" {
+		details = append(details, "profile: "+n.Profile)
+	}
+	if n.Namespace != "" {
+		details = append(details, "namespace: "+n.Namespace)
+	}
+	if len(n.Images) > 0 {
+		details = append(details, "images: "+strings.Join(n.Images, ", "))
+	}
+	if len(n.Deployments) > 0 {
+		details = append(details, "deployments: "+strings.Join(n.Deployments, ", "))
+	}
+
+	return details
+}
+
+func mermaidLabel(lines []string) string {
+	return strconv.Quote(strings.ReplaceAll(strings.Join(lines, "<br/>"), `"`, "'"))
+}
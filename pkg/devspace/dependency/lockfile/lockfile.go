@@ -0,0 +1,147 @@
+package lockfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// FileName is the name of the lockfile that is stored next to the devspace.yaml
+const FileName = "devspace.lock"
+
+// Version is the current version of the lockfile format
+const Version = "v1"
+
+// CIEnv is the environment variable that is set by most ci systems. If it is true, the lockfile
+// is not written and dependencies that differ from the lockfile result in an error
+const CIEnv = "CI"
+
+// Lockfile holds the resolved state of all dependencies
+type Lockfile struct {
+	Version      string            `yaml:"version"`
+	Dependencies map[string]*Entry `yaml:"dependencies,omitempty"`
+}
+
+// Entry is the resolved state of a single dependency
+type Entry struct {
+	// Name is the name of the dependency
+	Name string `yaml:"name"`
+
+	// Source is the git url or path of the dependency
+	Source string `yaml:"source,omitempty"`
+
+	// Commit is the resolved git commit if the dependency is a git dependency
+	Commit string `yaml:"commit,omitempty"`
+
+	// ConfigHash is the hash of the dependency devspace.yaml
+	ConfigHash string `yaml:"configHash,omitempty"`
+
+	// Profile are the profiles that were used to load the dependency config
+	Profile string `yaml:"profile,omitempty"`
+}
+
+// New creates a new empty lockfile
+func New() *Lockfile {
+	return &Lockfile{
+		Version:      Version,
+		Dependencies: map[string]*Entry{},
+	}
+}
+
+// IsCI returns true if devspace is running within a ci system
+func IsCI() bool {
+	ci, _ := strconv.ParseBool(os.Getenv(CIEnv))
+	return ci
+}
+
+// Path returns the path of the lockfile for the given devspace.yaml path
+func Path(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), FileName)
+}
+
+// Load loads the lockfile from the given path. If the lockfile does not exist, an empty lockfile
+// is returned
+func Load(path string) (*Lockfile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return New(), nil
+		}
+
+		return nil, err
+	}
+
+	lockfile := &Lockfile{}
+	err = yaml.Unmarshal(data, lockfile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+	if lockfile.Version != Version {
+		return nil, errors.Errorf("unsupported %s version %s, please run 'devspace update dependencies'", path, lockfile.Version)
+	}
+	if lockfile.Dependencies == nil {
+		lockfile.Dependencies = map[string]*Entry{}
+	}
+
+	return lockfile, nil
+}
+
+// Save saves the lockfile to the given path
+func (l *Lockfile) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Equal checks if two entries are the same
+func (e *Entry) Equal(other *Entry) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+
+	return *e == *other
+}
+
+// Diff returns a human readable description of the differences between the locked
+// and the resolved dependencies. If there are no differences an empty string is returned.
+func Diff(locked, resolved *Lockfile) string {
+	ids := map[string]bool{}
+	for id := range locked.Dependencies {
+		ids[id] = true
+	}
+	for id := range resolved.Dependencies {
+		ids[id] = true
+	}
+
+	drift := []string{}
+	for id := range ids {
+		lockedEntry, resolvedEntry := locked.Dependencies[id], resolved.Dependencies[id]
+		switch {
+		case lockedEntry.Equal(resolvedEntry):
+			continue
+		case lockedEntry == nil:
+			drift = append(drift, fmt.Sprintf("dependency %s is not locked", resolvedEntry.Name))
+		case resolvedEntry == nil:
+			drift = append(drift, fmt.Sprintf("dependency %s is locked but not used anymore", lockedEntry.Name))
+		case lockedEntry.Commit != resolvedEntry.Commit:
+			drift = append(drift, fmt.Sprintf("dependency %s resolved to commit %s, but %s is locked", resolvedEntry.Name, resolvedEntry.Commit, lockedEntry.Commit))
+		case lockedEntry.ConfigHash != resolvedEntry.ConfigHash:
+			drift = append(drift, fmt.Sprintf("dependency %s config has changed", resolvedEntry.Name))
+		default:
+			drift = append(drift, fmt.Sprintf("dependency %s differs from the locked state", resolvedEntry.Name))
+		}
+	}
+
+	sort.Strings(drift)
+	return strings.Join(drift, "\n")
+}
@@ -0,0 +1,67 @@
+package lockfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLoadSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lockfile")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := Path(filepath.Join(dir, "devspace.yaml"))
+	assert.Equal(t, path, filepath.Join(dir, FileName))
+
+	// a missing lockfile is empty
+	lockfile, err := Load(path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(lockfile.Dependencies), 0)
+
+	lockfile.Dependencies["id"] = &Entry{
+		Name:       "postgres",
+		Source:     "https://github.com/org/postgres.git",
+		Commit:     "0123456789abcdef",
+		ConfigHash: "hash",
+		Profile:    "dev",
+	}
+	assert.NilError(t, lockfile.Save(path))
+
+	loaded, err := Load(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded, lockfile)
+
+	// unsupported versions
+	assert.NilError(t, ioutil.WriteFile(path, []byte("version: v0\n"), 0644))
+	_, err = Load(path)
+	assert.ErrorContains(t, err, "unsupported")
+}
+
+func TestDiff(t *testing.T) {
+	locked := New()
+	locked.Dependencies["a"] = &Entry{Name: "a", Commit: "1", ConfigHash: "x"}
+	locked.Dependencies["b"] = &Entry{Name: "b", Commit: "1", ConfigHash: "x"}
+	locked.Dependencies["c"] = &Entry{Name: "c", ConfigHash: "x"}
+
+	resolved := New()
+	resolved.Dependencies["a"] = &Entry{Name: "a", Commit: "1", ConfigHash: "x"}
+	assert.Equal(t, Diff(locked, locked), "")
+
+	resolved.Dependencies["b"] = &Entry{Name: "b", Commit: "2", ConfigHash: "x"}
+	resolved.Dependencies["c"] = &Entry{Name: "c", ConfigHash: "y"}
+	resolved.Dependencies["d"] = &Entry{Name: "d"}
+	delete(locked.Dependencies, "a")
+	assert.Equal(t, Diff(locked, resolved), `dependency a is not locked
+dependency b resolved to commit 2, but 1 is locked
+dependency c config has changed
+dependency d is not locked`)
+
+	assert.Equal(t, Diff(resolved, New()), `dependency a is locked but not used anymore
+dependency b is locked but not used anymore
+dependency c is locked but not used anymore
+dependency d is locked but not used anymore`)
+}
@@ -0,0 +1,221 @@
+package dependency
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	"github.com/loft-sh/devspace/pkg/util/shell"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"github.com/pkg/errors"
+)
+
+// outputsMutex guards the outputs of all dependencies, because the outputs of a dependency may be
+// resolved lazily while dependencies that depend on it are executed concurrently
+var outputsMutex sync.Mutex
+
+// resolveOutputs resolves the outputs of the dependency config. This needs to be called after the
+// dependency was deployed, because output commands usually query the deployed resources.
+func (d *Dependency) resolveOutputs() error {
+	outputsMutex.Lock()
+	defer outputsMutex.Unlock()
+
+	return d.resolveOutputsLocked()
+}
+
+// ensureOutputs returns the outputs of the dependency and resolves them if this was not done
+// yet, e.g. because the dependency was only built or rendered. The output commands then query
+// the resources of a previous deployment.
+func (d *Dependency) ensureOutputs() (map[string]string, error) {
+	outputsMutex.Lock()
+	defer outputsMutex.Unlock()
+
+	if d.outputs == nil {
+		err := d.resolveOutputsLocked()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.outputs, nil
+}
+
+func (d *Dependency) resolveOutputsLocked() error {
+	outputs := map[string]string{}
+	if d.localConfig != nil && d.localConfig.Config() != nil {
+		for _, output := range d.localConfig.Config().Outputs {
+			if output.Command == "" {
+				outputs[output.Name] = output.Value
+				continue
+			}
+
+			value, err := executeOutputCommand(output, d.localPath)
+			if err != nil {
+				return errors.Wrapf(err, "resolve output %s of dependency %s", output.Name, d.Name())
+			}
+
+			outputs[output.Name] = value
+		}
+	}
+
+	d.outputs = outputs
+	return nil
+}
+
+func executeOutputCommand(output *latest.OutputConfig, dir string) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	var err error
+	if output.Args == nil {
+		err = shell.ExecuteShellCommand(output.Command, nil, dir, stdout, stderr, nil)
+	} else {
+		err = command.ExecuteCommandWithEnv(output.Command, output.Args, dir, stdout, stderr, nil)
+	}
+	if err != nil {
+		if stderr.Len() > 0 {
+			return "", errors.Errorf("%v\n\nstderr: \n%s", err, stderr.String())
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// FillOutputs replaces all ${dep.NAME.outputs.OUTPUT} variables within the config with the resolved
+// outputs of the given dependencies. The strings of the config are replaced in place and an error is
+// returned for every output that cannot be resolved, e.g. because the dependency was skipped.
+func FillOutputs(config *latest.Config, dependencies []types.Dependency) error {
+	if config == nil {
+		return nil
+	}
+
+	return fillStrings(reflect.ValueOf(config), func(value string) (string, error) {
+		if !varspkg.VarMatchRegex.MatchString(value) {
+			return value, nil
+		}
+
+		filled, err := varspkg.ParseString(value, func(name string) (interface{}, error) {
+			dependencyName, outputName, ok := variable.ParseDependencyOutput(name)
+			if !ok {
+				return "${" + name + "}", nil
+			}
+
+			value, err := dependencyOutput(dependencies, dependencyName, outputName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "fill variable ${%s}", name)
+			}
+
+			return value, nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%v", filled), nil
+	})
+}
+
+// fillStrings calls fill for every string that is reachable from the given value and replaces
+// the string with the returned one
+func fillStrings(value reflect.Value, fill func(value string) (string, error)) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+
+		return fillStrings(value.Elem(), fill)
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		elem := value.Elem()
+		if elem.Kind() != reflect.String {
+			return fillStrings(elem, fill)
+		}
+
+		filled, err := fill(elem.String())
+		if err != nil {
+			return err
+		} else if filled != elem.String() {
+			value.Set(reflect.ValueOf(filled))
+		}
+	case reflect.String:
+		filled, err := fill(value.String())
+		if err != nil {
+			return err
+		} else if filled != value.String() {
+			value.SetString(filled)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if !value.Field(i).CanSet() {
+				continue
+			}
+
+			err := fillStrings(value.Field(i), fill)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			err := fillStrings(value.Index(i), fill)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		// map values are not addressable, so we fill a copy and set it again
+		for _, key := range value.MapKeys() {
+			elem := reflect.New(value.Type().Elem()).Elem()
+			elem.Set(value.MapIndex(key))
+			err := fillStrings(elem, fill)
+			if err != nil {
+				return err
+			}
+
+			value.SetMapIndex(key, elem)
+		}
+	}
+
+	return nil
+}
+
+func dependencyOutput(dependencies []types.Dependency, dependencyName, outputName string) (string, error) {
+	for _, dependency := range dependencies {
+		if dependency.Name() != dependencyName {
+			continue
+		}
+
+		outputs := dependency.Outputs()
+		if d, ok := dependency.(*Dependency); ok {
+			var err error
+			outputs, err = d.ensureOutputs()
+			if err != nil {
+				return "", err
+			}
+		}
+		if outputs == nil {
+			return "", errors.Errorf("dependency %s was not deployed", dependencyName)
+		}
+
+		value, ok := outputs[outputName]
+		if !ok {
+			return "", errors.Errorf("dependency %s has no output %s", dependencyName, outputName)
+		}
+
+		return value, nil
+	}
+
+	return "", errors.Errorf("dependency %s was skipped or does not exist", dependencyName)
+}
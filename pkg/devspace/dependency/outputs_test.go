@@ -0,0 +1,100 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"gotest.tools/assert"
+)
+
+func TestResolveOutputs(t *testing.T) {
+	dependency := &Dependency{
+		localPath:        t.TempDir(),
+		dependencyConfig: &latest.DependencyConfig{Name: "postgres"},
+		localConfig: config.NewConfig(nil, &latest.Config{
+			Outputs: []*latest.OutputConfig{
+				{Name: "host", Value: "postgres.default"},
+				{Name: "port", Command: "echo 5432"},
+				{Name: "user", Command: "echo", Args: []string{"admin"}},
+			},
+		}, nil, nil, ""),
+	}
+	assert.Assert(t, dependency.Outputs() == nil)
+
+	err := dependency.resolveOutputs()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dependency.Outputs(), map[string]string{
+		"host": "postgres.default",
+		"port": "5432",
+		"user": "admin",
+	})
+
+	dependency.localConfig.Config().Outputs = []*latest.OutputConfig{{Name: "host", Command: "exit 1"}}
+	err = dependency.resolveOutputs()
+	assert.ErrorContains(t, err, "resolve output host of dependency postgres")
+}
+
+func TestFillOutputs(t *testing.T) {
+	dependencies := []types.Dependency{
+		&Dependency{
+			dependencyConfig: &latest.DependencyConfig{Name: "postgres"},
+			outputs:          map[string]string{"host": "postgres.default", "port": "5432"},
+		},
+		&Dependency{
+			dependencyConfig: &latest.DependencyConfig{Name: "redis"},
+			localPath:        t.TempDir(),
+			localConfig: config.NewConfig(nil, &latest.Config{
+				Outputs: []*latest.OutputConfig{{Name: "host", Command: "echo redis.default"}},
+			}, nil, nil, ""),
+		},
+	}
+
+	deployment := &latest.DeploymentConfig{
+		Name: "api",
+		Helm: &latest.HelmConfig{
+			Values: map[interface{}]interface{}{
+				"database": "${dep.postgres.outputs.host}:${dep.postgres.outputs.port}",
+				"other":    "${OTHER}",
+				"replicas": 2,
+			},
+		},
+	}
+	config := &latest.Config{
+		Version: latest.Version,
+		Deployments: []*latest.DeploymentConfig{
+			deployment,
+			{
+				Name:    "manifests",
+				Kubectl: &latest.KubectlConfig{Manifests: []string{"${dep.postgres.outputs.host}.yaml"}},
+			},
+		},
+	}
+	err := FillOutputs(config, dependencies)
+	assert.NilError(t, err)
+	assert.Assert(t, config.Deployments[0] == deployment, "config is not filled in place")
+	assert.DeepEqual(t, deployment.Helm.Values, map[interface{}]interface{}{
+		"database": "postgres.default:5432",
+		"other":    "${OTHER}",
+		"replicas": 2,
+	})
+	assert.DeepEqual(t, config.Deployments[1].Kubectl.Manifests, []string{"postgres.default.yaml"})
+
+	// outputs of dependencies that were not deployed are resolved when they are referenced
+	deployment.Helm.Values["cache"] = "${dep.redis.outputs.host}"
+	err = FillOutputs(config, dependencies)
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Helm.Values["cache"], "redis.default")
+
+	deployment.Helm.Values["cache"] = "${dep.postgres.outputs.user}"
+	err = FillOutputs(config, dependencies)
+	assert.Error(t, err, "fill variable ${dep.postgres.outputs.user}: dependency postgres has no output user")
+
+	deployment.Helm.Values["cache"] = "${dep.mysql.outputs.host}"
+	err = FillOutputs(config, dependencies)
+	assert.Error(t, err, "fill variable ${dep.mysql.outputs.host}: dependency mysql was skipped or does not exist")
+
+	err = FillOutputs(config, nil)
+	assert.ErrorContains(t, err, "was skipped or does not exist")
+}
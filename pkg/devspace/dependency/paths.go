@@ -0,0 +1,101 @@
+package dependency
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+)
+
+// workingDirectoryLock guards the process working directory while dependencies are executed
+// concurrently. Dependencies that do not need to switch the working directory hold a read
+// lock, while dependencies that have to switch it hold the write lock.
+var workingDirectoryLock sync.RWMutex
+
+// makePathsAbsolute rewrites the relative paths of images, deployments and hooks in the dependency
+// config so that the dependency can be built and deployed without switching the working directory.
+// Paths that are only known to local commands are handled by needsWorkingDirectory instead.
+func makePathsAbsolute(config *latest.Config, dir string) {
+	for _, image := range config.Images {
+		if image == nil {
+			continue
+		}
+
+		dockerfile, context := helper.GetDockerfileAndContext(image)
+		image.Dockerfile = absolutePath(dir, dockerfile)
+		image.Context = absolutePath(dir, context)
+		if image.Build != nil && image.Build.Custom != nil {
+			for i, pattern := range image.Build.Custom.OnChange {
+				image.Build.Custom.OnChange[i] = absolutePath(dir, pattern)
+			}
+		}
+	}
+
+	for _, deployment := range config.Deployments {
+		if deployment == nil {
+			continue
+		}
+
+		if deployment.Helm != nil {
+			if deployment.Helm.Chart != nil && deployment.Helm.Chart.Name != "" && deployment.Helm.Chart.RepoURL == "" {
+				chartPath := absolutePath(dir, deployment.Helm.Chart.Name)
+				if _, err := os.Stat(chartPath); err == nil {
+					deployment.Helm.Chart.Name = chartPath
+				}
+			}
+			for i, valuesFile := range deployment.Helm.ValuesFiles {
+				deployment.Helm.ValuesFiles[i] = absolutePath(dir, valuesFile)
+			}
+		}
+		if deployment.Kubectl != nil {
+			for i, manifest := range deployment.Kubectl.Manifests {
+				if strings.Contains(manifest, "://") {
+					continue
+				}
+
+				deployment.Kubectl.Manifests[i] = absolutePath(dir, manifest)
+			}
+		}
+	}
+
+	for _, hook := range config.Hooks {
+		if hook == nil {
+			continue
+		}
+
+		if hook.Upload != nil {
+			hook.Upload.LocalPath = absolutePath(dir, hook.Upload.LocalPath)
+		}
+		if hook.Download != nil {
+			hook.Download.LocalPath = absolutePath(dir, hook.Download.LocalPath)
+		}
+	}
+}
+
+// needsWorkingDirectory returns true if the dependency executes local commands, such as custom
+// builds or local hooks, which expect to run within the dependency directory
+func needsWorkingDirectory(config *latest.Config) bool {
+	for _, image := range config.Images {
+		if image != nil && image.Build != nil && image.Build.Custom != nil && !image.Build.Disabled {
+			return true
+		}
+	}
+	for _, hook := range config.Hooks {
+		if hook != nil && hook.Command != "" && hook.Container == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func absolutePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
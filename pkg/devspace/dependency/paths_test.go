@@ -0,0 +1,68 @@
+package dependency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestMakePathsAbsolute(t *testing.T) {
+	dir := t.TempDir()
+	dependencyConfig := &latest.Config{
+		Images: map[string]*latest.ImageConfig{
+			"api": {Image: "api", Dockerfile: "build/Dockerfile"},
+		},
+		Deployments: []*latest.DeploymentConfig{
+			{Name: "api", Kubectl: &latest.KubectlConfig{Manifests: []string{"kube/", "https://example.com/manifest.yaml"}}},
+		},
+		Hooks: []*latest.HookConfig{
+			{Upload: &latest.HookSyncConfig{LocalPath: "./assets"}, Container: &latest.HookContainer{ImageSelector: "api"}},
+			{Download: &latest.HookSyncConfig{}, Container: &latest.HookContainer{ImageSelector: "api"}},
+		},
+	}
+
+	makePathsAbsolute(dependencyConfig, dir)
+	assert.Equal(t, dependencyConfig.Images["api"].Dockerfile, filepath.Join(dir, "build", "Dockerfile"))
+	assert.Equal(t, dependencyConfig.Images["api"].Context, dir)
+	assert.DeepEqual(t, dependencyConfig.Deployments[0].Kubectl.Manifests, []string{filepath.Join(dir, "kube"), "https://example.com/manifest.yaml"})
+	assert.Equal(t, dependencyConfig.Hooks[0].Upload.LocalPath, filepath.Join(dir, "assets"))
+	assert.Equal(t, dependencyConfig.Hooks[1].Download.LocalPath, dir)
+
+	// container hooks don't need the dependency directory, local hooks do
+	assert.Assert(t, !needsWorkingDirectory(dependencyConfig))
+	dependencyConfig.Hooks = append(dependencyConfig.Hooks, &latest.HookConfig{Command: "./scripts/migrate.sh"})
+	assert.Assert(t, needsWorkingDirectory(dependencyConfig))
+}
+
+func TestLockWorkingDirectory(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	assert.NilError(t, err)
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+
+	dependency := &Dependency{
+		localPath:   dir,
+		localConfig: config.NewConfig(nil, &latest.Config{}, nil, nil, ""),
+	}
+	release, err := dependency.lockWorkingDirectory()
+	assert.NilError(t, err)
+	current, _ := os.Getwd()
+	release()
+	assert.Equal(t, current, wd)
+
+	dependency.localConfig = config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{{Command: "./scripts/migrate.sh"}},
+	}, nil, nil, "")
+	release, err = dependency.lockWorkingDirectory()
+	assert.NilError(t, err)
+	current, _ = os.Getwd()
+	release()
+	assert.Equal(t, current, dir)
+
+	current, _ = os.Getwd()
+	assert.Equal(t, current, wd)
+}
@@ -15,11 +15,14 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
 	"github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
 	"github.com/loft-sh/devspace/pkg/util/log"
 
@@ -43,6 +46,10 @@ type resolver struct {
 
 	ConfigOptions *loader.ConfigOptions
 
+	LockfilePath     string
+	Lockfile         *lockfile.Lockfile
+	ResolvedLockfile *lockfile.Lockfile
+
 	kubeLoader     kubeconfig.Loader
 	client         kubectl.Client
 	generatedSaver generated.ConfigLoader
@@ -81,6 +88,8 @@ func NewResolver(baseConfig config.Config, client kubectl.Client, configOptions
 
 		ConfigOptions: configOptions,
 
+		LockfilePath: lockfile.Path(baseConfig.Path()),
+
 		// We only need that for saving
 		kubeLoader:     kubeLoader,
 		client:         client,
@@ -96,6 +105,13 @@ func (r *resolver) Resolve(update bool) ([]*Dependency, error) {
 		return nil, errors.Wrap(err, "get current working directory")
 	}
 
+	// Load the lockfile
+	r.Lockfile, err = lockfile.Load(r.LockfilePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load lockfile")
+	}
+	r.ResolvedLockfile = lockfile.New()
+
 	err = r.resolveRecursive(currentWorkingDirectory, r.DependencyGraph.Root.ID, nil, r.BaseConfig.Dependencies, update)
 	if err != nil {
 		if _, ok := err.(*cyclicError); ok {
@@ -105,6 +121,12 @@ func (r *resolver) Resolve(update bool) ([]*Dependency, error) {
 		return nil, err
 	}
 
+	// Verify or update the lockfile
+	err = r.updateLockfile(update)
+	if err != nil {
+		return nil, err
+	}
+
 	// Save generated
 	err = r.generatedSaver.Save(r.BaseCache)
 	if err != nil {
@@ -114,6 +136,38 @@ func (r *resolver) Resolve(update bool) ([]*Dependency, error) {
 	return r.buildDependencyQueue()
 }
 
+func (r *resolver) updateLockfile(update bool) error {
+	drift := lockfile.Diff(r.Lockfile, r.ResolvedLockfile)
+	if drift == "" {
+		return nil
+	}
+
+	_, err := os.Stat(r.LockfilePath)
+	lockfileExists := err == nil
+
+	// in ci we only verify an existing lockfile
+	if lockfile.IsCI() {
+		if !lockfileExists {
+			r.log.Warnf("No %s found, the dependencies are not pinned. Please run 'devspace update dependencies' and commit the created %s", lockfile.FileName, lockfile.FileName)
+			return nil
+		}
+
+		return errors.Errorf("dependencies differ from %s:\n%s\nPlease run 'devspace update dependencies' and commit the updated %s", lockfile.FileName, drift, lockfile.FileName)
+	}
+
+	if lockfileExists && !update {
+		r.log.Warnf("Dependencies differ from %s, updating it:\n%s", lockfile.FileName, drift)
+	}
+
+	err = r.ResolvedLockfile.Save(r.LockfilePath)
+	if err != nil {
+		return errors.Wrap(err, "save lockfile")
+	}
+
+	r.Lockfile = r.ResolvedLockfile
+	return nil
+}
+
 func (r *resolver) buildDependencyQueue() ([]*Dependency, error) {
 	retDependencies := make([]*Dependency, 0, len(r.DependencyGraph.Nodes)-1)
 
@@ -205,7 +259,16 @@ func (r *resolver) resolveDependency(basePath string, dependency *latest.Depende
 		return nil, err
 	}
 
-	localPath, err := util.DownloadDependency(ID, basePath, dependency.Source, update, r.log)
+	// use the locked commit if the dependency is not updated
+	source := dependency.Source
+	locked := r.Lockfile.Dependencies[ID]
+	if source.Git != "" && source.Revision == "" && !update && locked != nil && locked.Commit != "" {
+		pinned := *source
+		pinned.Revision = locked.Commit
+		source = &pinned
+	}
+
+	localPath, err := util.DownloadDependency(ID, basePath, source, update, r.log)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +308,7 @@ func (r *resolver) resolveDependency(basePath string, dependency *latest.Depende
 
 	if dependency.OverwriteVars {
 		for k, v := range r.BaseVars {
-			cloned.Vars = append(cloned.Vars, strings.TrimSpace(k)+"="+strings.TrimSpace(fmt.Sprintf("%v", v)))
+			cloned.Vars = append(cloned.Vars, strings.TrimSpace(k)+"="+strings.TrimSpace(variable.EncodeValue(v)))
 		}
 	}
 	for _, v := range dependency.Vars {
@@ -268,6 +331,19 @@ func (r *resolver) resolveDependency(basePath string, dependency *latest.Depende
 
 	dConfig := dConfigWrapper.Config()
 
+	// record the resolved dependency in the lockfile
+	err = r.lockDependency(ID, dependency, configPath, cloned.Profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	// make paths absolute, so the dependency can be executed concurrently
+	absoluteLocalPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return nil, err
+	}
+	makePathsAbsolute(dConfig, absoluteLocalPath)
+
 	// set parsed variables in parent config
 	if dependency.OverwriteVars {
 		for k, v := range dConfigWrapper.Variables() {
@@ -330,6 +406,29 @@ func (r *resolver) resolveDependency(basePath string, dependency *latest.Depende
 	}, nil
 }
 
+func (r *resolver) lockDependency(ID string, dependency *latest.DependencyConfig, configPath string, profiles []string) error {
+	configHash, err := hash.File(configPath)
+	if err != nil {
+		return errors.Wrapf(err, "hash config of dependency %s", dependency.Name)
+	}
+
+	entry := &lockfile.Entry{
+		Name:       dependency.Name,
+		Source:     util.GetSource(dependency.Source),
+		ConfigHash: configHash,
+		Profile:    strings.Join(profiles, ","),
+	}
+	if dependency.Source.Git != "" {
+		entry.Commit, err = util.GetDependencyCommit(ID)
+		if err != nil {
+			return errors.Wrapf(err, "get commit of dependency %s", dependency.Name)
+		}
+	}
+
+	r.ResolvedLockfile.Dependencies[ID] = entry
+	return nil
+}
+
 func executeInDirectory(dir string, fn func() error) error {
 	oldWorkingDirectory, err := os.Getwd()
 	if err != nil {
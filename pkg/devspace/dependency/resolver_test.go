@@ -1,14 +1,17 @@
 package dependency
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/util/hash"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/lockfile"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/util"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
@@ -19,6 +22,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	"github.com/loft-sh/devspace/pkg/util/log"
 
+	"github.com/sirupsen/logrus"
 	"gotest.tools/assert"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -289,3 +293,47 @@ func TestGetDependencyID(t *testing.T) {
 		assert.Equal(t, testCase.expectedID, id, "Dependency has wrong id in testCase %s", testCase.name)
 	}
 }
+
+func TestUpdateLockfile(t *testing.T) {
+	lockfilePath := filepath.Join(t.TempDir(), "devspace.lock")
+	output := &bytes.Buffer{}
+	newResolver := func() *resolver {
+		resolved := lockfile.New()
+		resolved.Dependencies["dep"] = &lockfile.Entry{Name: "dep", Source: "https://github.com/test/test.git", Commit: "abc"}
+		return &resolver{
+			LockfilePath:     lockfilePath,
+			Lockfile:         lockfile.New(),
+			ResolvedLockfile: resolved,
+			log:              log.NewStreamLogger(output, logrus.InfoLevel),
+		}
+	}
+
+	// in ci a missing lockfile is not created, but a warning is printed
+	defer os.Unsetenv(lockfile.CIEnv)
+	os.Setenv(lockfile.CIEnv, "true")
+	assert.NilError(t, newResolver().updateLockfile(false))
+	assert.Assert(t, strings.Contains(output.String(), "No devspace.lock found"), output.String())
+	_, err := os.Stat(lockfilePath)
+	assert.Assert(t, os.IsNotExist(err))
+
+	// outside of ci the lockfile is created silently
+	os.Unsetenv(lockfile.CIEnv)
+	output.Reset()
+	assert.NilError(t, newResolver().updateLockfile(false))
+	assert.Equal(t, output.String(), "")
+
+	// drift is written to the lockfile with a warning
+	r := newResolver()
+	r.Lockfile, err = lockfile.Load(lockfilePath)
+	assert.NilError(t, err)
+	r.ResolvedLockfile.Dependencies["dep"].Commit = "def"
+	assert.NilError(t, r.updateLockfile(false))
+	assert.Assert(t, strings.Contains(output.String(), "Dependencies differ from devspace.lock"), output.String())
+
+	// in ci drift is an error
+	os.Setenv(lockfile.CIEnv, "true")
+	r = newResolver()
+	r.Lockfile, err = lockfile.Load(lockfilePath)
+	assert.NilError(t, err)
+	assert.ErrorContains(t, r.updateLockfile(false), "dependencies differ from devspace.lock")
+}
@@ -0,0 +1,99 @@
+package dependency
+
+// schedule executes fn for every dependency in the given queue. A dependency is only
+// executed after all of its children have finished (or in reverse mode after all
+// of its parents have finished), which means independent dependencies are executed
+// concurrently. At most concurrency dependencies are executed at the same time. If
+// fn returns an error for a dependency, no new dependencies are started and the first
+// error is returned after all running dependencies have finished.
+func schedule(queue []*Dependency, reverse bool, concurrency int, fn func(dependency *Dependency) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	// the queue is already in a valid execution order, so we start
+	// ready dependencies in queue order to stay deterministic
+	ordered := make([]*Dependency, 0, len(queue))
+	if reverse {
+		for i := len(queue) - 1; i >= 0; i-- {
+			ordered = append(ordered, queue[i])
+		}
+	} else {
+		ordered = append(ordered, queue...)
+	}
+
+	// build the edges between the dependencies in the queue
+	inQueue := map[string]bool{}
+	for _, dependency := range ordered {
+		inQueue[dependency.ID()] = true
+	}
+
+	blockedBy := map[string]int{}
+	unblocks := map[string][]string{}
+	for _, dependency := range ordered {
+		for _, child := range dependency.Children() {
+			if !inQueue[child.ID()] || child.ID() == dependency.ID() {
+				continue
+			}
+
+			if reverse {
+				blockedBy[child.ID()]++
+				unblocks[dependency.ID()] = append(unblocks[dependency.ID()], child.ID())
+			} else {
+				blockedBy[dependency.ID()]++
+				unblocks[child.ID()] = append(unblocks[child.ID()], dependency.ID())
+			}
+		}
+	}
+
+	type result struct {
+		id  string
+		err error
+	}
+
+	var (
+		results  = make(chan result)
+		started  = map[string]bool{}
+		running  = 0
+		finished = 0
+		firstErr error
+	)
+	for finished < len(ordered) {
+		// start all ready dependencies
+		for _, dependency := range ordered {
+			if firstErr != nil || running >= concurrency {
+				break
+			} else if started[dependency.ID()] || blockedBy[dependency.ID()] > 0 {
+				continue
+			}
+
+			started[dependency.ID()] = true
+			running++
+			go func(dependency *Dependency) {
+				results <- result{id: dependency.ID(), err: fn(dependency)}
+			}(dependency)
+		}
+
+		// nothing is running anymore, which only happens after an error
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		finished++
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+
+			continue
+		}
+
+		for _, id := range unblocks[r.id] {
+			blockedBy[id]--
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,107 @@
+package dependency
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"gotest.tools/assert"
+)
+
+func newTestDependency(id string, children ...*Dependency) *Dependency {
+	dependency := &Dependency{id: id, children: []types.Dependency{}}
+	for _, child := range children {
+		dependency.children = append(dependency.children, child)
+	}
+	return dependency
+}
+
+func TestSchedule(t *testing.T) {
+	var (
+		leaf1  = newTestDependency("leaf1")
+		leaf2  = newTestDependency("leaf2")
+		leaf3  = newTestDependency("leaf3")
+		middle = newTestDependency("middle", leaf1, leaf2)
+		top    = newTestDependency("top", middle, leaf3)
+		queue  = []*Dependency{leaf1, leaf2, middle, leaf3, top}
+	)
+
+	for _, reverse := range []bool{false, true} {
+		for _, concurrency := range []int{1, 2, 10} {
+			var (
+				mutex      sync.Mutex
+				running    = 0
+				maxRunning = 0
+				finished   = map[string]bool{}
+			)
+
+			err := schedule(queue, reverse, concurrency, func(dependency *Dependency) error {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+
+				// check that all edges are respected
+				for _, child := range dependency.Children() {
+					assert.Equal(t, finished[child.ID()], !reverse, "reverse %v: %s before %s", reverse, dependency.ID(), child.ID())
+				}
+				if reverse {
+					for _, other := range queue {
+						for _, child := range other.Children() {
+							if child.ID() == dependency.ID() {
+								assert.Assert(t, finished[other.ID()], "%s before %s", dependency.ID(), other.ID())
+							}
+						}
+					}
+				}
+				mutex.Unlock()
+
+				time.Sleep(time.Millisecond * 10)
+
+				mutex.Lock()
+				running--
+				finished[dependency.ID()] = true
+				mutex.Unlock()
+				return nil
+			})
+			assert.NilError(t, err)
+			assert.Equal(t, len(finished), len(queue))
+			assert.Assert(t, maxRunning <= concurrency, "concurrency %d exceeded: %d", concurrency, maxRunning)
+			if concurrency > 1 {
+				assert.Assert(t, maxRunning > 1, "dependencies were not executed concurrently")
+			}
+		}
+	}
+}
+
+func TestScheduleError(t *testing.T) {
+	var (
+		leaf1 = newTestDependency("leaf1")
+		leaf2 = newTestDependency("leaf2")
+		top   = newTestDependency("top", leaf1, leaf2)
+		queue = []*Dependency{leaf1, leaf2, top}
+
+		mutex    sync.Mutex
+		executed = []string{}
+	)
+
+	err := schedule(queue, false, 2, func(dependency *Dependency) error {
+		mutex.Lock()
+		executed = append(executed, dependency.ID())
+		mutex.Unlock()
+
+		if dependency.ID() == "leaf1" {
+			return fmt.Errorf("leaf1 failed")
+		}
+
+		return nil
+	})
+	assert.Error(t, err, "leaf1 failed")
+	assert.Equal(t, len(executed), 2)
+	for _, id := range executed {
+		assert.Assert(t, id != "top", "top should not be executed after an error")
+	}
+}
@@ -29,6 +29,10 @@ type Dependency interface {
 	// BuiltImages returns the images that were built by this dependency
 	BuiltImages() map[string]string
 
+	// Outputs returns the resolved outputs of this dependency. Outputs are only available after
+	// the dependency was deployed
+	Outputs() map[string]string
+
 	// DependencyConfig is the config this dependency was created from
 	DependencyConfig() *latest.DependencyConfig
 
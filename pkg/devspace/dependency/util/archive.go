@@ -0,0 +1,197 @@
+package util
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// httpClient is used to download tarballs and oci artifacts
+var httpClient = &http.Client{
+	Timeout: 10 * time.Minute,
+}
+
+// downloadTarball downloads the tarball from the given url, verifies its checksum and extracts
+// it into the local path
+func downloadTarball(url, checksum, localPath string) error {
+	if !strings.HasPrefix(url, "https://") {
+		return errors.Errorf("tarball url %s has to use https", url)
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return errors.Wrapf(err, "request %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request %s: unexpected status code %d", url, resp.StatusCode)
+	}
+
+	return extractVerified(resp.Body, checksum, localPath)
+}
+
+// extractVerified buffers the archive in a temporary file, verifies the checksum if given and
+// replaces the local path with the extracted archive
+func extractVerified(reader io.Reader, checksum, localPath string) error {
+	tempFile, err := ioutil.TempFile("", "devspace-dependency-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hash), reader)
+	if err != nil {
+		return errors.Wrap(err, "download archive")
+	}
+
+	err = verifyChecksum(hex.EncodeToString(hash.Sum(nil)), checksum)
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	return replaceDirectory(localPath, func(dir string) error {
+		return extractArchive(tempFile, dir)
+	})
+}
+
+// verifyChecksum checks if the sha256 hex digest matches the expected checksum. The
+// checksum can be prefixed with sha256:
+func verifyChecksum(digest, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	expected := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(checksum), "sha256:"))
+	if expected != digest {
+		return errors.Errorf("checksum mismatch: expected sha256:%s, got sha256:%s", expected, digest)
+	}
+
+	return nil
+}
+
+// replaceDirectory fills a temporary directory with the given function and replaces the
+// target directory with it afterwards, so that a failed download does not leave a partial
+// dependency behind
+func replaceDirectory(target string, fill func(dir string) error) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := ioutil.TempDir(filepath.Dir(target), filepath.Base(target)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = fill(tempDir)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(target)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(stripSingleDirectory(tempDir), target)
+}
+
+// stripSingleDirectory returns the only directory within dir if there is nothing else in it,
+// because most tarballs (e.g. github archives) wrap their contents within a single folder
+func stripSingleDirectory(dir string) string {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil || len(files) != 1 || !files[0].IsDir() {
+		return dir
+	}
+
+	return filepath.Join(dir, files[0].Name())
+}
+
+// extractArchive extracts a .tar or .tar.gz archive into the given directory
+func extractArchive(reader io.Reader, dir string) error {
+	bufferedReader := bufio.NewReader(reader)
+	magic, err := bufferedReader.Peek(2)
+	if err != nil {
+		return errors.Wrap(err, "read archive")
+	}
+
+	var tarReader *tar.Reader
+	if magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			return errors.Wrap(err, "read gzip archive")
+		}
+		defer gzipReader.Close()
+
+		tarReader = tar.NewReader(gzipReader)
+	} else {
+		tarReader = tar.NewReader(bufferedReader)
+	}
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "read tar archive")
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+			return errors.Errorf("archive entry %s is outside of the target directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(target), 0755)
+			if err != nil {
+				return err
+			}
+
+			err = writeFile(target, tarReader, os.FileMode(header.Mode)&0777)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(path string, reader io.Reader, mode os.FileMode) error {
+	if mode == 0 {
+		mode = 0644
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, reader)
+	return err
+}
@@ -0,0 +1,371 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/docker"
+	"github.com/pkg/errors"
+)
+
+const (
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	ociIndexMediaType       = "application/vnd.oci.image.index.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerListMediaType     = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// ociTitleAnnotation is the annotation that tools like oras use to store the file name of a layer
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+var challengeParamRegEx = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+type ociReference struct {
+	Registry   string
+	Repository string
+	Reference  string
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *ociPlatform      `json:"platform,omitempty"`
+}
+
+type ociPlatform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+// parseOCIReference parses a reference such as ghcr.io/org/repo:tag or org/repo@sha256:...
+func parseOCIReference(ref string) (*ociReference, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "oci://")
+	if ref == "" {
+		return nil, errors.New("empty oci reference")
+	}
+
+	parsed := &ociReference{Registry: "registry-1.docker.io", Reference: "latest"}
+	if i := strings.Index(ref, "@"); i != -1 {
+		parsed.Reference = ref[i+1:]
+		ref = ref[:i]
+	} else if i := strings.LastIndex(ref, ":"); i != -1 && !strings.Contains(ref[i:], "/") {
+		parsed.Reference = ref[i+1:]
+		ref = ref[:i]
+	}
+
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		parsed.Registry = parts[0]
+		ref = parts[1]
+	}
+	if parsed.Registry == "docker.io" || parsed.Registry == "index.docker.io" {
+		parsed.Registry = "registry-1.docker.io"
+	}
+	if parsed.Registry == "registry-1.docker.io" && !strings.Contains(ref, "/") {
+		ref = "library/" + ref
+	}
+
+	parsed.Repository = ref
+	return parsed, nil
+}
+
+// ociClient pulls artifacts from an oci registry
+type ociClient struct {
+	reference *ociReference
+	client    *http.Client
+	token     string
+}
+
+// pullOCIArtifact downloads the layers of the oci artifact into the local path. Tar layers
+// are extracted and other layers are written as file with their title annotation as name.
+func pullOCIArtifact(ref, checksum, localPath string) error {
+	reference, err := parseOCIReference(ref)
+	if err != nil {
+		return err
+	}
+
+	c := &ociClient{reference: reference, client: httpClient}
+	manifest, digest, err := c.manifest(reference.Reference)
+	if err != nil {
+		return errors.Wrapf(err, "get manifest of %s", ref)
+	}
+
+	// verify the manifest digest
+	if strings.HasPrefix(reference.Reference, "sha256:") {
+		err = verifyChecksum(digest, reference.Reference)
+		if err != nil {
+			return errors.Wrapf(err, "verify %s", ref)
+		}
+	}
+	err = verifyChecksum(digest, checksum)
+	if err != nil {
+		return errors.Wrapf(err, "verify %s", ref)
+	}
+
+	// use the manifest of the current platform of an index
+	if len(manifest.Manifests) > 0 {
+		descriptor, err := selectManifest(manifest.Manifests)
+		if err != nil {
+			return errors.Wrapf(err, "select manifest of %s", ref)
+		}
+
+		manifest, digest, err = c.manifest(descriptor.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "get manifest of %s", ref)
+		}
+
+		err = verifyChecksum(digest, descriptor.Digest)
+		if err != nil {
+			return errors.Wrapf(err, "verify manifest %s of %s", descriptor.Digest, ref)
+		}
+	}
+	if len(manifest.Layers) == 0 {
+		return errors.Errorf("oci artifact %s has no layers", ref)
+	}
+
+	return replaceDirectory(localPath, func(dir string) error {
+		for _, layer := range manifest.Layers {
+			err := c.pullLayer(layer, dir)
+			if err != nil {
+				return errors.Wrapf(err, "pull layer %s of %s", layer.Digest, ref)
+			}
+		}
+
+		return nil
+	})
+}
+
+// selectManifest returns the manifest of the index that matches the current platform. Manifests
+// without a platform are used if no manifest matches and a single manifest is always used.
+func selectManifest(manifests []ociDescriptor) (ociDescriptor, error) {
+	var withoutPlatform *ociDescriptor
+	for i, manifest := range manifests {
+		if manifest.Platform == nil {
+			if withoutPlatform == nil {
+				withoutPlatform = &manifests[i]
+			}
+		} else if manifest.Platform.OS == runtime.GOOS && manifest.Platform.Architecture == runtime.GOARCH {
+			return manifest, nil
+		}
+	}
+
+	if withoutPlatform != nil {
+		return *withoutPlatform, nil
+	} else if len(manifests) == 1 {
+		return manifests[0], nil
+	}
+
+	return ociDescriptor{}, errors.Errorf("no manifest found for platform %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+func (c *ociClient) pullLayer(layer ociDescriptor, dir string) error {
+	resp, err := c.get("/blobs/"+layer.Digest, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tempFile, err := ioutil.TempFile("", "devspace-layer-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	// verify the layer digest
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hash), resp.Body)
+	if err != nil {
+		return err
+	}
+	err = verifyChecksum(hex.EncodeToString(hash.Sum(nil)), layer.Digest)
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	title := layer.Annotations[ociTitleAnnotation]
+	if strings.Contains(layer.MediaType, "tar") || title == "" {
+		return extractArchive(tempFile, dir)
+	}
+
+	target := filepath.Join(dir, filepath.Base(filepath.FromSlash(title)))
+	return writeFile(target, tempFile, 0644)
+}
+
+func (c *ociClient) manifest(reference string) (*ociManifest, string, error) {
+	accept := strings.Join([]string{ociManifestMediaType, ociIndexMediaType, dockerManifestMediaType, dockerListMediaType}, ",")
+	resp, err := c.get("/manifests/"+reference, accept)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	out, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	manifest := &ociManifest{}
+	err = json.Unmarshal(out, manifest)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "parse manifest")
+	}
+
+	digest := sha256.Sum256(out)
+	return manifest, hex.EncodeToString(digest[:]), nil
+}
+
+// get requests the given path of the repository and authenticates if the registry asks for it
+func (c *ociClient) get(path, accept string) (*http.Response, error) {
+	requestURL := fmt.Sprintf("%s://%s/v2/%s%s", c.scheme(), c.reference.Registry, c.reference.Repository, path)
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", c.token)
+		}
+
+		return c.client.Do(req)
+	}
+
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		err = c.authenticate(challenge)
+		if err != nil {
+			return nil, errors.Wrap(err, "authenticate")
+		}
+
+		resp, err = do()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("request %s: unexpected status code %d", requestURL, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// authenticate answers the registry challenge with the credentials from the docker config
+func (c *ociClient) authenticate(challenge string) error {
+	username, password := "", ""
+	authConfig, err := docker.GetRegistryAuthConfig(c.reference.Registry)
+	if err == nil && authConfig != nil {
+		username, password = authConfig.Username, authConfig.Password
+		if authConfig.IdentityToken != "" {
+			password = authConfig.IdentityToken
+		}
+	}
+
+	if strings.HasPrefix(strings.ToLower(challenge), "basic") {
+		if username == "" {
+			return errors.Errorf("no credentials found for %s, please run 'docker login %s'", c.reference.Registry, c.reference.Registry)
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(username, password)
+		c.token = req.Header.Get("Authorization")
+		return nil
+	} else if !strings.HasPrefix(strings.ToLower(challenge), "bearer") {
+		return errors.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	params := map[string]string{}
+	for _, match := range challengeParamRegEx.FindAllStringSubmatch(challenge, -1) {
+		params[match[1]] = match[2]
+	}
+	if params["realm"] == "" {
+		return errors.Errorf("missing realm in authentication challenge %q", challenge)
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + c.reference.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("request token from %s: unexpected status code %d", params["realm"], resp.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return errors.Wrap(err, "parse token")
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	c.token = "Bearer " + token.Token
+	return nil
+}
+
+func (c *ociClient) scheme() string {
+	host := c.reference.Registry
+	if i := strings.LastIndex(host, ":"); i != -1 {
+		host = host[:i]
+	}
+	if host == "localhost" || host == "127.0.0.1" {
+		return "http"
+	}
+
+	return "https"
+}
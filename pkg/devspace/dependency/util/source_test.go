@@ -0,0 +1,249 @@
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func createTarball(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		assert.NilError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tarWriter.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tarWriter.Close())
+	assert.NilError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestDownloadTarball(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dependency")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	DependencyFolderPath = dir
+
+	tarball := createTarball(t, map[string]string{"env-main/devspace.yaml": "version: v1beta10\n"})
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(tarball)
+	}))
+	defer server.Close()
+
+	defaultClient := httpClient
+	httpClient = server.Client()
+	defer func() { httpClient = defaultClient }()
+
+	// plain http
+	_, err = DownloadDependency("http", "", &latest.SourceConfig{Tarball: strings.Replace(server.URL, "https://", "http://", 1)}, false, log.Discard)
+	assert.ErrorContains(t, err, "has to use https")
+
+	// wrong checksum
+	_, err = DownloadDependency("wrong", "", &latest.SourceConfig{Tarball: server.URL, Checksum: "sha256:1234"}, false, log.Discard)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	_, err = os.Stat(filepath.Join(dir, "wrong"))
+	assert.Assert(t, os.IsNotExist(err))
+
+	// correct checksum and single root folder is stripped
+	source := &latest.SourceConfig{Tarball: server.URL, Checksum: digest(tarball)}
+	localPath, err := DownloadDependency("id", "", source, false, log.Discard)
+	assert.NilError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(localPath, "devspace.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "version: v1beta10\n")
+
+	// cached
+	_, err = DownloadDependency("id", "", source, false, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, requests, 2)
+}
+
+func TestExtractArchiveOutsideDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dependency")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	tarball := createTarball(t, map[string]string{"../evil": "evil"})
+	err = extractArchive(bytes.NewReader(tarball), dir)
+	assert.ErrorContains(t, err, "outside of the target directory")
+}
+
+func TestParseOCIReference(t *testing.T) {
+	testCases := map[string]ociReference{
+		"ghcr.io/org/env:1.0.0":     {Registry: "ghcr.io", Repository: "org/env", Reference: "1.0.0"},
+		"localhost:5000/env":        {Registry: "localhost:5000", Repository: "env", Reference: "latest"},
+		"org/env@sha256:abc":        {Registry: "registry-1.docker.io", Repository: "org/env", Reference: "sha256:abc"},
+		"oci://env":                 {Registry: "registry-1.docker.io", Repository: "library/env", Reference: "latest"},
+		"docker.io/org/env:v2":      {Registry: "registry-1.docker.io", Repository: "org/env", Reference: "v2"},
+		"my.registry:443/a/b/c:dev": {Registry: "my.registry:443", Repository: "a/b/c", Reference: "dev"},
+	}
+
+	for ref, expected := range testCases {
+		parsed, err := parseOCIReference(ref)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, *parsed, expected)
+	}
+}
+
+func TestPullOCIArtifact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dependency")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	DependencyFolderPath = dir
+
+	layer := createTarball(t, map[string]string{"devspace.yaml": "version: v1beta10\n", "chart/values.yaml": "a: b\n"})
+	file := []byte("extra")
+	manifest, err := json.Marshal(ociManifest{
+		MediaType: ociManifestMediaType,
+		Layers: []ociDescriptor{
+			{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: digest(layer)},
+			{MediaType: "text/plain", Digest: digest(file), Annotations: map[string]string{ociTitleAnnotation: "extra.txt"}},
+		},
+	})
+	assert.NilError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, r.URL.Query().Get("scope"), "repository:org/env:pull")
+			_, _ = w.Write([]byte(`{"token":"secret"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/org/env/manifests/1.0.0":
+			_, _ = w.Write(manifest)
+		case "/v2/org/env/blobs/" + digest(layer):
+			_, _ = w.Write(layer)
+		case "/v2/org/env/blobs/" + digest(file):
+			_, _ = w.Write(file)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ref := strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1) + "/org/env:1.0.0"
+	_, err = DownloadDependency("wrong", "", &latest.SourceConfig{OCI: ref, Checksum: "sha256:1234"}, false, log.Discard)
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	localPath, err := DownloadDependency("id", "", &latest.SourceConfig{OCI: ref, Checksum: digest(manifest)}, false, log.Discard)
+	assert.NilError(t, err)
+	for name, expected := range map[string]string{"devspace.yaml": "version: v1beta10\n", "chart/values.yaml": "a: b\n", "extra.txt": "extra"} {
+		content, err := ioutil.ReadFile(filepath.Join(localPath, name))
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), expected)
+	}
+}
+
+func TestPullOCIArtifactIndex(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dependency")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	DependencyFolderPath = dir
+
+	newManifest := func(content string) ([]byte, []byte) {
+		layer := createTarball(t, map[string]string{"devspace.yaml": content})
+		manifest, err := json.Marshal(ociManifest{
+			MediaType: ociManifestMediaType,
+			Layers:    []ociDescriptor{{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: digest(layer)}},
+		})
+		assert.NilError(t, err)
+		return manifest, layer
+	}
+	otherManifest, otherLayer := newManifest("other\n")
+	platformManifest, platformLayer := newManifest("platform\n")
+	swappedManifest, swappedLayer := newManifest("swapped\n")
+	index, err := json.Marshal(ociManifest{
+		MediaType: ociIndexMediaType,
+		Manifests: []ociDescriptor{
+			{MediaType: ociManifestMediaType, Digest: digest(otherManifest), Platform: &ociPlatform{OS: "other", Architecture: "other"}},
+			{MediaType: ociManifestMediaType, Digest: digest(platformManifest), Platform: &ociPlatform{OS: runtime.GOOS, Architecture: runtime.GOARCH}},
+		},
+	})
+	assert.NilError(t, err)
+
+	swap := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/org/env/manifests/1.0.0":
+			_, _ = w.Write(index)
+		case "/v2/org/env/manifests/" + digest(otherManifest):
+			_, _ = w.Write(otherManifest)
+		case "/v2/org/env/manifests/" + digest(platformManifest):
+			if swap {
+				_, _ = w.Write(swappedManifest)
+			} else {
+				_, _ = w.Write(platformManifest)
+			}
+		case "/v2/org/env/blobs/" + digest(otherLayer):
+			_, _ = w.Write(otherLayer)
+		case "/v2/org/env/blobs/" + digest(platformLayer):
+			_, _ = w.Write(platformLayer)
+		case "/v2/org/env/blobs/" + digest(swappedLayer):
+			_, _ = w.Write(swappedLayer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// the manifest of the current platform is used
+	ref := strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1) + "/org/env:1.0.0"
+	localPath, err := DownloadDependency("id", "", &latest.SourceConfig{OCI: ref, Checksum: digest(index)}, false, log.Discard)
+	assert.NilError(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(localPath, "devspace.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "platform\n")
+
+	// a swapped manifest doesn't match the digest of the index
+	swap = true
+	_, err = DownloadDependency("swapped", "", &latest.SourceConfig{OCI: ref, Checksum: digest(index)}, false, log.Discard)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	_, err = os.Stat(filepath.Join(dir, "swapped"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestSelectManifest(t *testing.T) {
+	_, err := selectManifest([]ociDescriptor{
+		{Digest: "a", Platform: &ociPlatform{OS: "other", Architecture: "other"}},
+		{Digest: "b", Platform: &ociPlatform{OS: "other", Architecture: "amd64"}},
+	})
+	assert.ErrorContains(t, err, "no manifest found for platform")
+
+	manifest, err := selectManifest([]ociDescriptor{
+		{Digest: "a", Platform: &ociPlatform{OS: "other", Architecture: "other"}},
+		{Digest: "b"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, manifest.Digest, "b")
+}
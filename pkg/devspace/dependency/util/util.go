@@ -46,6 +46,12 @@ func DownloadDependency(ID, basePath string, source *latest.SourceConfig, update
 		_, err := os.Stat(localPath)
 		if err != nil {
 			update = true
+		} else if source.Revision != "" {
+			// Check if the correct revision is checked out
+			commit, err := git.GetHash(localPath)
+			if err != nil || !strings.HasPrefix(commit, source.Revision) {
+				update = true
+			}
 		}
 
 		// Update dependency
@@ -67,6 +73,31 @@ func DownloadDependency(ID, basePath string, source *latest.SourceConfig, update
 				return "", errors.Wrap(err, "clone repository")
 			}
 
+			log.Donef("Pulled %s", ID)
+		}
+	} else if source.OCI != "" || source.Tarball != "" {
+		localPath = filepath.Join(DependencyFolderPath, hash.String(ID))
+
+		// Check if dependency exists
+		_, err := os.Stat(localPath)
+		if err != nil {
+			update = true
+		}
+
+		// Update dependency
+		if update {
+			if source.OCI != "" {
+				err = pullOCIArtifact(source.OCI, source.Checksum, localPath)
+				if err != nil {
+					return "", errors.Wrapf(err, "pull oci artifact %s", source.OCI)
+				}
+			} else {
+				err = downloadTarball(source.Tarball, source.Checksum, localPath)
+				if err != nil {
+					return "", errors.Wrapf(err, "download tarball %s", source.Tarball)
+				}
+			}
+
 			log.Donef("Pulled %s", ID)
 		}
 	} else if source.Path != "" {
@@ -121,6 +152,24 @@ func DownloadDependency(ID, basePath string, source *latest.SourceConfig, update
 	return localPath, nil
 }
 
+// GetDependencyCommit returns the checked out commit of a git dependency
+func GetDependencyCommit(ID string) (string, error) {
+	return git.GetHash(filepath.Join(DependencyFolderPath, hash.String(ID)))
+}
+
+// GetSource returns the git url without credentials, the oci reference, the tarball url or the path of the dependency source
+func GetSource(source *latest.SourceConfig) string {
+	if source.Git != "" {
+		return authRegEx.ReplaceAllString(strings.TrimSpace(source.Git), "$1$2")
+	} else if source.OCI != "" {
+		return source.OCI
+	} else if source.Tarball != "" {
+		return authRegEx.ReplaceAllString(strings.TrimSpace(source.Tarball), "$1$2")
+	}
+
+	return source.Path
+}
+
 func GetDependencyID(basePath string, config *latest.DependencyConfig) (string, error) {
 	// copy config
 	out, err := yaml.Marshal(config)
@@ -179,6 +228,22 @@ func GetParentProfileID(basePath string, source *latest.SourceConfig, profile st
 			id += ";" + v.Name + "=" + v.Value
 		}
 
+		return id
+	} else if source.OCI != "" || source.Tarball != "" {
+		id := GetSource(source)
+		if source.Checksum != "" {
+			id += "@" + source.Checksum
+		}
+		if source.SubPath != "" {
+			id += ":" + source.SubPath
+		}
+		if profile != "" {
+			id += " - profile " + profile
+		}
+		for _, v := range vars {
+			id += ";" + v.Name + "=" + v.Value
+		}
+
 		return id
 	} else if source.Path != "" {
 		if isURL(source.Path) {
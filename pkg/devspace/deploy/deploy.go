@@ -11,6 +11,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/plugin"
 	helmclient "github.com/loft-sh/devspace/pkg/devspace/helm"
 	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
@@ -145,6 +146,11 @@ func (c *controller) getDeployClient(deployConfig *latest.DeploymentConfig, helm
 		if err != nil {
 			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
 		}
+	} else if deployConfig.Plugin != nil {
+		deployClient, err = plugin.New(deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
+		}
 	} else {
 		return nil, errors.Errorf("error render: deployment %s has no deployment method", deployConfig.Name)
 	}
@@ -163,6 +169,7 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 			return err
 		}
 
+		deployed := []string{}
 		for _, deployConfig := range config.Deployments {
 			if len(options.Deployments) > 0 {
 				shouldSkip := true
@@ -205,6 +212,13 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 				}
 
 				method = "helm"
+			} else if deployConfig.Plugin != nil {
+				deployClient, err = plugin.New(deployConfig, log)
+				if err != nil {
+					return errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+				}
+
+				method = "plugin " + deployConfig.Plugin.Name
 			} else {
 				return errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
 			}
@@ -229,11 +243,23 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 					return hookErr
 				}
 
+				// Execute error deployments deploy hook
+				hookErr = hook.ExecuteHooks(c.client, c.config, c.dependencies, map[string]interface{}{
+					"DEPLOY_NAME":  deployConfig.Name,
+					"DEPLOYMENTS":  deployed,
+					"BUILT_IMAGES": options.BuiltImages,
+					"ERROR":        err,
+				}, log, "error:deploy")
+				if hookErr != nil {
+					return hookErr
+				}
+
 				return errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
 			}
 
 			if wasDeployed {
 				log.Donef("Successfully deployed %s with %s", deployConfig.Name, method)
+				deployed = append(deployed, deployConfig.Name)
 
 				// Execute after deployment deploy hook
 				err = hook.ExecuteHooks(c.client, c.config, c.dependencies, map[string]interface{}{
@@ -258,7 +284,10 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 		}
 
 		// Execute after deployments deploy hook
-		err = hook.ExecuteHooks(c.client, c.config, c.dependencies, nil, log, "after:deploy")
+		err = hook.ExecuteHooks(c.client, c.config, c.dependencies, map[string]interface{}{
+			"DEPLOYMENTS":  deployed,
+			"BUILT_IMAGES": options.BuiltImages,
+		}, log, "after:deploy")
 		if err != nil {
 			return err
 		}
@@ -323,6 +352,11 @@ func (c *controller) Purge(deployments []string, log log.Logger) error {
 				if err != nil {
 					return errors.Wrap(err, "create helm client")
 				}
+			} else if deployConfig.Plugin != nil {
+				deployClient, err = plugin.New(deployConfig, log)
+				if err != nil {
+					return errors.Wrap(err, "create plugin deployer")
+				}
 			} else {
 				return errors.Errorf("error purging: deployment %s has no deployment method", deployConfig.Name)
 			}
@@ -0,0 +1,105 @@
+package plugin
+
+import (
+	"io"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	devspaceplugin "github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin/pluginpb"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// DeployConfig holds the necessary information for a deployment with a deployer of a grpc plugin
+type DeployConfig struct {
+	DeploymentConfig *latest.DeploymentConfig
+	Log              log.Logger
+
+	plugin *devspaceplugin.GRPCPlugin
+}
+
+// New creates a new deploy config for a plugin deployer
+func New(deployConfig *latest.DeploymentConfig, log log.Logger) (deployer.Interface, error) {
+	if deployConfig.Plugin == nil {
+		return nil, errors.New("error creating plugin deploy config: plugin is nil")
+	}
+
+	p, err := devspaceplugin.FindDeployer(deployConfig.Plugin.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeployConfig{
+		DeploymentConfig: deployConfig,
+		Log:              log,
+		plugin:           p,
+	}, nil
+}
+
+// Status returns the status of the deployment as reported by the plugin
+func (d *DeployConfig) Status() (*deployer.StatusResult, error) {
+	request, err := d.request(nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := d.plugin.Status(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployer.StatusResult{
+		Name:   d.DeploymentConfig.Name,
+		Type:   "Plugin",
+		Target: response.Target,
+		Status: response.Status,
+	}, nil
+}
+
+// Deploy deploys the deployment with the plugin
+func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (bool, error) {
+	request, err := d.request(builtImages, forceDeploy)
+	if err != nil {
+		return false, err
+	}
+
+	return d.plugin.Deploy(request, d.Log)
+}
+
+// Render writes the deployment rendered by the plugin to out
+func (d *DeployConfig) Render(builtImages map[string]string, out io.Writer) error {
+	request, err := d.request(builtImages, false)
+	if err != nil {
+		return err
+	}
+
+	return d.plugin.Render(request, out, d.Log)
+}
+
+// Delete removes the deployment with the plugin
+func (d *DeployConfig) Delete() error {
+	request, err := d.request(nil, false)
+	if err != nil {
+		return err
+	}
+
+	return d.plugin.Purge(request, d.Log)
+}
+
+func (d *DeployConfig) request(builtImages map[string]string, force bool) (*pluginpb.DeployRequest, error) {
+	deploymentConfig, err := yaml.Marshal(d.DeploymentConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal deployment config")
+	}
+
+	return &pluginpb.DeployRequest{
+		Deployer:         d.DeploymentConfig.Plugin.Name,
+		Name:             d.DeploymentConfig.Name,
+		DeploymentConfig: string(deploymentConfig),
+		BuiltImages:      builtImages,
+		Force:            force,
+	}, nil
+}
@@ -55,3 +55,14 @@ func GetAllAuthConfigs() (map[string]types.AuthConfig, error) {
 
 	return retMap, nil
 }
+
+// GetRegistryAuthConfig returns the auth config for the given registry hostname from the docker config
+// without the need of a docker daemon
+func GetRegistryAuthConfig(hostname string) (*types.AuthConfig, error) {
+	isDefaultRegistry := hostname == "docker.io" || hostname == "index.docker.io" || hostname == "registry-1.docker.io"
+	if isDefaultRegistry {
+		hostname = "https://index.docker.io/v1/"
+	}
+
+	return getDefaultAuthConfig(true, hostname, isDefaultRegistry)
+}
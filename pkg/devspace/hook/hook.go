@@ -2,12 +2,15 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -15,10 +18,12 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/loft-sh/devspace/pkg/util/command"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/shell"
 	"github.com/mgutz/ansi"
 	dockerterm "github.com/moby/term"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"mvdan.cc/sh/v3/interp"
 )
 
 var (
@@ -29,8 +34,12 @@ const (
 	KubeContextEnv   = "DEVSPACE_HOOK_KUBE_CONTEXT"
 	KubeNamespaceEnv = "DEVSPACE_HOOK_KUBE_NAMESPACE"
 	OsArgsEnv        = "DEVSPACE_HOOK_OS_ARGS"
+	ProfileEnv       = "DEVSPACE_PROFILE"
 )
 
+// defaultRetryBackoff is the time to wait before the first retry of a failed hook
+var defaultRetryBackoff = 2 * time.Second
+
 type Events []string
 
 func (e Events) With(name string) Events {
@@ -47,7 +56,7 @@ func EventsForSingle(base, name string) Events {
 
 // Hook is an interface to execute a specific hook type
 type Hook interface {
-	Execute(hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error
+	Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error
 }
 
 // LogExecuteHooks executes plugin hooks and config hooks and prints errors to the log
@@ -133,15 +142,21 @@ func executeSingle(client kubectl.Client, config config.Config, dependencies []t
 				writer = log
 			}
 
-			// If the hook is silent, we cache it in a buffer
-			hookWriter := writer
+			// The output is captured for the report. If the hook is silent, it is only
+			// written to the buffer
+			output := &outputBuffer{}
+			hookWriter := io.MultiWriter(writer, output)
 			if hookConfig.Silent {
-				hookWriter = &bytes.Buffer{}
+				hookWriter = output
 			}
 
 			// Decide which hook type to use
 			var hook Hook
-			if hookConfig.Container != nil {
+			if hookConfig.Job != nil {
+				hook = NewJobHook(hookWriter)
+			} else if hookConfig.Webhook != nil {
+				hook = NewWebhookHook()
+			} else if hookConfig.Container != nil {
 				if hookConfig.Upload != nil {
 					hook = NewRemoteHook(NewUploadHook())
 				} else if hookConfig.Download != nil {
@@ -159,7 +174,7 @@ func executeSingle(client kubectl.Client, config config.Config, dependencies []t
 			}
 
 			// Execute the hook
-			err := executeHook(hookConfig, hookWriter, client, config, dependencies, extraEnv, log, hook, event)
+			err := executeHook(hookConfig, output, client, config, dependencies, extraEnv, log, hook, event)
 			if err != nil {
 				return err
 			}
@@ -169,19 +184,31 @@ func executeSingle(client kubectl.Client, config config.Config, dependencies []t
 	return nil
 }
 
-func executeHook(hookConfig *latest.HookConfig, hookWriter io.Writer, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger, hook Hook, event string) error {
+func executeHook(hookConfig *latest.HookConfig, output *outputBuffer, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger, hook Hook, event string) error {
 	hookLog := log
 	if hookConfig.Silent {
 		hookLog = logpkg.Discard
 	}
 
+	if hookConfig.When != "" {
+		execute, err := evaluateWhen(hookConfig.When, config, extraEnv)
+		if err != nil {
+			return errors.Wrapf(err, "evaluate when of hook '%s'", ansi.Color(hookName(hookConfig), "white+b"))
+		} else if !execute {
+			log.Infof("Skip hook '%s' at %s, because its condition is not met", ansi.Color(hookName(hookConfig), "white+b"), ansi.Color(event, "white+b"))
+			return nil
+		}
+	}
+
 	if hookConfig.Background {
 		log.Infof("Execute hook '%s' in background at %s", ansi.Color(hookName(hookConfig), "white+b"), ansi.Color(event, "white+b"))
 		go func() {
-			err := hook.Execute(hookConfig, client, config, dependencies, extraEnv, hookLog)
+			startTime := time.Now()
+			attempts, err := executeWithRetry(hookConfig, client, config, dependencies, extraEnv, log, hookLog, hook)
+			recordHook(newHookReport(hookConfig, event, startTime, attempts, output, err))
 			if err != nil {
 				if hookConfig.Silent {
-					log.Warnf("Error executing hook '%s' in background: %s %v", ansi.Color(hookName(hookConfig), "white+b"), hookWriter.(*bytes.Buffer).String(), err)
+					log.Warnf("Error executing hook '%s' in background: %s %v", ansi.Color(hookName(hookConfig), "white+b"), output.String(), err)
 				} else {
 					log.Warnf("Error executing hook '%s' in background: %v", ansi.Color(hookName(hookConfig), "white+b"), err)
 				}
@@ -192,10 +219,12 @@ func executeHook(hookConfig *latest.HookConfig, hookWriter io.Writer, client kub
 	}
 
 	log.Infof("Execute hook '%s' at %s", ansi.Color(hookName(hookConfig), "white+b"), ansi.Color(event, "white+b"))
-	err := hook.Execute(hookConfig, client, config, dependencies, extraEnv, hookLog)
+	startTime := time.Now()
+	attempts, err := executeWithRetry(hookConfig, client, config, dependencies, extraEnv, log, hookLog, hook)
+	recordHook(newHookReport(hookConfig, event, startTime, attempts, output, err))
 	if err != nil {
 		if hookConfig.Silent {
-			return errors.Wrapf(err, "in hook '%s': %s", ansi.Color(hookName(hookConfig), "white+b"), hookWriter.(*bytes.Buffer).String())
+			return errors.Wrapf(err, "in hook '%s': %s", ansi.Color(hookName(hookConfig), "white+b"), output.String())
 		}
 		return errors.Wrapf(err, "in hook '%s'", ansi.Color(hookName(hookConfig), "white+b"))
 	}
@@ -203,6 +232,91 @@ func executeHook(hookConfig *latest.HookConfig, hookWriter io.Writer, client kub
 	return nil
 }
 
+// executeWithRetry executes the hook until it succeeds or the retries are exhausted and returns
+// the number of executions
+func executeWithRetry(hookConfig *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger, hookLog logpkg.Logger, hook Hook) (int, error) {
+	retries := 0
+	backoff := defaultRetryBackoff
+	if hookConfig.Retry != nil {
+		retries = hookConfig.Retry.Count
+		if hookConfig.Retry.Backoff > 0 {
+			backoff = time.Duration(hookConfig.Retry.Backoff) * time.Second
+		}
+	}
+
+	attempt := 1
+	for {
+		err := executeWithTimeout(hookConfig, client, config, dependencies, extraEnv, hookLog, hook)
+		if err == nil || attempt > retries {
+			return attempt, err
+		}
+
+		log.Warnf("Hook '%s' failed (attempt %d/%d), retrying in %s: %v", ansi.Color(hookName(hookConfig), "white+b"), attempt, retries+1, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		attempt++
+	}
+}
+
+// executeWithTimeout executes the hook and returns an error if it does not finish within the
+// timeout of the hook. The hook is stopped through its context when the timeout is reached and
+// this function only returns after the hook has returned, so that a retry never runs at the
+// same time as the previous attempt.
+func executeWithTimeout(hookConfig *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger, hook Hook) error {
+	if hookConfig.Timeout <= 0 {
+		return hook.Execute(context.Background(), hookConfig, client, config, dependencies, extraEnv, log)
+	}
+
+	timeout := time.Duration(hookConfig.Timeout) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := hook.Execute(ctx, hookConfig, client, config, dependencies, extraEnv, log)
+	if ctx.Err() == context.DeadlineExceeded {
+		if err == nil || err == context.DeadlineExceeded || errors.Cause(err) == context.DeadlineExceeded {
+			return errors.Errorf("timed out after %s", timeout)
+		}
+
+		return errors.Errorf("timed out after %s: %v", timeout, err)
+	}
+
+	return err
+}
+
+// evaluateWhen executes the when expression of the hook in a shell and returns true if
+// the expression exits with code 0
+func evaluateWhen(when string, config config.Config, extraEnv map[string]string) (bool, error) {
+	env := map[string]string{}
+	if config != nil {
+		for name, value := range config.Variables() {
+			env[name] = variable.EncodeValue(value)
+		}
+		if config.Generated() != nil {
+			env[ProfileEnv] = config.Generated().GetActiveProfile()
+		}
+	}
+	for k, v := range extraEnv {
+		env[k] = v
+	}
+
+	stderr := &bytes.Buffer{}
+	err := shell.ExecuteShellCommand(when, nil, "", ioutil.Discard, stderr, env)
+	if err != nil {
+		if _, ok := interp.IsExitStatus(err); ok {
+			return false, nil
+		}
+
+		return false, errors.Errorf("%v: %s", err, stderr.String())
+	}
+
+	return true, nil
+}
+
+// Name returns the name of the hook or a description of the hook if it has no name
+func Name(hook *latest.HookConfig) string {
+	return hookName(hook)
+}
+
 func hookName(hook *latest.HookConfig) string {
 	if hook.Name != "" {
 		return hook.Name
@@ -216,6 +330,17 @@ func hookName(hook *latest.HookConfig) string {
 
 		return commandString
 	}
+	if hook.Webhook != nil {
+		return webhookName(hook.Webhook)
+	}
+	if hook.Job != nil {
+		name := defaultJobName
+		if hook.Job.Name != "" {
+			name = hook.Job.Name
+		}
+
+		return fmt.Sprintf("job %s", name)
+	}
 	if hook.Upload != nil && hook.Container != nil {
 		localPath := "."
 		if hook.Upload.LocalPath != "" {
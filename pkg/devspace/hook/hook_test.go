@@ -1,9 +1,17 @@
 package hook
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"gotest.tools/assert"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -58,3 +66,78 @@ func TestHookWithExecution(t *testing.T) {
 		t.Fatalf("Failed to execute 1 hook with empty When.After: %v", err)
 	}
 }
+
+func TestHookWhen(t *testing.T) {
+	conf := config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events:  []string{"after:deploy:backend"},
+				Command: "exit 1",
+				When:    `[ "$DEVSPACE_HOOK_DEPLOY_NAME" = "frontend" ]`,
+			},
+			{
+				Events:  []string{"after:deploy:backend"},
+				Command: "exit 1",
+				When:    `[ "$ENVIRONMENT" = "staging" ]`,
+			},
+		},
+	}, nil, map[string]interface{}{"ENVIRONMENT": "production"}, constants.DefaultConfigPath)
+	err := ExecuteHooks(nil, conf, nil, map[string]interface{}{"DEPLOY_NAME": "backend"}, log.Discard, "after:deploy:backend")
+	assert.NilError(t, err)
+
+	conf.Config().Hooks[0].When = `[ "$DEVSPACE_HOOK_DEPLOY_NAME" = "backend" ]`
+	err = ExecuteHooks(nil, conf, nil, map[string]interface{}{"DEPLOY_NAME": "backend"}, log.Discard, "after:deploy:backend")
+	assert.ErrorContains(t, err, "exit status 1")
+}
+
+func TestHookRetry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the hook fails until it was executed three times
+	counter := filepath.Join(dir, "counter")
+	conf := config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events:  []string{"my-event"},
+				Command: `echo x >> "` + counter + `" && [ "$(cat "` + counter + `" | wc -l)" -ge 3 ]`,
+				Retry:   &latest.HookRetryConfig{Count: 2},
+			},
+		},
+	}, nil, nil, constants.DefaultConfigPath)
+
+	hookConfig := conf.Config().Hooks[0]
+	attempts, err := executeWithRetry(hookConfig, nil, conf, nil, nil, log.Discard, log.Discard, &fakeHook{})
+	assert.NilError(t, err)
+	assert.Equal(t, attempts, 1)
+
+	defaultRetryBackoff = time.Millisecond
+	defer func() { defaultRetryBackoff = 2 * time.Second }()
+	attempts, err = executeWithRetry(hookConfig, nil, conf, nil, nil, log.Discard, log.Discard, NewLocalCommandHook(ioutil.Discard, ioutil.Discard))
+	assert.NilError(t, err)
+	assert.Equal(t, attempts, 3)
+}
+
+func TestHookTimeout(t *testing.T) {
+	conf := config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events:  []string{"my-event"},
+				Command: "sleep 10",
+				Timeout: 1,
+			},
+		},
+	}, nil, nil, constants.DefaultConfigPath)
+
+	start := time.Now()
+	err := ExecuteHooks(nil, conf, nil, nil, log.Discard, "my-event")
+	assert.ErrorContains(t, err, "timed out after 1s")
+	assert.Assert(t, time.Since(start) < 5*time.Second)
+}
+
+type fakeHook struct{}
+
+func (f *fakeHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log log.Logger) error {
+	return nil
+}
@@ -0,0 +1,303 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"github.com/loft-sh/devspace/pkg/util/randutil"
+	"github.com/loft-sh/devspace/pkg/util/yamlutil"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+const (
+	// JobHookLabel is the label that is added to all jobs created by hooks
+	JobHookLabel = "devspace.sh/hook"
+
+	defaultJobName    = "devspace-hook"
+	defaultJobTimeout = 300
+)
+
+// jobPollInterval is the interval in which the job status is checked
+var jobPollInterval = time.Second
+
+// jobLogsTimeout is the maximum time to wait for the remaining logs of a failed job
+var jobLogsTimeout = 10 * time.Second
+
+// NewJobHook creates a new hook that creates a kubernetes job and waits until it has completed
+func NewJobHook(writer io.Writer) Hook {
+	return &jobHook{
+		Writer: writer,
+	}
+}
+
+type jobHook struct {
+	Writer io.Writer
+
+	printWarning sync.Once
+}
+
+func (j *jobHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
+	if client == nil {
+		return errors.Errorf("Cannot execute hook '%s': kube client is not initialized", ansi.Color(hookName(hook), "white+b"))
+	}
+
+	// the job is stopped by kubernetes when the timeout of the job or the timeout of the hook
+	// is reached, so that a retry of the hook never runs at the same time as this job
+	timeout := time.Duration(defaultJobTimeout) * time.Second
+	if hook.Job.Timeout > 0 {
+		timeout = time.Duration(hook.Job.Timeout) * time.Second
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	job, err := newJob(hook.Job, timeout, config, dependencies)
+	if err != nil {
+		return err
+	}
+	if job.Namespace == "" {
+		job.Namespace = client.Namespace()
+	}
+
+	job, err = client.KubeClient().BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "create job")
+	}
+
+	log.Infof("Created job %s/%s for hook '%s'", job.Namespace, job.Name, ansi.Color(hookName(hook), "white+b"))
+	defer func() {
+		// a job that has timed out or was canceled is always deleted, because it would
+		// continue to run otherwise
+		if !hook.Job.Delete && ctx.Err() == nil {
+			return
+		}
+
+		propagationPolicy := metav1.DeletePropagationBackground
+		err := client.KubeClient().BatchV1().Jobs(job.Namespace).Delete(context.TODO(), job.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		})
+		if err != nil {
+			log.Warnf("Error deleting job %s/%s: %v", job.Namespace, job.Name, err)
+		}
+	}()
+
+	// stream the logs as soon as the pod has started
+	pod, err := j.waitForPod(ctx, client, job, log)
+	if err != nil {
+		return err
+	}
+
+	logsDone := make(chan struct{})
+	if pod != nil {
+		go func() {
+			defer close(logsDone)
+			j.streamLogs(ctx, client, pod, log)
+		}()
+	} else {
+		close(logsDone)
+	}
+
+	err = j.waitForCompletion(ctx, client, job)
+	if err != nil {
+		// the last output of a failed job is the most important one, so wait for the
+		// log streams to finish before they are stopped by the context
+		select {
+		case <-logsDone:
+		case <-time.After(jobLogsTimeout):
+		}
+
+		return err
+	}
+
+	<-logsDone
+	log.Donef("Job %s/%s of hook '%s' completed successfully", job.Namespace, job.Name, ansi.Color(hookName(hook), "white+b"))
+	return nil
+}
+
+// newJob creates the job from the hook config and replaces the images of the pod template. The
+// job is terminated by kubernetes after the timeout
+func newJob(jobConfig *latest.HookJobConfig, timeout time.Duration, config config.Config, dependencies []types.Dependency) (*batchv1.Job, error) {
+	template := &corev1.PodTemplateSpec{}
+	raw, err := json.Marshal(yamlutil.Convert(jobConfig.Template))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal job template")
+	}
+	err = json.Unmarshal(raw, template)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse job template")
+	}
+	if len(template.Spec.Containers) == 0 {
+		return nil, errors.New("job template has no containers")
+	}
+
+	for i := range template.Spec.InitContainers {
+		template.Spec.InitContainers[i].Image, err = util.ResolveImage(template.Spec.InitContainers[i].Image, config, dependencies)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].Image, err = util.ResolveImage(template.Spec.Containers[i].Image, config, dependencies)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	name := defaultJobName
+	if jobConfig.Name != "" {
+		name = jobConfig.Name
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-" + strings.ToLower(randutil.GenerateRandomString(5)),
+			Namespace: jobConfig.Namespace,
+			Labels: map[string]string{
+				JobHookLabel: "true",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// failed hooks are retried by devspace
+			BackoffLimit:          ptr.Int32(0),
+			ActiveDeadlineSeconds: ptr.Int64(int64(math.Ceil(timeout.Seconds()))),
+			Template:              *template,
+		},
+	}, nil
+}
+
+// waitForPod waits until the pod of the job is not pending anymore. If the job has finished
+// before a pod was found, nil is returned
+func (j *jobHook) waitForPod(ctx context.Context, client kubectl.Client, job *batchv1.Job, log logpkg.Logger) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	err := wait.PollImmediateUntil(jobPollInterval, func() (bool, error) {
+		pods, err := client.KubeClient().CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: "job-name=" + job.Name,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for i := range pods.Items {
+			if pods.Items[i].Status.Phase != corev1.PodPending {
+				pod = &pods.Items[i]
+				return true, nil
+			} else if targetselector.HasPodProblem(&pods.Items[i]) {
+				j.printWarning.Do(func() {
+					log.Warnf("Pod %s/%s of job has critical status: %s. DevSpace will continue waiting, but this operation might timeout", pods.Items[i].Namespace, pods.Items[i].Name, kubectl.GetPodStatus(&pods.Items[i]))
+				})
+			}
+		}
+
+		return jobFinished(ctx, client, job)
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return nil, errors.Errorf("timed out waiting for the pod of job %s/%s", job.Namespace, job.Name)
+	}
+
+	return pod, err
+}
+
+func (j *jobHook) streamLogs(ctx context.Context, client kubectl.Client, pod *corev1.Pod, log logpkg.Logger) {
+	wg := sync.WaitGroup{}
+	for _, container := range pod.Spec.Containers {
+		wg.Add(1)
+		go func(container string) {
+			defer wg.Done()
+
+			reader, err := client.Logs(ctx, pod.Namespace, pod.Name, container, false, nil, true)
+			if err != nil {
+				log.Warnf("Error reading logs of job pod %s/%s: %v", pod.Namespace, pod.Name, err)
+				return
+			}
+			defer reader.Close()
+
+			_, _ = io.Copy(j.Writer, reader)
+		}(container.Name)
+	}
+
+	wg.Wait()
+}
+
+func (j *jobHook) waitForCompletion(ctx context.Context, client kubectl.Client, job *batchv1.Job) error {
+	err := wait.PollImmediateUntil(jobPollInterval, func() (bool, error) {
+		return jobFinished(ctx, client, job)
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("timed out waiting for job %s/%s to complete", job.Namespace, job.Name)
+	}
+
+	return err
+}
+
+// jobFinished returns true if the job has succeeded and an error if it has failed
+func jobFinished(ctx context.Context, client kubectl.Client, job *batchv1.Job) (bool, error) {
+	current, err := client.KubeClient().BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	if current.Status.Succeeded > 0 {
+		return true, nil
+	}
+	for _, condition := range current.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return false, jobError(ctx, client, job, condition.Message)
+		}
+	}
+	if current.Status.Failed > 0 {
+		return false, jobError(ctx, client, job, "")
+	}
+
+	return false, nil
+}
+
+// jobError returns the exit code of the failed job container as error if possible
+func jobError(ctx context.Context, client kubectl.Client, job *batchv1.Job, message string) error {
+	err := errors.Errorf("job %s/%s failed", job.Namespace, job.Name)
+	if message != "" {
+		err = errors.Errorf("job %s/%s failed: %s", job.Namespace, job.Name, message)
+	}
+
+	pods, listErr := client.KubeClient().CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if listErr != nil {
+		return err
+	}
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+				return kubectlExec.CodeExitError{
+					Err:  err,
+					Code: int(status.State.Terminated.ExitCode),
+				}
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,236 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+type jobTestCase struct {
+	delete           bool
+	exitCode         int32
+	expectedExitCode int
+}
+
+func TestJobHook(t *testing.T) {
+	jobPollInterval = time.Millisecond * 10
+	defer func() { jobPollInterval = time.Second }()
+
+	testCases := map[string]jobTestCase{
+		"Succeeded": {
+			delete: true,
+		},
+		"Failed": {
+			exitCode:         3,
+			expectedExitCode: 3,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		kubeClient := fake.NewSimpleClientset()
+		hookConfig := &latest.HookConfig{
+			Events: []string{"before:deploy"},
+			Job: &latest.HookJobConfig{
+				Name: "migrate",
+				Template: map[interface{}]interface{}{
+					"spec": map[interface{}]interface{}{
+						"containers": []interface{}{
+							map[interface{}]interface{}{
+								"name":    "migrate",
+								"image":   "image(api):tag(api)",
+								"command": []interface{}{"migrate", "up"},
+							},
+						},
+					},
+				},
+				Delete: testCase.delete,
+			},
+		}
+		conf := config.NewConfig(nil, &latest.Config{
+			Images: map[string]*latest.ImageConfig{
+				"api": {Image: "myregistry/api"},
+			},
+		}, &generated.Config{
+			ActiveProfile: "",
+			Profiles: map[string]*generated.CacheConfig{
+				"": {
+					Images: map[string]*generated.ImageCache{
+						"api": {ImageName: "myregistry/api", Tag: "abc"},
+					},
+				},
+			},
+		}, nil, constants.DefaultConfigPath)
+
+		go completeJob(kubeClient, testCase.exitCode)
+
+		output := &bytes.Buffer{}
+		err := NewJobHook(output).Execute(context.Background(), hookConfig, &fakekube.Client{Client: kubeClient}, conf, nil, nil, log.Discard)
+		assert.Equal(t, exitCode(err), testCase.expectedExitCode, "Unexpected exit code in testCase %s: %v", testName, err)
+		if testCase.expectedExitCode == 0 {
+			assert.Equal(t, output.String(), "ContainerLogs", "Unexpected output in testCase %s", testName)
+		} else {
+			_, ok := err.(kubectlExec.CodeExitError)
+			assert.Assert(t, ok, "Unexpected error type in testCase %s", testName)
+		}
+
+		jobs, err := kubeClient.BatchV1().Jobs("testNamespace").List(context.TODO(), metav1.ListOptions{})
+		assert.NilError(t, err)
+		if testCase.delete {
+			assert.Equal(t, len(jobs.Items), 0, "Job not deleted in testCase %s", testName)
+		} else {
+			assert.Equal(t, len(jobs.Items), 1, "Job deleted in testCase %s", testName)
+			assert.Equal(t, jobs.Items[0].Spec.Template.Spec.Containers[0].Image, "myregistry/api:abc")
+			assert.Equal(t, jobs.Items[0].Spec.Template.Spec.RestartPolicy, corev1.RestartPolicyNever)
+		}
+	}
+}
+
+// completeJob simulates the job controller by creating the job pod and updating the job status
+func completeJob(kubeClient kubernetes.Interface, exitCode int32) {
+	_ = wait.PollImmediate(time.Millisecond*10, time.Second*10, func() (bool, error) {
+		jobs, err := kubeClient.BatchV1().Jobs("testNamespace").List(context.TODO(), metav1.ListOptions{})
+		if err != nil || len(jobs.Items) == 0 {
+			return false, nil
+		}
+
+		job := &jobs.Items[0]
+		phase := corev1.PodSucceeded
+		if exitCode != 0 {
+			phase = corev1.PodFailed
+		}
+		_, _ = kubeClient.CoreV1().Pods(job.Namespace).Create(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      job.Name + "-pod",
+				Namespace: job.Namespace,
+				Labels:    map[string]string{"job-name": job.Name},
+			},
+			Spec: job.Spec.Template.Spec,
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:  "migrate",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+					},
+				},
+			},
+		}, metav1.CreateOptions{})
+
+		if exitCode == 0 {
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		}
+		_, _ = kubeClient.BatchV1().Jobs(job.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+		return true, nil
+	})
+}
+
+// delayedLogsClient returns the logs of a container after a delay, like a log stream that
+// is still transferring the last lines after the job has failed
+type delayedLogsClient struct {
+	*fakekube.Client
+}
+
+func (d *delayedLogsClient) Logs(ctx context.Context, namespace, podName, containerName string, lastContainerLog bool, tail *int64, follow bool) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		select {
+		case <-time.After(time.Millisecond * 200):
+			_, _ = writer.Write([]byte("error: migration 0042 failed\n"))
+			_ = writer.Close()
+		case <-ctx.Done():
+			_ = writer.CloseWithError(ctx.Err())
+		}
+	}()
+
+	return reader, nil
+}
+
+func TestJobHookFailedLogs(t *testing.T) {
+	jobPollInterval = time.Millisecond * 10
+	defer func() { jobPollInterval = time.Second }()
+
+	kubeClient := fake.NewSimpleClientset()
+	hookConfig := &latest.HookConfig{
+		Job: &latest.HookJobConfig{
+			Template: map[interface{}]interface{}{
+				"spec": map[interface{}]interface{}{
+					"containers": []interface{}{
+						map[interface{}]interface{}{
+							"name":  "migrate",
+							"image": "migrate",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	go completeJob(kubeClient, 1)
+
+	output := &bytes.Buffer{}
+	err := NewJobHook(output).Execute(context.Background(), hookConfig, &delayedLogsClient{Client: &fakekube.Client{Client: kubeClient}}, nil, nil, nil, log.Discard)
+	assert.Equal(t, exitCode(err), 1)
+	assert.Equal(t, output.String(), "error: migration 0042 failed\n")
+}
+
+func TestJobHookTimeout(t *testing.T) {
+	jobPollInterval = time.Millisecond * 10
+	defer func() { jobPollInterval = time.Second }()
+
+	kubeClient := fake.NewSimpleClientset()
+	hookConfig := &latest.HookConfig{
+		Job: &latest.HookJobConfig{
+			Template: map[interface{}]interface{}{
+				"spec": map[interface{}]interface{}{
+					"containers": []interface{}{
+						map[interface{}]interface{}{
+							"name":  "migrate",
+							"image": "migrate",
+						},
+					},
+				},
+			},
+			Timeout: 60,
+		},
+	}
+
+	// the job never completes, so the hook times out and the job has to be deleted
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	err := NewJobHook(&bytes.Buffer{}).Execute(ctx, hookConfig, &fakekube.Client{Client: kubeClient}, nil, nil, nil, log.Discard)
+	assert.ErrorContains(t, err, "timed out")
+
+	jobs, err := kubeClient.BatchV1().Jobs("testNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(jobs.Items), 0)
+
+	var created *batchv1.Job
+	for _, action := range kubeClient.Actions() {
+		if createAction, ok := action.(k8stesting.CreateAction); ok && action.GetResource().Resource == "jobs" {
+			created = createAction.GetObject().(*batchv1.Job)
+		}
+	}
+	assert.Assert(t, created != nil)
+	assert.Equal(t, *created.Spec.ActiveDeadlineSeconds, int64(1))
+}
@@ -1,6 +1,7 @@
 package hook
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
@@ -29,7 +30,7 @@ type localCommandHook struct {
 	Stderr io.Writer
 }
 
-func (l *localCommandHook) Execute(hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, cmdExtraEnv map[string]string, log logpkg.Logger) error {
+func (l *localCommandHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, cmdExtraEnv map[string]string, log logpkg.Logger) error {
 	// Create extra env variables
 	osArgsBytes, err := json.Marshal(os.Args)
 	if err != nil {
@@ -56,11 +57,11 @@ func (l *localCommandHook) Execute(hook *latest.HookConfig, client kubectl.Clien
 
 	// if args are nil we execute the command in a shell
 	if hook.Args == nil {
-		return shell.ExecuteShellCommand(hookCommand, nil, dir, l.Stdout, l.Stderr, extraEnv)
+		return shell.ExecuteShellCommandWithContext(ctx, hookCommand, nil, dir, l.Stdout, l.Stderr, extraEnv)
 	}
 
 	// else we execute it directly
-	return command.ExecuteCommandWithEnv(hookCommand, hookArgs, dir, l.Stdout, l.Stderr, extraEnv)
+	return command.ExecuteCommandWithContext(ctx, hookCommand, hookArgs, dir, l.Stdout, l.Stderr, extraEnv)
 }
 
 func ResolveCommand(command string, args []string, config config.Config, dependencies []types.Dependency) (string, []string, error) {
@@ -23,13 +23,19 @@ type remoteLogsHook struct {
 	Writer io.Writer
 }
 
-func (r *remoteLogsHook) ExecuteRemotely(hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
+func (r *remoteLogsHook) ExecuteRemotely(ctx context.Context, hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
 	log.Infof("Execute hook '%s' in container '%s/%s/%s'", ansi.Color(hookName(hook), "white+b"), podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name)
-	reader, err := client.Logs(context.TODO(), podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, false, hook.Logs.TailLines, true)
+	reader, err := client.Logs(ctx, podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, false, hook.Logs.TailLines, true)
 	if err != nil {
 		return err
 	}
 
+	defer reader.Close()
+
 	_, err = io.Copy(r.Writer, reader)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	return err
 }
@@ -2,9 +2,11 @@ package hook
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -18,6 +20,25 @@ import (
 	"k8s.io/client-go/util/exec"
 )
 
+// watchdogScript runs the command in a new session if setsid is available and kills the
+// process group of the command when stdin is closed
+const watchdogScript = `if command -v setsid >/dev/null 2>&1; then
+  setsid "$@" </dev/null &
+else
+  "$@" </dev/null &
+fi
+pid=$!
+(cat >/dev/null; kill -TERM -$pid 2>/dev/null || kill -TERM $pid 2>/dev/null) >/dev/null 2>&1 &
+watchdog=$!
+wait $pid
+status=$?
+kill $watchdog 2>/dev/null
+exit $status`
+
+// watchdogGracePeriod is the time the watchdog has to kill the command before the connection
+// is closed
+var watchdogGracePeriod = 5 * time.Second
+
 func NewRemoteCommandHook(stdout io.Writer, stderr io.Writer) RemoteHook {
 	return &remoteCommandHook{
 		Stdout: stdout,
@@ -30,7 +51,7 @@ type remoteCommandHook struct {
 	Stderr io.Writer
 }
 
-func (r *remoteCommandHook) ExecuteRemotely(hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
+func (r *remoteCommandHook) ExecuteRemotely(ctx context.Context, hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
 	hookCommand, hookArgs, err := ResolveCommand(hook.Command, hook.Args, config, dependencies)
 	if err != nil {
 		return err
@@ -46,7 +67,7 @@ func (r *remoteCommandHook) ExecuteRemotely(hook *latest.HookConfig, podContaine
 	once := hook.Container.Once != nil && *hook.Container.Once
 	if once {
 		// check whether hook has previously executed
-		hookExecuted, err := hasHookExecuted(hookCommand, hookArgs, podContainer, client)
+		hookExecuted, err := hasHookExecuted(ctx, hookCommand, hookArgs, podContainer, client)
 		if err != nil {
 			return errors.Errorf("error checking whether hook has executed '%s/%s/%s': %v", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err)
 		}
@@ -58,20 +79,14 @@ func (r *remoteCommandHook) ExecuteRemotely(hook *latest.HookConfig, podContaine
 	}
 
 	log.Infof("Execute hook '%s' in container '%s/%s/%s'", ansi.Color(hookName(hook), "white+b"), podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name)
-	err = client.ExecStream(&kubectl.ExecStreamOptions{
-		Pod:       podContainer.Pod,
-		Container: podContainer.Container.Name,
-		Command:   cmd,
-		Stdout:    r.Stdout,
-		Stderr:    r.Stderr,
-	})
+	err = execStream(ctx, client, podContainer, cmd, r.Stdout, r.Stderr)
 	if err != nil {
-		return errors.Errorf("error in container '%s/%s/%s': %v", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err)
+		return errors.Wrapf(err, "error in container '%s/%s/%s'", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name)
 	}
 
 	if once {
 		// record hook execution
-		err := recordHookExecuted(hookCommand, hookArgs, podContainer, client)
+		err := recordHookExecuted(ctx, hookCommand, hookArgs, podContainer, client)
 		if err != nil {
 			return errors.Errorf("error recording hook execution %s in container '%s/%s/%s': %v", ansi.Color(hookName(hook), "white+b"), podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err)
 		}
@@ -80,17 +95,69 @@ func (r *remoteCommandHook) ExecuteRemotely(hook *latest.HookConfig, podContaine
 	return nil
 }
 
+// execStream executes the command in the container. If the context has a deadline, the command
+// is wrapped by a watchdog that kills the command as soon as its stdin is closed, which happens
+// when the context is done. This makes sure the command doesn't keep running in the container
+// after the hook timed out, because closing the connection alone doesn't stop the command.
+func execStream(ctx context.Context, client kubectl.Client, podContainer *selector.SelectedPodContainer, cmd []string, stdout, stderr io.Writer) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return client.ExecStream(&kubectl.ExecStreamOptions{
+			Context:   ctx,
+			Pod:       podContainer.Pod,
+			Container: podContainer.Container.Name,
+			Command:   cmd,
+			Stdout:    stdout,
+			Stderr:    stderr,
+		})
+	}
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	// the connection is closed after the watchdog had time to kill the command
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = stdinWriter.Close()
+			select {
+			case <-time.After(watchdogGracePeriod):
+			case <-streamCtx.Done():
+			}
+			cancel()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	err := client.ExecStream(&kubectl.ExecStreamOptions{
+		Context:   streamCtx,
+		Pod:       podContainer.Pod,
+		Container: podContainer.Container.Name,
+		Command:   append([]string{"sh", "-c", watchdogScript, "devspace-hook"}, cmd...),
+		Stdin:     stdinReader,
+		Stdout:    stdout,
+		Stderr:    stderr,
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
 func commandHash(command string, args []string) string {
 	return hash.String(fmt.Sprintf("%s %s", command, strings.Join(args, " ")))
 }
 
-func hasHookExecuted(command string, args []string, podContainer *selector.SelectedPodContainer, client kubectl.Client) (bool, error) {
+func hasHookExecuted(ctx context.Context, command string, args []string, podContainer *selector.SelectedPodContainer, client kubectl.Client) (bool, error) {
 	cmdHash := commandHash(command, args)
 	cmd := []string{"test", "-e", fmt.Sprintf(`/tmp/hook-%s`, cmdHash)}
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
 
 	err := client.ExecStream(&kubectl.ExecStreamOptions{
+		Context:   ctx,
 		Pod:       podContainer.Pod,
 		Container: podContainer.Container.Name,
 		Command:   cmd,
@@ -107,13 +174,14 @@ func hasHookExecuted(command string, args []string, podContainer *selector.Selec
 	return true, nil
 }
 
-func recordHookExecuted(command string, args []string, podContainer *selector.SelectedPodContainer, client kubectl.Client) error {
+func recordHookExecuted(ctx context.Context, command string, args []string, podContainer *selector.SelectedPodContainer, client kubectl.Client) error {
 	cmdHash := commandHash(command, args)
 	cmd := []string{"touch", fmt.Sprintf(`/tmp/hook-%s`, cmdHash)}
 	stdout := new(bytes.Buffer)
 	stderr := new(bytes.Buffer)
 
 	err := client.ExecStream(&kubectl.ExecStreamOptions{
+		Context:   ctx,
 		Pod:       podContainer.Pod,
 		Container: podContainer.Container.Name,
 		Command:   cmd,
@@ -20,7 +20,7 @@ import (
 
 // RemoteHook is a hook that is executed in a container
 type RemoteHook interface {
-	ExecuteRemotely(hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error
+	ExecuteRemotely(ctx context.Context, hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error
 }
 
 func NewRemoteHook(hook RemoteHook) Hook {
@@ -42,7 +42,7 @@ type remoteHook struct {
 	WaitingStrategy targetselector.WaitingStrategy
 }
 
-func (r *remoteHook) Execute(hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
+func (r *remoteHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
 	if client == nil {
 		return errors.Errorf("Cannot execute hook '%s': kube client is not initialized", ansi.Color(hookName(hook), "white+b"))
 	}
@@ -66,7 +66,7 @@ func (r *remoteHook) Execute(hook *latest.HookConfig, client kubectl.Client, con
 		}
 	}
 
-	executed, err := r.execute(hook, imageSelectors, client, config, dependencies, log)
+	executed, err := r.execute(ctx, hook, imageSelectors, client, config, dependencies, log)
 	if err != nil {
 		return err
 	} else if !executed {
@@ -76,7 +76,7 @@ func (r *remoteHook) Execute(hook *latest.HookConfig, client kubectl.Client, con
 	return nil
 }
 
-func (r *remoteHook) execute(hook *latest.HookConfig, imageSelector []imageselector.ImageSelector, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) (bool, error) {
+func (r *remoteHook) execute(ctx context.Context, hook *latest.HookConfig, imageSelector []imageselector.ImageSelector, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) (bool, error) {
 	labelSelector := ""
 	if len(hook.Container.LabelSelector) > 0 {
 		labelSelector = labels.Set(hook.Container.LabelSelector).String()
@@ -95,7 +95,7 @@ func (r *remoteHook) execute(hook *latest.HookConfig, imageSelector []imageselec
 
 	// select the container
 	targetSelector := targetselector.NewTargetSelector(client)
-	podContainer, err := targetSelector.SelectSingleContainer(context.TODO(), targetselector.Options{
+	podContainer, err := targetSelector.SelectSingleContainer(ctx, targetselector.Options{
 		Selector: selector.Selector{
 			ImageSelector: imageSelector,
 			LabelSelector: labelSelector,
@@ -118,7 +118,7 @@ func (r *remoteHook) execute(hook *latest.HookConfig, imageSelector []imageselec
 	}
 
 	// execute the hook in the container
-	err = r.Hook.ExecuteRemotely(hook, podContainer, client, config, dependencies, log)
+	err = r.Hook.ExecuteRemotely(ctx, hook, podContainer, client, config, dependencies, log)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,150 @@
+package hook
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// blockingClient is a kube client whose exec and log streams block until their context is done
+type blockingClient struct {
+	*fakekube.Client
+
+	m         sync.Mutex
+	running   int
+	overlaps  int
+	calls     int
+	commands  [][]string
+	stdinSeen bool
+}
+
+func (b *blockingClient) start() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.calls++
+	b.running++
+	if b.running > 1 {
+		b.overlaps++
+	}
+}
+
+func (b *blockingClient) stop() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.running--
+}
+
+func (b *blockingClient) ExecStream(options *kubectl.ExecStreamOptions) error {
+	b.start()
+	defer b.stop()
+
+	b.m.Lock()
+	b.commands = append(b.commands, options.Command)
+	b.m.Unlock()
+
+	if options.Stdin != nil {
+		// the watchdog kills the command as soon as stdin is closed
+		_, _ = io.Copy(ioutil.Discard, options.Stdin)
+		b.m.Lock()
+		b.stdinSeen = true
+		b.m.Unlock()
+	}
+
+	<-options.Context.Done()
+	return options.Context.Err()
+}
+
+func (b *blockingClient) Logs(ctx context.Context, namespace, podName, containerName string, lastContainerLog bool, tail *int64, follow bool) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+	go func() {
+		b.start()
+		defer b.stop()
+
+		<-ctx.Done()
+		_ = writer.CloseWithError(ctx.Err())
+	}()
+
+	return reader, nil
+}
+
+// selectedRemoteHook executes the remote hook in a fixed container
+type selectedRemoteHook struct {
+	hook         RemoteHook
+	podContainer *selector.SelectedPodContainer
+}
+
+func (s *selectedRemoteHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log log.Logger) error {
+	return s.hook.ExecuteRemotely(ctx, hook, s.podContainer, client, config, dependencies, log)
+}
+
+func TestRemoteHookTimeout(t *testing.T) {
+	defaultRetryBackoff = time.Millisecond
+	watchdogGracePeriod = 100 * time.Millisecond
+	defer func() {
+		defaultRetryBackoff = 2 * time.Second
+		watchdogGracePeriod = 5 * time.Second
+	}()
+
+	podContainer := &selector.SelectedPodContainer{
+		Pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		},
+		Container: &corev1.Container{Name: "container"},
+	}
+
+	// remote command
+	client := &blockingClient{Client: &fakekube.Client{}}
+	hookConfig := &latest.HookConfig{
+		Command:   "migrate",
+		Container: &latest.HookContainer{},
+		Timeout:   1,
+		Retry:     &latest.HookRetryConfig{Count: 1},
+	}
+	start := time.Now()
+	attempts, err := executeWithRetry(hookConfig, client, nil, nil, nil, log.Discard, log.Discard, &selectedRemoteHook{hook: NewRemoteCommandHook(ioutil.Discard, ioutil.Discard), podContainer: podContainer})
+	assert.ErrorContains(t, err, "timed out after 1s")
+	assert.Equal(t, attempts, 2)
+	assert.Assert(t, time.Since(start) < 5*time.Second)
+	assert.Equal(t, client.calls, 2)
+	assert.Equal(t, client.overlaps, 0)
+	assert.Equal(t, client.running, 0)
+	assert.Assert(t, client.stdinSeen, "stdin of the watchdog was not closed")
+	assert.Equal(t, client.commands[0][2], watchdogScript)
+	assert.Equal(t, strings.Join(client.commands[0][4:], " "), "sh -c migrate")
+
+	// logs
+	client = &blockingClient{Client: &fakekube.Client{}}
+	hookConfig = &latest.HookConfig{
+		Container: &latest.HookContainer{},
+		Logs:      &latest.HookLogsConfig{},
+		Timeout:   1,
+		Retry:     &latest.HookRetryConfig{Count: 1},
+	}
+	attempts, err = executeWithRetry(hookConfig, client, nil, nil, nil, log.Discard, log.Discard, &selectedRemoteHook{hook: NewLogsHook(ioutil.Discard), podContainer: podContainer})
+	assert.ErrorContains(t, err, "timed out after 1s")
+	assert.Equal(t, attempts, 2)
+
+	// wait for the log streams to be stopped
+	time.Sleep(100 * time.Millisecond)
+	client.m.Lock()
+	defer client.m.Unlock()
+	assert.Equal(t, client.overlaps, 0)
+	assert.Equal(t, client.running, 0)
+}
@@ -0,0 +1,201 @@
+package hook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	kubectlExec "k8s.io/client-go/util/exec"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// ReportPath is the path of the report of the hooks that were executed during the last run,
+// relative to the DevSpace root
+var ReportPath = filepath.Join(constants.DefaultCacheFolder, "hooks-last-run.json")
+
+// maxReportOutput is the maximum amount of output in bytes that is stored per hook execution
+const maxReportOutput = 32 * 1024
+
+// Report holds all hook executions of a single devspace run
+type Report struct {
+	Command   []string      `json:"command"`
+	StartTime time.Time     `json:"startTime"`
+	Hooks     []*HookReport `json:"hooks"`
+}
+
+// HookReport is the record of a single hook execution
+type HookReport struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Event      string        `json:"event"`
+	Background bool          `json:"background,omitempty"`
+	StartTime  time.Time     `json:"startTime"`
+	Duration   time.Duration `json:"duration"`
+	Attempts   int           `json:"attempts"`
+	ExitCode   int           `json:"exitCode"`
+	Error      string        `json:"error,omitempty"`
+	Output     string        `json:"output,omitempty"`
+}
+
+var (
+	reportMutex   sync.Mutex
+	reportEnabled bool
+	reportFile    string
+	report        *Report
+)
+
+// EnableReport enables recording of hook executions into the report in the given DevSpace
+// root. The report path is resolved once, because the working directory changes while
+// dependencies are executed. The first recorded hook execution replaces the report of the
+// previous run.
+func EnableReport(root string) {
+	reportMutex.Lock()
+	defer reportMutex.Unlock()
+
+	reportEnabled = true
+	reportFile = filepath.Join(root, ReportPath)
+	if !filepath.IsAbs(reportFile) {
+		absPath, err := filepath.Abs(reportFile)
+		if err == nil {
+			reportFile = absPath
+		}
+	}
+}
+
+// LoadReport loads the report of the last run from the given path
+func LoadReport(path string) (*Report, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	err = json.Unmarshal(data, report)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse hook report")
+	}
+
+	return report, nil
+}
+
+// recordHook adds the hook execution to the report and saves the report, so that it
+// is available even if devspace is interrupted
+func recordHook(hookReport *HookReport) {
+	reportMutex.Lock()
+	defer reportMutex.Unlock()
+
+	if !reportEnabled {
+		return
+	}
+	if report == nil {
+		report = &Report{
+			Command:   os.Args,
+			StartTime: hookReport.StartTime,
+		}
+	}
+
+	report.Hooks = append(report.Hooks, hookReport)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(reportFile), 0755)
+	if err == nil {
+		_ = ioutil.WriteFile(reportFile, data, 0666)
+	}
+}
+
+func newHookReport(hookConfig *latest.HookConfig, event string, startTime time.Time, attempts int, output *outputBuffer, err error) *HookReport {
+	hookReport := &HookReport{
+		Name:       hookName(hookConfig),
+		Type:       Type(hookConfig),
+		Event:      event,
+		Background: hookConfig.Background,
+		StartTime:  startTime,
+		Duration:   time.Since(startTime),
+		Attempts:   attempts,
+		ExitCode:   exitCode(err),
+		Output:     logpkg.Redact(output.String()),
+	}
+	if err != nil {
+		hookReport.Error = logpkg.Redact(err.Error())
+	}
+
+	return hookReport
+}
+
+// Type returns the type of the hook, which is one of local, remote, upload, download, logs, wait, job or webhook
+func Type(hook *latest.HookConfig) string {
+	if hook.Webhook != nil {
+		return "webhook"
+	} else if hook.Job != nil {
+		return "job"
+	} else if hook.Container == nil {
+		return "local"
+	} else if hook.Upload != nil {
+		return "upload"
+	} else if hook.Download != nil {
+		return "download"
+	} else if hook.Logs != nil {
+		return "logs"
+	} else if hook.Wait != nil {
+		return "wait"
+	}
+
+	return "remote"
+}
+
+// exitCode returns the exit code of the hook command or 1 if the hook failed otherwise
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if status, ok := interp.IsExitStatus(errors.Cause(err)); ok {
+		return int(status)
+	}
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode()
+	}
+	var codeExitError kubectlExec.CodeExitError
+	if errors.As(err, &codeExitError) {
+		return codeExitError.Code
+	}
+
+	return 1
+}
+
+// outputBuffer captures the last bytes written by a hook
+type outputBuffer struct {
+	m    sync.Mutex
+	data []byte
+}
+
+func (o *outputBuffer) Write(p []byte) (int, error) {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	o.data = append(o.data, p...)
+	if len(o.data) > maxReportOutput {
+		o.data = o.data[len(o.data)-maxReportOutput:]
+	}
+
+	return len(p), nil
+}
+
+func (o *outputBuffer) String() string {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	return string(o.data)
+}
@@ -0,0 +1,74 @@
+package hook
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+func TestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	EnableReport(dir)
+	defer func() {
+		reportMutex.Lock()
+		reportEnabled = false
+		report = nil
+		reportMutex.Unlock()
+	}()
+
+	// the report is saved in the root even if the working directory changes
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	defer func() { _ = os.Chdir(wd) }()
+	assert.NilError(t, os.Chdir(os.TempDir()))
+
+	conf := config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Name:    "greet",
+				Events:  []string{"my-event"},
+				Command: "echo hello",
+				Silent:  true,
+			},
+			{
+				Name:    "fail",
+				Events:  []string{"my-event"},
+				Command: "exit 3",
+			},
+		},
+	}, nil, nil, constants.DefaultConfigPath)
+	err = ExecuteHooks(nil, conf, nil, nil, log.Discard, "my-event")
+	assert.ErrorContains(t, err, "fail")
+
+	lastRun, err := LoadReport(filepath.Join(dir, ReportPath))
+	assert.NilError(t, err)
+	assert.Equal(t, len(lastRun.Hooks), 2)
+	assert.Equal(t, lastRun.Hooks[0].Name, "greet")
+	assert.Equal(t, lastRun.Hooks[0].Type, "local")
+	assert.Equal(t, lastRun.Hooks[0].Event, "my-event")
+	assert.Equal(t, lastRun.Hooks[0].ExitCode, 0)
+	assert.Equal(t, lastRun.Hooks[0].Output, "hello\n")
+	assert.Equal(t, lastRun.Hooks[1].Name, "fail")
+	assert.Equal(t, lastRun.Hooks[1].ExitCode, 3)
+	assert.Assert(t, lastRun.Hooks[1].Error != "")
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, exitCode(nil), 0)
+	assert.Equal(t, exitCode(errors.New("failed")), 1)
+	assert.Equal(t, exitCode(errors.Wrap(kubectlExec.CodeExitError{Err: errors.New("failed"), Code: 42}, "error in container")), 42)
+}
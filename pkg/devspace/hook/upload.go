@@ -2,7 +2,9 @@ package hook
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"context"
 	"io"
 	"io/ioutil"
 	"os"
@@ -26,7 +28,7 @@ func NewUploadHook() RemoteHook {
 
 type remoteUploadHook struct{}
 
-func (r *remoteUploadHook) ExecuteRemotely(hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
+func (r *remoteUploadHook) ExecuteRemotely(ctx context.Context, hook *latest.HookConfig, podContainer *selector.SelectedPodContainer, client kubectl.Client, config config.Config, dependencies []types.Dependency, log logpkg.Logger) error {
 	containerPath := "."
 	if hook.Upload.ContainerPath != "" {
 		containerPath = hook.Upload.ContainerPath
@@ -41,14 +43,14 @@ func (r *remoteUploadHook) ExecuteRemotely(hook *latest.HookConfig, podContainer
 	// Make sure the target folder exists
 	destDir := path.Dir(containerPath)
 	if len(destDir) > 0 {
-		_, stderr, err := client.ExecBuffered(podContainer.Pod, podContainer.Container.Name, []string{"mkdir", "-p", destDir}, nil)
+		stderr, err := execBuffered(ctx, client, podContainer.Pod, podContainer.Container.Name, []string{"mkdir", "-p", destDir}, nil)
 		if err != nil {
-			return errors.Errorf("error in container '%s/%s/%s': %v: %s", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err, string(stderr))
+			return errors.Errorf("error in container '%s/%s/%s': %v: %s", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err, stderr)
 		}
 	}
 
 	// Upload the files
-	err := upload(client, podContainer.Pod, podContainer.Container.Name, localPath, containerPath)
+	err := upload(ctx, client, podContainer.Pod, podContainer.Container.Name, localPath, containerPath)
 	if err != nil {
 		return errors.Errorf("error in container '%s/%s/%s': %v", podContainer.Pod.Namespace, podContainer.Pod.Name, podContainer.Container.Name, err)
 	}
@@ -56,13 +58,13 @@ func (r *remoteUploadHook) ExecuteRemotely(hook *latest.HookConfig, podContainer
 	return nil
 }
 
-func upload(client kubectl.Client, pod *v1.Pod, container string, localPath string, containerPath string) error {
+func upload(ctx context.Context, client kubectl.Client, pod *v1.Pod, container string, localPath string, containerPath string) error {
 	// do the actual copy
 	reader, writer := io.Pipe()
 	errorChan := make(chan error)
 	go func() {
 		defer reader.Close()
-		errorChan <- uploadFromReader(client, pod, container, containerPath, reader)
+		errorChan <- uploadFromReader(ctx, client, pod, container, containerPath, reader)
 	}()
 	go func() {
 		defer writer.Close()
@@ -74,17 +76,17 @@ func upload(client kubectl.Client, pod *v1.Pod, container string, localPath stri
 	return err
 }
 
-func uploadFromReader(client kubectl.Client, pod *v1.Pod, container, containerPath string, reader io.Reader) error {
+func uploadFromReader(ctx context.Context, client kubectl.Client, pod *v1.Pod, container, containerPath string, reader io.Reader) error {
 	cmd := []string{"tar", "xzp"}
 	destDir := path.Dir(containerPath)
 	if len(destDir) > 0 {
 		cmd = append(cmd, "-C", destDir)
 	}
 
-	_, stderr, err := client.ExecBuffered(pod, container, cmd, reader)
+	stderr, err := execBuffered(ctx, client, pod, container, cmd, reader)
 	if err != nil {
-		if stderr != nil {
-			return errors.Errorf("error executing tar: %s: %v", string(stderr), err)
+		if stderr != "" {
+			return errors.Errorf("error executing tar: %s: %v", stderr, err)
 		}
 
 		return errors.Wrap(err, "exec")
@@ -93,6 +95,22 @@ func uploadFromReader(client kubectl.Client, pod *v1.Pod, container, containerPa
 	return nil
 }
 
+// execBuffered executes the command in the container until it finishes or the context is done
+// and returns the stderr output
+func execBuffered(ctx context.Context, client kubectl.Client, pod *v1.Pod, container string, command []string, stdin io.Reader) (string, error) {
+	stderr := &bytes.Buffer{}
+	err := client.ExecStream(&kubectl.ExecStreamOptions{
+		Context:   ctx,
+		Pod:       pod,
+		Container: container,
+		Command:   command,
+		Stdin:     stdin,
+		Stdout:    ioutil.Discard,
+		Stderr:    stderr,
+	})
+	return stderr.String(), err
+}
+
 func makeTar(srcPath, destPath string, writer io.Writer) error {
 	gw := gzip.NewWriter(writer)
 	defer gw.Close()
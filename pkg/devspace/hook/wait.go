@@ -28,7 +28,7 @@ type waitHook struct {
 	printWarning sync.Once
 }
 
-func (r *waitHook) Execute(hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
+func (r *waitHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
 	if client == nil {
 		return errors.Errorf("Cannot execute hook '%s': kube client is not initialized", ansi.Color(hookName(hook), "white+b"))
 	}
@@ -52,7 +52,7 @@ func (r *waitHook) Execute(hook *latest.HookConfig, client kubectl.Client, confi
 		}
 	}
 
-	err = r.execute(hook, client, imageSelectors, log)
+	err = r.execute(ctx, hook, client, imageSelectors, log)
 	if err != nil {
 		return err
 	}
@@ -61,7 +61,7 @@ func (r *waitHook) Execute(hook *latest.HookConfig, client kubectl.Client, confi
 	return nil
 }
 
-func (r *waitHook) execute(hook *latest.HookConfig, client kubectl.Client, imageSelector []imageselector.ImageSelector, log logpkg.Logger) error {
+func (r *waitHook) execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, imageSelector []imageselector.ImageSelector, log logpkg.Logger) error {
 	labelSelector := ""
 	if len(hook.Container.LabelSelector) > 0 {
 		labelSelector = labels.Set(hook.Container.LabelSelector).String()
@@ -74,7 +74,7 @@ func (r *waitHook) execute(hook *latest.HookConfig, client kubectl.Client, image
 
 	// wait until the defined condition will be true, this will wait initially 2 seconds
 	err := wait.Poll(time.Second*2, time.Duration(timeout)*time.Second, func() (done bool, err error) {
-		podContainers, err := selector.NewFilter(client).SelectContainers(context.TODO(), selector.Selector{
+		podContainers, err := selector.NewFilter(client).SelectContainers(ctx, selector.Selector{
 			ImageSelector: imageSelector,
 			LabelSelector: labelSelector,
 			Pod:           hook.Container.Pod,
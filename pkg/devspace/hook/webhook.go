@@ -0,0 +1,140 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// webhookTimeout is the maximum duration of a webhook request
+var webhookTimeout = 30 * time.Second
+
+// WebhookData is the data that is sent by a webhook hook and can be used within the body template
+type WebhookData struct {
+	// Event is the event that triggered the hook, e.g. after:deploy:backend
+	Event string `json:"event"`
+	// Data holds the hook data without the DEVSPACE_HOOK_ prefix in lower case,
+	// e.g. deploy_name, deploy_config, image_name, image_tags or error
+	Data map[string]interface{} `json:"data"`
+}
+
+// NewWebhookHook creates a new hook that sends a http request
+func NewWebhookHook() Hook {
+	return &webhookHook{}
+}
+
+type webhookHook struct{}
+
+func (w *webhookHook) Execute(ctx context.Context, hook *latest.HookConfig, client kubectl.Client, config config.Config, dependencies []types.Dependency, extraEnv map[string]string, log logpkg.Logger) error {
+	data := newWebhookData(extraEnv)
+	body, err := webhookBody(hook.Webhook.Body, data)
+	if err != nil {
+		return err
+	}
+
+	method := http.MethodPost
+	if hook.Webhook.Method != "" {
+		method = strings.ToUpper(hook.Webhook.Method)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, hook.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range hook.Webhook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Errorf("send request: %s", logpkg.Redact(err.Error()))
+	}
+	defer resp.Body.Close()
+
+	out, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(logpkg.Redact(string(out))))
+	}
+
+	log.Donef("Hook '%s' successfully executed", ansi.Color(hookName(hook), "white+b"))
+	return nil
+}
+
+// newWebhookData converts the hook environment variables to the webhook data. Values that
+// contain json objects or arrays are decoded, so that they are not sent as strings.
+func newWebhookData(extraEnv map[string]string) *WebhookData {
+	data := &WebhookData{
+		Data: map[string]interface{}{},
+	}
+	for name, value := range extraEnv {
+		if name == "DEVSPACE_HOOK_EVENT" {
+			data.Event = value
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimPrefix(name, "DEVSPACE_HOOK_"))
+		data.Data[key] = value
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded interface{}
+			if json.Unmarshal([]byte(trimmed), &decoded) == nil {
+				data.Data[key] = decoded
+			}
+		}
+	}
+
+	return data
+}
+
+func webhookBody(bodyTemplate string, data *WebhookData) ([]byte, error) {
+	if bodyTemplate == "" {
+		return json.Marshal(data)
+	}
+
+	t, err := template.New("body").Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			out, err := json.Marshal(value)
+			return string(out), err
+		},
+	}).Option("missingkey=zero").Parse(bodyTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse webhook body")
+	}
+
+	body := &bytes.Buffer{}
+	err = t.Execute(body, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute webhook body")
+	}
+
+	return body.Bytes(), nil
+}
+
+func webhookName(webhook *latest.HookWebhookConfig) string {
+	// only the host is shown, because the url might contain tokens
+	u, err := url.Parse(webhook.URL)
+	if err != nil || u.Host == "" {
+		return "webhook"
+	}
+
+	return fmt.Sprintf("webhook %s", u.Host)
+}
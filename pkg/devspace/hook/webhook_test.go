@@ -0,0 +1,89 @@
+package hook
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+type webhookRequest struct {
+	method string
+	header http.Header
+	body   string
+}
+
+func TestWebhookHook(t *testing.T) {
+	requests := []webhookRequest{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, webhookRequest{method: r.Method, header: r.Header, body: string(body)})
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("bot unavailable"))
+	}))
+	defer server.Close()
+
+	conf := config.NewConfig(nil, &latest.Config{
+		Hooks: []*latest.HookConfig{
+			{
+				Events: []string{"error:deploy"},
+				Webhook: &latest.HookWebhookConfig{
+					URL: server.URL + "/events",
+				},
+			},
+			{
+				Events: []string{"after:deploy"},
+				Webhook: &latest.HookWebhookConfig{
+					URL:     server.URL + "/chat",
+					Method:  "put",
+					Headers: map[string]string{"Authorization": "Bearer token"},
+					Body:    `{"text": {{ json (printf "deployed %s" .Data.deploy_name) }}, "config": {{ json .Data.deploy_config }}}`,
+				},
+			},
+		},
+	}, nil, nil, constants.DefaultConfigPath)
+
+	// default body
+	err := ExecuteHooks(nil, conf, nil, map[string]interface{}{
+		"DEPLOY_NAME":   "backend",
+		"DEPLOY_CONFIG": `{"namespace": "test"}`,
+		"ERROR":         errors.New("deploy failed"),
+	}, log.Discard, "error:deploy")
+	assert.NilError(t, err)
+	assert.Equal(t, len(requests), 1)
+	assert.Equal(t, requests[0].method, http.MethodPost)
+	assert.Equal(t, requests[0].header.Get("Content-Type"), "application/json")
+
+	data := &WebhookData{}
+	err = json.Unmarshal([]byte(requests[0].body), data)
+	assert.NilError(t, err)
+	assert.Equal(t, data.Event, "error:deploy")
+	assert.Equal(t, data.Data["deploy_name"], "backend")
+	assert.Equal(t, data.Data["error"], "deploy failed")
+	assert.DeepEqual(t, data.Data["deploy_config"], map[string]interface{}{"namespace": "test"})
+
+	// templated body
+	err = ExecuteHooks(nil, conf, nil, map[string]interface{}{
+		"DEPLOY_NAME":   `my "backend"`,
+		"DEPLOY_CONFIG": `{"namespace": "test"}`,
+	}, log.Discard, "after:deploy")
+	assert.NilError(t, err)
+	assert.Equal(t, len(requests), 2)
+	assert.Equal(t, requests[1].method, http.MethodPut)
+	assert.Equal(t, requests[1].header.Get("Authorization"), "Bearer token")
+	assert.Equal(t, requests[1].body, `{"text": "deployed my \"backend\"", "config": {"namespace":"test"}}`)
+
+	// failed request
+	status = http.StatusInternalServerError
+	err = ExecuteHooks(nil, conf, nil, nil, log.Discard, "after:deploy")
+	assert.ErrorContains(t, err, "request failed with status 500: bot unavailable")
+}
@@ -2,6 +2,7 @@ package kubectl
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 
@@ -112,6 +113,10 @@ func (client *client) ExecStreamWithTransport(options *ExecStreamWithTransportOp
 
 // ExecStreamOptions are the options for ExecStream
 type ExecStreamOptions struct {
+	// Context stops the exec by closing the connection when it is done. Closing the connection
+	// doesn't necessarily terminate the command in the container.
+	Context context.Context
+
 	Pod *corev1.Pod
 
 	Container string
@@ -133,12 +138,33 @@ func (client *client) ExecStream(options *ExecStreamOptions) error {
 		return err
 	}
 
-	return client.ExecStreamWithTransport(&ExecStreamWithTransportOptions{
+	// closed is set if the connection was closed because the context is done
+	closed := make(chan struct{})
+	if options.Context != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-options.Context.Done():
+				close(closed)
+				_ = upgradeRoundTripper.Close()
+			case <-done:
+			}
+		}()
+	}
+
+	err = client.ExecStreamWithTransport(&ExecStreamWithTransportOptions{
 		ExecStreamOptions: *options,
 		Transport:         wrapper,
 		Upgrader:          upgradeRoundTripper,
 		SubResource:       SubResourceExec,
 	})
+	select {
+	case <-closed:
+		return options.Context.Err()
+	default:
+		return err
+	}
 }
 
 // ExecBuffered executes a command for kubernetes and returns the output and error buffers
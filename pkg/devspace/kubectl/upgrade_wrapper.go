@@ -2,6 +2,7 @@ package kubectl
 
 import (
 	"net/http"
+	"sync"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/httpstream"
@@ -17,6 +18,9 @@ type UpgraderWrapper interface {
 type upgraderWrapper struct {
 	Upgrader    clientspdy.Upgrader
 	Connections []httpstream.Connection
+
+	connectionsMutex sync.Mutex
+	closed           bool
 }
 
 // NewConnection receives a new connection
@@ -26,12 +30,24 @@ func (uw *upgraderWrapper) NewConnection(resp *http.Response) (httpstream.Connec
 		return nil, err
 	}
 
+	uw.connectionsMutex.Lock()
+	defer uw.connectionsMutex.Unlock()
+
+	// connections that are established after close are closed right away
+	if uw.closed {
+		_ = conn.Close()
+	}
+
 	uw.Connections = append(uw.Connections, conn)
 	return conn, nil
 }
 
 // Close closes all connections
 func (uw *upgraderWrapper) Close() error {
+	uw.connectionsMutex.Lock()
+	defer uw.connectionsMutex.Unlock()
+
+	uw.closed = true
 	errs := []error{}
 	for _, conn := range uw.Connections {
 		err := conn.Close()
@@ -0,0 +1,598 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin/pluginpb"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// ProtocolVersion is the version of the grpc plugin protocol
+	ProtocolVersion = 1
+
+	// ProtocolVersionEnv tells the plugin binary which protocol version DevSpace speaks
+	ProtocolVersionEnv = "DEVSPACE_PLUGIN_PROTOCOL_VERSION"
+
+	// tokenMetadataKey is the grpc metadata key of the token that authenticates calls to a plugin
+	tokenMetadataKey = "devspace-plugin-token"
+)
+
+// handshakeTimeout is the time DevSpace waits for a plugin to print its address
+var handshakeTimeout = 10 * time.Second
+
+// callTimeout is the maximum duration of calls that return a single response, e.g. Configure
+var callTimeout = time.Minute
+
+// defaultStreamTimeout is the maximum duration of calls that stream output, e.g. Build, if the
+// plugin doesn't specify grpc.timeout
+var defaultStreamTimeout = 30 * time.Minute
+
+var grpcPluginsLock sync.Mutex
+var grpcPlugins = map[string]*GRPCPlugin{}
+
+// GRPCPlugin is a started long-lived plugin that is called over grpc
+type GRPCPlugin struct {
+	Metadata Metadata
+
+	cmd         *exec.Cmd
+	conn        *grpc.ClientConn
+	client      pluginpb.PluginClient
+	description *pluginpb.DescribeResponse
+
+	streamTimeout time.Duration
+
+	// configured is the revision of the plugin context that was sent to the plugin
+	configuredLock sync.Mutex
+	configured     int
+}
+
+// NewGRPCPlugin creates a plugin for an already established connection and checks
+// that the plugin speaks the same protocol version
+func NewGRPCPlugin(metadata Metadata, conn *grpc.ClientConn) (*GRPCPlugin, error) {
+	streamTimeout := defaultStreamTimeout
+	if metadata.GRPC != nil && metadata.GRPC.Timeout != "" {
+		timeout, err := time.ParseDuration(metadata.GRPC.Timeout)
+		if err != nil {
+			return nil, errors.Errorf("parse grpc.timeout of plugin %s: %v", metadata.Name, err)
+		}
+
+		streamTimeout = timeout
+	}
+
+	client := pluginpb.NewPluginClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	description, err := client.Describe(ctx, &pluginpb.DescribeRequest{ProtocolVersion: ProtocolVersion})
+	if err != nil {
+		return nil, errors.Errorf("describe plugin %s: %s", metadata.Name, status.Convert(err).Message())
+	} else if description.ProtocolVersion != ProtocolVersion {
+		return nil, errors.Errorf("plugin %s uses protocol version %d, but DevSpace requires version %d", metadata.Name, description.ProtocolVersion, ProtocolVersion)
+	}
+
+	return &GRPCPlugin{
+		Metadata:    metadata,
+		conn:        conn,
+		client:      client,
+		description: description,
+
+		streamTimeout: streamTimeout,
+		configured:    -1,
+	}, nil
+}
+
+// IsGRPC returns true if the plugin runs in the long-lived grpc mode
+func IsGRPC(plugin Metadata) bool {
+	return plugin.GRPC != nil
+}
+
+// GetGRPCPlugin returns the started plugin or starts the plugin binary if it is not running yet
+func GetGRPCPlugin(plugin Metadata) (*GRPCPlugin, error) {
+	grpcPluginsLock.Lock()
+	defer grpcPluginsLock.Unlock()
+
+	if p, ok := grpcPlugins[plugin.Name]; ok {
+		return p, nil
+	}
+
+	p, err := startGRPCPlugin(plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcPlugins[plugin.Name] = p
+	return p, nil
+}
+
+// StopGRPCPlugins stops all started plugins
+func StopGRPCPlugins() {
+	grpcPluginsLock.Lock()
+	defer grpcPluginsLock.Unlock()
+
+	for name, p := range grpcPlugins {
+		p.Stop()
+		delete(grpcPlugins, name)
+	}
+}
+
+// FindBuilder returns the plugin that contributes the builder with the given name
+func FindBuilder(name string) (*GRPCPlugin, error) {
+	return find("builder", name, func(description *pluginpb.DescribeResponse) []string {
+		return description.Builders
+	})
+}
+
+// FindDeployer returns the plugin that contributes the deployer with the given name
+func FindDeployer(name string) (*GRPCPlugin, error) {
+	return find("deployer", name, func(description *pluginpb.DescribeResponse) []string {
+		return description.Deployers
+	})
+}
+
+func find(kind, name string, names func(description *pluginpb.DescribeResponse) []string) (*GRPCPlugin, error) {
+	for _, plugin := range plugins {
+		if !IsGRPC(plugin) {
+			continue
+		}
+
+		p, err := GetGRPCPlugin(plugin)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range names(p.description) {
+			if n == name {
+				return p, nil
+			}
+		}
+	}
+
+	return nil, errors.Errorf("couldn't find a plugin that provides the %s %s", kind, name)
+}
+
+func startGRPCPlugin(plugin Metadata) (*GRPCPlugin, error) {
+	env := os.Environ()
+	pluginContextLock.Lock()
+	for k, v := range pluginContext {
+		env = append(env, k+"="+v)
+	}
+	pluginContextLock.Unlock()
+	env = append(env, ProtocolVersionEnv+"="+strconv.Itoa(ProtocolVersion))
+
+	cmd := exec.Command(filepath.Join(plugin.PluginFolder, PluginBinary), plugin.GRPC.BaseArgs...)
+	cmd.Env = env
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	// the plugin stops itself when stdin is closed, which happens when DevSpace exits
+	_, err = cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, errors.Errorf("start plugin %s: %v", plugin.Name, err)
+	}
+
+	network, address, token, err := readHandshake(plugin.Name, stdout)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, stdout)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithPerRPCCredentials(pluginToken(token)), grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}))
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, errors.Errorf("connect to plugin %s: %v", plugin.Name, err)
+	}
+
+	p, err := NewGRPCPlugin(plugin, conn)
+	if err != nil {
+		_ = conn.Close()
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+
+	p.cmd = cmd
+	return p, nil
+}
+
+// readHandshake reads the first line of the plugin output, which has the format
+// protocol-version|network|address|token, e.g. 1|tcp|127.0.0.1:1234|5f2b...
+func readHandshake(name string, stdout io.Reader) (string, string, string, error) {
+	lineChan := make(chan string, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(stdout).ReadString('\n')
+		if err != nil {
+			errChan <- err
+			return
+		}
+
+		lineChan <- strings.TrimSpace(line)
+	}()
+
+	select {
+	case line := <-lineChan:
+		parts := strings.Split(line, "|")
+		if len(parts) < 3 || len(parts) > 4 {
+			return "", "", "", errors.Errorf("plugin %s printed an unexpected handshake: %s", name, line)
+		} else if parts[0] != strconv.Itoa(ProtocolVersion) {
+			return "", "", "", errors.Errorf("plugin %s uses protocol version %s, but DevSpace requires version %d", name, parts[0], ProtocolVersion)
+		} else if len(parts) != 4 || parts[3] == "" {
+			return "", "", "", errors.Errorf("plugin %s printed a handshake without a token", name)
+		}
+
+		return parts[1], parts[2], parts[3], nil
+	case err := <-errChan:
+		return "", "", "", errors.Errorf("plugin %s exited before the handshake: %v", name, err)
+	case <-time.After(handshakeTimeout):
+		return "", "", "", errors.Errorf("timed out waiting for the handshake of plugin %s", name)
+	}
+}
+
+// pluginToken sends the token of the plugin handshake with every call
+type pluginToken string
+
+func (p pluginToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{tokenMetadataKey: string(p)}, nil
+}
+
+func (p pluginToken) RequireTransportSecurity() bool {
+	return false
+}
+
+// Stop closes the connection and stops the plugin binary
+func (p *GRPCPlugin) Stop() {
+	_ = p.conn.Close()
+	if p.cmd != nil && p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+		_ = p.cmd.Wait()
+	}
+}
+
+// SubscribedTo returns true if the plugin wants to receive the event
+func (p *GRPCPlugin) SubscribedTo(event string) bool {
+	for _, e := range p.description.Events {
+		if e == event || e == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleEvent sends the event with the data to the plugin
+func (p *GRPCPlugin) HandleEvent(event string, data map[string]string, log log.Logger) error {
+	err := p.configure()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.streamTimeout)
+	defer cancel()
+	stream, err := p.client.HandleEvent(ctx, &pluginpb.EventRequest{Event: event, Data: data})
+	if err != nil {
+		return p.wrap(err)
+	}
+
+	_, err = p.receive(stream, log)
+	return err
+}
+
+// ResolveVariable returns the value of a variable of the plugin
+func (p *GRPCPlugin) ResolveVariable(name string) (string, error) {
+	err := p.configure()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	response, err := p.client.ResolveVariable(ctx, &pluginpb.VariableRequest{Name: name})
+	if err != nil {
+		return "", p.wrap(err)
+	}
+
+	return response.Value, nil
+}
+
+// Build builds an image with the builder of the plugin
+func (p *GRPCPlugin) Build(request *pluginpb.BuildRequest, log log.Logger) error {
+	err := p.configure()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.streamTimeout)
+	defer cancel()
+	stream, err := p.client.Build(ctx, request)
+	if err != nil {
+		return p.wrap(err)
+	}
+
+	_, err = p.receive(stream, log)
+	return err
+}
+
+// Deploy deploys the deployment with the deployer of the plugin and returns true if the
+// deployment was deployed
+func (p *GRPCPlugin) Deploy(request *pluginpb.DeployRequest, log log.Logger) (bool, error) {
+	err := p.configure()
+	if err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.streamTimeout)
+	defer cancel()
+	stream, err := p.client.Deploy(ctx, request)
+	if err != nil {
+		return false, p.wrap(err)
+	}
+
+	result, err := p.receive(stream, log)
+	if err != nil {
+		return false, err
+	}
+
+	return result.deployed, nil
+}
+
+// Render writes the rendered deployment of the deployer of the plugin to out
+func (p *GRPCPlugin) Render(request *pluginpb.DeployRequest, out io.Writer, log log.Logger) error {
+	err := p.configure()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.streamTimeout)
+	defer cancel()
+	stream, err := p.client.Render(ctx, request)
+	if err != nil {
+		return p.wrap(err)
+	}
+
+	result, err := p.receive(stream, log)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(result.data)
+	return err
+}
+
+// Purge removes the deployment with the deployer of the plugin
+func (p *GRPCPlugin) Purge(request *pluginpb.DeployRequest, log log.Logger) error {
+	err := p.configure()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.streamTimeout)
+	defer cancel()
+	stream, err := p.client.Purge(ctx, request)
+	if err != nil {
+		return p.wrap(err)
+	}
+
+	_, err = p.receive(stream, log)
+	return err
+}
+
+// Status returns the status of the deployment
+func (p *GRPCPlugin) Status(request *pluginpb.DeployRequest) (*pluginpb.StatusResponse, error) {
+	err := p.configure()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	response, err := p.client.Status(ctx, request)
+	if err != nil {
+		return nil, p.wrap(err)
+	}
+
+	return response, nil
+}
+
+// configure sends the resolved config and the command to the plugin if they changed since
+// the last call
+func (p *GRPCPlugin) configure() error {
+	p.configuredLock.Lock()
+	defer p.configuredLock.Unlock()
+
+	pluginContextLock.Lock()
+	revision := pluginContextRevision
+	request := &pluginpb.ConfigureRequest{
+		Config:      pluginContext[ConfigEnv],
+		ConfigPath:  pluginContext[ConfigPathEnv],
+		Variables:   pluginContext[ConfigVarsEnv],
+		KubeContext: pluginContext[KubeContextFlagEnv],
+		Namespace:   pluginContext[KubeNamespaceFlagEnv],
+		Command:     pluginContext[CommandEnv],
+	}
+	if pluginContext[CommandArgsEnv] != "" {
+		_ = json.Unmarshal([]byte(pluginContext[CommandArgsEnv]), &request.Args)
+	}
+	pluginContextLock.Unlock()
+
+	if p.configured == revision {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+	_, err := p.client.Configure(ctx, request)
+	if err != nil {
+		return p.wrap(err)
+	}
+
+	p.configured = revision
+	return nil
+}
+
+type outputStream interface {
+	Recv() (*pluginpb.Output, error)
+}
+
+type outputResult struct {
+	data     []byte
+	deployed bool
+}
+
+// receive prints the log messages of the stream with the logger until the stream ends
+func (p *GRPCPlugin) receive(stream outputStream, log log.Logger) (*outputResult, error) {
+	result := &outputResult{}
+	for {
+		output, err := stream.Recv()
+		if err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return nil, p.wrap(err)
+		}
+
+		result.data = append(result.data, output.Data...)
+		result.deployed = result.deployed || output.Deployed
+		if output.Message == "" {
+			continue
+		}
+
+		switch output.LogLevel {
+		case pluginpb.LogLevel_DEBUG:
+			log.Debug(output.Message)
+		case pluginpb.LogLevel_WARN:
+			log.Warn(output.Message)
+		case pluginpb.LogLevel_ERROR:
+			log.Error(output.Message)
+		case pluginpb.LogLevel_DONE:
+			log.Done(output.Message)
+		default:
+			log.Info(output.Message)
+		}
+	}
+}
+
+func (p *GRPCPlugin) wrap(err error) error {
+	if status.Code(err) == codes.DeadlineExceeded {
+		return fmt.Errorf("plugin %s: call timed out", p.Metadata.Name)
+	}
+
+	return fmt.Errorf("plugin %s: %s", p.Metadata.Name, status.Convert(err).Message())
+}
+
+// executeGRPCPluginHooks sends the events to all grpc plugins that subscribed to them
+func executeGRPCPluginHooks(data map[string]string, events ...string) error {
+	for _, plugin := range plugins {
+		if !IsGRPC(plugin) {
+			continue
+		}
+
+		p, err := GetGRPCPlugin(plugin)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			if !p.SubscribedTo(e) {
+				continue
+			}
+
+			err = p.HandleEvent(e, data, log.GetInstance())
+			if err != nil {
+				return fmt.Errorf("error calling plugin hook %s at event %s: %v", plugin.Name, e, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Serve starts the grpc server of a plugin and prints the handshake DevSpace waits for. It is
+// meant to be called from the main function of a plugin binary and returns when DevSpace exits.
+// The handshake contains a random token and calls without it are rejected, so that other local
+// processes cannot call the plugin.
+func Serve(server pluginpb.PluginServer) error {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return errors.Wrap(err, "generate plugin token")
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+
+	s := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}), grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := authenticate(stream.Context(), token)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}))
+	pluginpb.RegisterPluginServer(s, server)
+	go func() {
+		// DevSpace closes stdin of the plugin when it exits
+		_, _ = io.Copy(ioutil.Discard, os.Stdin)
+		s.Stop()
+	}()
+
+	fmt.Printf("%d|tcp|%s|%s\n", ProtocolVersion, listener.Addr().String(), token)
+	return s.Serve(listener)
+}
+
+// authenticate returns an error if the call does not contain the token of the plugin
+func authenticate(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(tokenMetadataKey)
+	if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid plugin token")
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/plugin/pluginpb"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+type fakePluginServer struct {
+	pluginpb.UnimplementedPluginServer
+
+	protocolVersion int32
+	configured      []*pluginpb.ConfigureRequest
+	events          []*pluginpb.EventRequest
+}
+
+func (f *fakePluginServer) Describe(ctx context.Context, request *pluginpb.DescribeRequest) (*pluginpb.DescribeResponse, error) {
+	return &pluginpb.DescribeResponse{
+		ProtocolVersion: f.protocolVersion,
+		Events:          []string{"after:deploy"},
+		Builders:        []string{"fake-builder"},
+		Deployers:       []string{"fake"},
+	}, nil
+}
+
+func (f *fakePluginServer) Configure(ctx context.Context, request *pluginpb.ConfigureRequest) (*pluginpb.Empty, error) {
+	f.configured = append(f.configured, request)
+	return &pluginpb.Empty{}, nil
+}
+
+func (f *fakePluginServer) HandleEvent(request *pluginpb.EventRequest, stream pluginpb.Plugin_HandleEventServer) error {
+	f.events = append(f.events, request)
+	return stream.Send(&pluginpb.Output{LogLevel: pluginpb.LogLevel_WARN, Message: "received " + request.Event})
+}
+
+func (f *fakePluginServer) Deploy(request *pluginpb.DeployRequest, stream pluginpb.Plugin_DeployServer) error {
+	err := stream.Send(&pluginpb.Output{Message: "deploying " + request.Name + " with " + request.BuiltImages["api"]})
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&pluginpb.Output{LogLevel: pluginpb.LogLevel_DONE, Message: "deployed", Deployed: true})
+}
+
+func (f *fakePluginServer) Render(request *pluginpb.DeployRequest, stream pluginpb.Plugin_RenderServer) error {
+	err := stream.Send(&pluginpb.Output{Data: []byte("kind: ")})
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&pluginpb.Output{Data: []byte("Deployment")})
+}
+
+// Purge blocks until the call is canceled
+func (f *fakePluginServer) Purge(request *pluginpb.DeployRequest, stream pluginpb.Plugin_PurgeServer) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func startFakePlugin(t *testing.T, server *fakePluginServer) *grpc.ClientConn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	s := grpc.NewServer()
+	pluginpb.RegisterPluginServer(s, server)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	assert.NilError(t, err)
+	return conn
+}
+
+func TestGRPCPlugin(t *testing.T) {
+	server := &fakePluginServer{protocolVersion: ProtocolVersion}
+	p, err := NewGRPCPlugin(Metadata{Name: "fake"}, startFakePlugin(t, server))
+	assert.NilError(t, err)
+	defer p.Stop()
+
+	assert.Assert(t, p.SubscribedTo("after:deploy"))
+	assert.Assert(t, !p.SubscribedTo("before:deploy"))
+
+	// events
+	out := &bytes.Buffer{}
+	logger := log.NewStreamLogger(out, logrus.InfoLevel)
+	err = p.HandleEvent("after:deploy", map[string]string{"DEVSPACE_PLUGIN_DEPLOY_NAME": "api"}, logger)
+	assert.NilError(t, err)
+	assert.Equal(t, len(server.events), 1)
+	assert.Equal(t, server.events[0].Data["DEVSPACE_PLUGIN_DEPLOY_NAME"], "api")
+	assert.Assert(t, strings.Contains(out.String(), "received after:deploy"), out.String())
+
+	// deployers
+	out.Reset()
+	deployed, err := p.Deploy(&pluginpb.DeployRequest{Deployer: "fake", Name: "api", BuiltImages: map[string]string{"api": "api:abc"}}, logger)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+	assert.Assert(t, strings.Contains(out.String(), "deploying api with api:abc"), out.String())
+	assert.Assert(t, strings.Contains(out.String(), "deployed"), out.String())
+
+	rendered := &bytes.Buffer{}
+	err = p.Render(&pluginpb.DeployRequest{Deployer: "fake", Name: "api"}, rendered, logger)
+	assert.NilError(t, err)
+	assert.Equal(t, rendered.String(), "kind: Deployment")
+
+	// the config is only sent again if it changed
+	assert.Equal(t, len(server.configured), 1)
+	SetPluginKubeContext("my-context", "my-namespace")
+	err = p.HandleEvent("after:deploy", nil, logger)
+	assert.NilError(t, err)
+	assert.Equal(t, len(server.configured), 2)
+	assert.Equal(t, server.configured[1].KubeContext, "my-context")
+	assert.Equal(t, server.configured[1].Namespace, "my-namespace")
+
+	// unimplemented methods return an error
+	_, err = p.ResolveVariable("MY_VAR")
+	assert.ErrorContains(t, err, "plugin fake: method ResolveVariable not implemented")
+}
+
+func TestGRPCPluginProtocolVersion(t *testing.T) {
+	_, err := NewGRPCPlugin(Metadata{Name: "fake"}, startFakePlugin(t, &fakePluginServer{protocolVersion: 2}))
+	assert.ErrorContains(t, err, "plugin fake uses protocol version 2, but DevSpace requires version 1")
+}
+
+func TestReadHandshake(t *testing.T) {
+	network, address, token, err := readHandshake("fake", strings.NewReader("1|tcp|127.0.0.1:1234|secret\nother output"))
+	assert.NilError(t, err)
+	assert.Equal(t, network, "tcp")
+	assert.Equal(t, address, "127.0.0.1:1234")
+	assert.Equal(t, token, "secret")
+
+	_, _, _, err = readHandshake("fake", strings.NewReader("2|tcp|127.0.0.1:1234\n"))
+	assert.ErrorContains(t, err, "plugin fake uses protocol version 2")
+
+	_, _, _, err = readHandshake("fake", strings.NewReader("1|tcp|127.0.0.1:1234\n"))
+	assert.Error(t, err, "plugin fake printed a handshake without a token")
+
+	_, _, _, err = readHandshake("fake", strings.NewReader("Hello World\n"))
+	assert.ErrorContains(t, err, "unexpected handshake")
+
+	_, _, _, err = readHandshake("fake", strings.NewReader(""))
+	assert.ErrorContains(t, err, "exited before the handshake")
+}
+
+func TestGRPCPluginTimeout(t *testing.T) {
+	metadata := Metadata{Name: "fake", GRPC: &GRPC{Timeout: "100ms"}}
+	p, err := NewGRPCPlugin(metadata, startFakePlugin(t, &fakePluginServer{protocolVersion: ProtocolVersion}))
+	assert.NilError(t, err)
+	defer p.Stop()
+
+	err = p.Purge(&pluginpb.DeployRequest{Deployer: "fake", Name: "api"}, log.Discard)
+	assert.Error(t, err, "plugin fake: call timed out")
+
+	metadata.GRPC.Timeout = "soon"
+	_, err = NewGRPCPlugin(metadata, startFakePlugin(t, &fakePluginServer{protocolVersion: ProtocolVersion}))
+	assert.ErrorContains(t, err, "parse grpc.timeout of plugin fake")
+}
+
+// helperPluginEnv makes the test binary serve the fake plugin, so that TestStartGRPCPlugin
+// can start it as a real plugin binary
+const helperPluginEnv = "DEVSPACE_TEST_HELPER_PLUGIN"
+
+func TestHelperPlugin(t *testing.T) {
+	if os.Getenv(helperPluginEnv) != "1" {
+		return
+	}
+
+	err := Serve(&fakePluginServer{protocolVersion: ProtocolVersion})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func TestStartGRPCPlugin(t *testing.T) {
+	executable, err := os.Executable()
+	assert.NilError(t, err)
+
+	pluginFolder := t.TempDir()
+	err = os.Symlink(executable, filepath.Join(pluginFolder, PluginBinary))
+	assert.NilError(t, err)
+
+	os.Setenv(helperPluginEnv, "1")
+	defer os.Unsetenv(helperPluginEnv)
+
+	oldPlugins := plugins
+	defer func() { plugins = oldPlugins }()
+	plugins = []Metadata{
+		{Name: "hooks-only"},
+		{Name: "fake", PluginFolder: pluginFolder, GRPC: &GRPC{BaseArgs: []string{"-test.run=^TestHelperPlugin$"}}},
+	}
+	defer StopGRPCPlugins()
+
+	// the plugin binary is started once and shared by all lookups
+	deployer, err := FindDeployer("fake")
+	assert.NilError(t, err)
+	assert.Assert(t, deployer.cmd != nil)
+	builder, err := FindBuilder("fake-builder")
+	assert.NilError(t, err)
+	assert.Assert(t, builder == deployer)
+
+	_, err = FindBuilder("fake")
+	assert.Error(t, err, "couldn't find a plugin that provides the builder fake")
+	_, err = FindDeployer("other")
+	assert.Error(t, err, "couldn't find a plugin that provides the deployer other")
+
+	out := &bytes.Buffer{}
+	deployed, err := deployer.Deploy(&pluginpb.DeployRequest{Deployer: "fake", Name: "api"}, log.NewStreamLogger(out, logrus.InfoLevel))
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+	assert.Assert(t, strings.Contains(out.String(), "deploying api"), out.String())
+
+	// calls without the token of the handshake are rejected
+	conn, err := grpc.Dial(deployer.conn.Target(), grpc.WithInsecure())
+	assert.NilError(t, err)
+	defer conn.Close()
+	_, err = pluginpb.NewPluginClient(conn).Describe(context.Background(), &pluginpb.DescribeRequest{})
+	assert.Equal(t, status.Code(err), codes.Unauthenticated)
+
+	// stopping kills the plugin binary and the next lookup starts it again
+	StopGRPCPlugins()
+	assert.Assert(t, deployer.cmd.ProcessState != nil)
+	restarted, err := FindDeployer("fake")
+	assert.NilError(t, err)
+	assert.Assert(t, restarted != deployer)
+	assert.Assert(t, restarted.cmd.Process.Pid != deployer.cmd.Process.Pid)
+}
+
+func TestStartGRPCPluginHandshake(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugin binaries are shell scripts")
+	}
+
+	oldTimeout := handshakeTimeout
+	defer func() { handshakeTimeout = oldTimeout }()
+	handshakeTimeout = 500 * time.Millisecond
+
+	pluginFolder := t.TempDir()
+	binary := filepath.Join(pluginFolder, PluginBinary)
+	err := ioutil.WriteFile(binary, []byte("#!/bin/sh\necho 'Hello World'\n"), 0755)
+	assert.NilError(t, err)
+	_, err = startGRPCPlugin(Metadata{Name: "fake", PluginFolder: pluginFolder, GRPC: &GRPC{}})
+	assert.ErrorContains(t, err, "plugin fake printed an unexpected handshake: Hello World")
+
+	err = ioutil.WriteFile(binary, []byte("#!/bin/sh\nexec sleep 5\n"), 0755)
+	assert.NilError(t, err)
+	_, err = startGRPCPlugin(Metadata{Name: "fake", PluginFolder: pluginFolder, GRPC: &GRPC{}})
+	assert.Error(t, err, "timed out waiting for the handshake of plugin fake")
+
+	_, err = startGRPCPlugin(Metadata{Name: "fake", PluginFolder: filepath.Join(pluginFolder, "missing"), GRPC: &GRPC{}})
+	assert.ErrorContains(t, err, "start plugin fake")
+}
@@ -46,6 +46,9 @@ var plugins []Metadata
 var pluginContextLock sync.Mutex
 var pluginContext map[string]string = map[string]string{}
 
+// pluginContextRevision is increased whenever the plugin context changes
+var pluginContextRevision int
+
 var pluginsOnce sync.Once
 
 func SetPlugins(p []Metadata) {
@@ -66,6 +69,7 @@ func SetPluginKubeContext(kubeContext, namespace string) {
 	kubeContextOnce.Do(func() {
 		pluginContextLock.Lock()
 		defer pluginContextLock.Unlock()
+		pluginContextRevision++
 
 		if kubeContext != "" {
 			pluginContext[KubeContextFlagEnv] = kubeContext
@@ -82,6 +86,7 @@ func SetPluginCommand(cobraCmd *cobra.Command, args []string) {
 	commandOnce.Do(func() {
 		pluginContextLock.Lock()
 		defer pluginContextLock.Unlock()
+		pluginContextRevision++
 
 		if cobraCmd == nil {
 			return
@@ -133,6 +138,7 @@ func SetPluginConfig(config config.Config) {
 	configOnce.Do(func() {
 		pluginContextLock.Lock()
 		defer pluginContextLock.Unlock()
+		pluginContextRevision++
 
 		if config == nil || config.Config() == nil {
 			return
@@ -192,7 +198,7 @@ func ExecutePluginHookWithContext(extraEnv map[string]interface{}, events ...str
 		}
 	}
 
-	return nil
+	return executeGRPCPluginHooks(convertedExtraEnv, events...)
 }
 
 func ConvertExtraEnv(base string, extraEnv map[string]interface{}) map[string]string {
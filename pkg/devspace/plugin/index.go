@@ -0,0 +1,146 @@
+package plugin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// IndexEnv is the environment variable that holds the default plugin index
+const IndexEnv = "DEVSPACE_PLUGIN_INDEX"
+
+// indexClient downloads remote plugin indexes
+var indexClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// Index is a list of plugins that can be installed by their name
+type Index struct {
+	Plugins []IndexPlugin `json:"plugins,omitempty"`
+}
+
+type IndexPlugin struct {
+	// Name is the name that is used to install the plugin, e.g. devspace add plugin my-plugin
+	Name string `json:"name"`
+
+	// Description is a short description of the plugin
+	Description string `json:"description,omitempty"`
+
+	// PublicKeys are trusted base64 encoded ed25519 public keys. If set, the plugin
+	// binaries have to be signed by one of them
+	PublicKeys []string `json:"publicKeys,omitempty"`
+
+	// Versions are the available versions of the plugin
+	Versions []IndexVersion `json:"versions,omitempty"`
+}
+
+type IndexVersion struct {
+	// Version is a SemVer 2 version of the plugin
+	Version string `json:"version"`
+
+	// Path is the git repository, url or local path of the plugin.yaml
+	Path string `json:"path"`
+
+	// Tag is the git tag of the version. Defaults to the version
+	Tag string `json:"tag,omitempty"`
+}
+
+// LoadIndex loads the plugin index from a local path or a https url. Remote indexes have to use
+// https, because the index supplies the trusted public keys and the locations of the plugins.
+func LoadIndex(path string) (*Index, error) {
+	var (
+		out []byte
+		err error
+	)
+	if strings.HasPrefix(path, "http://") {
+		return nil, errors.Errorf("plugin index %s has to use https", path)
+	} else if strings.HasPrefix(path, "https://") {
+		resp, err := indexClient.Get(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "download plugin index")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.Errorf("download plugin index: unexpected status %d", resp.StatusCode)
+		}
+
+		out, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "download plugin index")
+		}
+	} else {
+		out, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "read plugin index")
+		}
+	}
+
+	index := &Index{}
+	err = yaml.Unmarshal(out, index)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse plugin index")
+	}
+
+	return index, nil
+}
+
+// Resolve returns the plugin and the newest version that matches the constraint, e.g. 1.2.0
+// or ">=1.0.0 <2.0.0". An empty constraint matches all versions.
+func (i *Index) Resolve(name, constraint string) (*IndexPlugin, *IndexVersion, error) {
+	var plugin *IndexPlugin
+	for idx := range i.Plugins {
+		if i.Plugins[idx].Name == name {
+			plugin = &i.Plugins[idx]
+			break
+		}
+	}
+	if plugin == nil {
+		return nil, nil, errors.Errorf("couldn't find plugin %s in the plugin index", name)
+	}
+
+	versionRange := func(semver.Version) bool { return true }
+	if constraint != "" {
+		var err error
+		versionRange, err = semver.ParseRange(strings.TrimPrefix(strings.TrimSpace(constraint), "v"))
+		if err != nil {
+			return nil, nil, errors.Errorf("invalid version constraint %s: %v", constraint, err)
+		}
+	}
+
+	type candidate struct {
+		version semver.Version
+		entry   *IndexVersion
+	}
+	candidates := []candidate{}
+	for idx := range plugin.Versions {
+		version, err := semver.Parse(strings.TrimPrefix(plugin.Versions[idx].Version, "v"))
+		if err != nil {
+			return nil, nil, errors.Errorf("plugin %s has an invalid version %s in the plugin index: %v", name, plugin.Versions[idx].Version, err)
+		}
+
+		if versionRange(version) {
+			candidates = append(candidates, candidate{version: version, entry: &plugin.Versions[idx]})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("couldn't find a version of plugin %s that matches %s", name, constraint)
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].version.GT(candidates[b].version)
+	})
+	return plugin, candidates[0].entry, nil
+}
+
+// isIndexReference checks if the source is a plugin name instead of a path, url or git repository
+func isIndexReference(source string) bool {
+	return !isLocalReference(source) && !strings.ContainsAny(source, "/:\\")
+}
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type resolveTestCase struct {
+	name       string
+	constraint string
+
+	expectedVersion string
+	expectedErr     string
+}
+
+func TestIndexResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	indexFile := filepath.Join(dir, "index.yaml")
+	err = ioutil.WriteFile(indexFile, []byte(`plugins:
+- name: my-plugin
+  versions:
+  - version: 1.0.0
+    path: https://github.com/my-org/my-plugin
+  - version: v2.1.0
+    path: https://github.com/my-org/my-plugin
+    tag: v2.1.0
+  - version: 1.3.0
+    path: https://github.com/my-org/my-plugin
+`), 0644)
+	assert.NilError(t, err)
+
+	index, err := LoadIndex(indexFile)
+	assert.NilError(t, err)
+
+	testCases := map[string]resolveTestCase{
+		"Newest version": {
+			name:            "my-plugin",
+			expectedVersion: "v2.1.0",
+		},
+		"Version range": {
+			name:            "my-plugin",
+			constraint:      ">=1.0.0 <2.0.0",
+			expectedVersion: "1.3.0",
+		},
+		"Exact version": {
+			name:            "my-plugin",
+			constraint:      "v1.0.0",
+			expectedVersion: "1.0.0",
+		},
+		"No matching version": {
+			name:        "my-plugin",
+			constraint:  ">3.0.0",
+			expectedErr: "couldn't find a version of plugin my-plugin that matches >3.0.0",
+		},
+		"Unknown plugin": {
+			name:        "other-plugin",
+			expectedErr: "couldn't find plugin other-plugin in the plugin index",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		_, version, err := index.Resolve(testCase.name, testCase.constraint)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Unexpected error in testCase %s", testName)
+			continue
+		}
+
+		assert.NilError(t, err, "Unexpected error in testCase %s", testName)
+		assert.Equal(t, version.Version, testCase.expectedVersion, "Unexpected version in testCase %s", testName)
+	}
+
+	_, err = LoadIndex("http://example.com/index.yaml")
+	assert.Error(t, err, "plugin index http://example.com/index.yaml has to use https")
+
+	assert.Assert(t, isIndexReference("my-plugin"))
+	assert.Assert(t, !isIndexReference("https://github.com/my-org/my-plugin"))
+	assert.Assert(t, !isIndexReference(indexFile))
+}
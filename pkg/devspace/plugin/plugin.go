@@ -44,14 +44,25 @@ func (n *NewestVersionError) Error() string {
 }
 
 type Interface interface {
-	Add(path, version string) (*Metadata, error)
+	Add(path, version string, options *InstallOptions) (*Metadata, error)
 	GetByName(name string) (string, *Metadata, error)
-	Update(name, version string) (*Metadata, error)
+	Update(name, version string, options *InstallOptions) (*Metadata, error)
 	Remove(name string) error
 
 	List() ([]Metadata, error)
 }
 
+// InstallOptions are the options to add or update a plugin
+type InstallOptions struct {
+	// Index is the path or url of the plugin index that is used to install plugins
+	// by their name. Defaults to the environment variable DEVSPACE_PLUGIN_INDEX
+	Index string
+
+	// PublicKeys are trusted ed25519 public keys, see ParsePublicKey. If set, the
+	// plugin binary has to be signed by one of them
+	PublicKeys []string
+}
+
 type client struct {
 	installer Installer
 
@@ -74,9 +85,35 @@ func (c *client) PluginFolder() (string, error) {
 	return filepath.Join(dir, constants.DefaultHomeDevSpaceFolder, PluginFolder), nil
 }
 
-func (c *client) Add(path, version string) (*Metadata, error) {
+func (c *client) Add(path, version string, options *InstallOptions) (*Metadata, error) {
+	if options == nil {
+		options = &InstallOptions{}
+	}
+
+	publicKeys, err := parsePublicKeys(options.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the plugin name with the plugin index
+	index, indexName := "", ""
+	if isIndexReference(path) {
+		indexName = path
+		index = options.Index
+		if index == "" {
+			index = os.Getenv(IndexEnv)
+		}
+		if index == "" {
+			return nil, fmt.Errorf("plugin %s is neither a path nor an url. Please specify a plugin index with --index or %s to install plugins by name", path, IndexEnv)
+		}
+
+		path, version, publicKeys, err = c.resolve(index, path, version, publicKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// resolve path if it's a local one
-	var err error
 	if isLocalReference(path) {
 		path, err = filepath.Abs(path)
 		if err != nil {
@@ -91,26 +128,62 @@ func (c *client) Add(path, version string) (*Metadata, error) {
 		return nil, fmt.Errorf("plugin %s already exists", path)
 	}
 
-	return c.install(path, version)
+	return c.install(path, version, index, indexName, publicKeys)
 }
 
-func (c *client) install(path, version string) (*Metadata, error) {
+// resolve returns the path and the git tag of the newest version of the plugin in the index
+// that matches the version constraint. The public keys of the index entry are added to the
+// trusted public keys.
+func (c *client) resolve(index, name, constraint string, publicKeys []string) (string, string, []string, error) {
+	loadedIndex, err := LoadIndex(index)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	plugin, version, err := loadedIndex.Resolve(name, constraint)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	for _, key := range plugin.PublicKeys {
+		parsed, err := ParsePublicKey(key)
+		if err != nil {
+			return "", "", nil, errors.Wrapf(err, "plugin %s in the plugin index", name)
+		}
+
+		publicKeys = appendUnique(publicKeys, parsed)
+	}
+
+	// paths within a local index are relative to the index
+	path := version.Path
+	if isLocalReference(index) && !filepath.IsAbs(path) && isLocalReference(filepath.Join(filepath.Dir(index), path)) {
+		path = filepath.Join(filepath.Dir(index), path)
+	}
+
+	tag := version.Tag
+	if tag == "" {
+		tag = version.Version
+	}
+
+	c.log.Infof("Resolved plugin %s to version %s", name, version.Version)
+	return path, tag, publicKeys, nil
+}
+
+func (c *client) install(path, version, index, indexName string, publicKeys []string) (*Metadata, error) {
 	metadata, err := c.installer.DownloadMetadata(path, version)
 	if err != nil {
 		return nil, errors.Wrap(err, "download metadata")
 	}
 
 	// find binary for system
-	found := false
-	binaryPath := ""
-	for _, binary := range metadata.Binaries {
-		if binary.OS == runtime.GOOS && binary.Arch == runtime.GOARCH {
-			found = true
-			binaryPath = binary.Path
+	var binary *Binary
+	for i := range metadata.Binaries {
+		if metadata.Binaries[i].OS == runtime.GOOS && metadata.Binaries[i].Arch == runtime.GOARCH {
+			binary = &metadata.Binaries[i]
 			break
 		}
 	}
-	if !found {
+	if binary == nil {
 		return nil, fmt.Errorf("plugin %s does not support %s/%s", metadata.Name, runtime.GOOS, runtime.GOARCH)
 	}
 
@@ -119,10 +192,17 @@ func (c *client) install(path, version string) (*Metadata, error) {
 	if runtime.GOOS == "windows" {
 		tempBinaryName += ".exe"
 	}
-	err = c.installer.DownloadBinary(path, version, binaryPath, tempBinaryName)
+	err = c.installer.DownloadBinary(path, version, binary.Path, tempBinaryName)
 	if err != nil {
 		return nil, errors.Wrap(err, "download plugin binary")
 	}
+
+	// verify checksum and signature
+	err = verifyBinary(*binary, tempBinaryName, publicKeys)
+	if err != nil {
+		_ = os.Remove(tempBinaryName)
+		return nil, errors.Wrapf(err, "verify plugin %s", metadata.Name)
+	}
 	_ = os.Chmod(tempBinaryName, 0755)
 
 	// test the binary
@@ -148,6 +228,9 @@ func (c *client) install(path, version string) (*Metadata, error) {
 		return nil, err
 	}
 
+	metadata.Index = index
+	metadata.IndexName = indexName
+	metadata.PublicKeys = publicKeys
 	out, err := yaml.Marshal(metadata)
 	if err != nil {
 		return nil, err
@@ -170,14 +253,43 @@ func (c *client) install(path, version string) (*Metadata, error) {
 	return metadata, nil
 }
 
-func (c *client) Update(name, version string) (*Metadata, error) {
-	path, metadata, err := c.GetByName(name)
+func (c *client) Update(name, version string, options *InstallOptions) (*Metadata, error) {
+	if options == nil {
+		options = &InstallOptions{}
+	}
+
+	oldPath, metadata, err := c.GetByName(name)
 	if err != nil {
 		return nil, err
 	} else if metadata == nil {
 		return nil, fmt.Errorf("couldn't find plugin %s", name)
 	}
 
+	// the plugin is updated with the index and the public keys it was installed with
+	publicKeys := metadata.PublicKeys
+	if len(options.PublicKeys) > 0 {
+		publicKeys, err = parsePublicKeys(options.PublicKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+	index := metadata.Index
+	if options.Index != "" {
+		index = options.Index
+	}
+	indexName := metadata.IndexName
+	if indexName == "" {
+		indexName = metadata.Name
+	}
+
+	path := oldPath
+	if index != "" {
+		path, version, publicKeys, err = c.resolve(index, indexName, version, publicKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	oldVersion, err := c.parseVersion(metadata.Version)
 	if err != nil {
 		return nil, errors.Wrap(err, "parse old version")
@@ -198,7 +310,47 @@ func (c *client) Update(name, version string) (*Metadata, error) {
 	}
 
 	c.log.Infof("Updating plugin %s to version %s", name, newMetadata.Version)
-	return c.install(path, version)
+	if index == "" {
+		indexName = ""
+	}
+	updatedMetadata, err := c.install(path, version, index, indexName, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove the old installation if the plugin moved
+	if path != oldPath {
+		err = c.removePath(oldPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updatedMetadata, nil
+}
+
+func parsePublicKeys(keys []string) ([]string, error) {
+	parsed := []string{}
+	for _, key := range keys {
+		publicKey, err := ParsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = appendUnique(parsed, publicKey)
+	}
+
+	return parsed, nil
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+
+	return append(list, value)
 }
 
 func (c *client) parseVersion(version string) (semver.Version, error) {
@@ -220,6 +372,10 @@ func (c *client) Remove(name string) error {
 		return fmt.Errorf("couldn't find plugin %s", name)
 	}
 
+	return c.removePath(path)
+}
+
+func (c *client) removePath(path string) error {
 	pluginFolder, err := c.PluginFolder()
 	if err != nil {
 		return err
@@ -0,0 +1,87 @@
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/mitchellh/go-homedir"
+	"gotest.tools/assert"
+)
+
+// fakeInstaller serves plugin.yaml files whose version is the name of their parent folder
+type fakeInstaller struct{}
+
+func (f *fakeInstaller) DownloadMetadata(path, version string) (*Metadata, error) {
+	return &Metadata{
+		Name:    "devspace-plugin-example",
+		Version: filepath.Base(filepath.Dir(path)),
+		Binaries: []Binary{
+			{OS: runtime.GOOS, Arch: runtime.GOARCH, Path: "binary"},
+		},
+	}, nil
+}
+
+func (f *fakeInstaller) DownloadBinary(metadataPath, version, binaryPath, outFile string) error {
+	return ioutil.WriteFile(outFile, []byte("#!/bin/sh\nexit 0\n"), 0755)
+}
+
+func TestInstallFromIndex(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake plugin binary is a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	err = os.Chdir(dir)
+	assert.NilError(t, err)
+	defer func() { _ = os.Chdir(wd) }()
+
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+	os.Setenv("HOME", dir)
+
+	indexFile := filepath.Join(dir, "index.yaml")
+	err = ioutil.WriteFile(indexFile, []byte(`plugins:
+- name: example
+  versions:
+  - version: 1.0.0
+    path: 1.0.0/plugin.yaml
+  - version: 2.0.0
+    path: 2.0.0/plugin.yaml
+`), 0644)
+	assert.NilError(t, err)
+
+	c := &client{installer: &fakeInstaller{}, log: log.Discard}
+	_, err = c.Add("example", "", nil)
+	assert.ErrorContains(t, err, "Please specify a plugin index")
+
+	metadata, err := c.Add("example", "<2.0.0", &InstallOptions{Index: indexFile})
+	assert.NilError(t, err)
+	assert.Equal(t, metadata.Version, "1.0.0")
+	assert.Equal(t, metadata.Index, indexFile)
+	assert.Equal(t, metadata.IndexName, "example")
+
+	// the plugin is updated by its own name, but resolved by its name within the index
+	metadata, err = c.Update("devspace-plugin-example", "", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, metadata.Version, "2.0.0")
+	assert.Equal(t, metadata.IndexName, "example")
+
+	plugins, err := c.List()
+	assert.NilError(t, err)
+	assert.Equal(t, len(plugins), 1)
+	assert.Equal(t, plugins[0].Version, "2.0.0")
+
+	_, err = c.Update("devspace-plugin-example", "", nil)
+	assert.ErrorContains(t, err, "2.0.0")
+}
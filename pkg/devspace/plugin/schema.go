@@ -23,8 +23,23 @@ type Metadata struct {
 	// Hooks are commands that will be executed at specific events
 	Hooks []Hook `json:"hooks,omitempty"`
 
+	// GRPC starts the plugin binary once per command as long-lived process, which DevSpace
+	// calls over the versioned grpc interface in pkg/devspace/plugin/pluginpb
+	GRPC *GRPC `json:"grpc,omitempty"`
+
 	// This will be filled after parsing the metadata
 	PluginFolder string `json:"pluginFolder,omitempty"`
+
+	// Index is the plugin index the plugin was installed from. This is set during installation
+	Index string `json:"index,omitempty"`
+
+	// IndexName is the name of the plugin within the plugin index, which can differ from the
+	// name of the plugin. This is set during installation
+	IndexName string `json:"indexName,omitempty"`
+
+	// PublicKeys are the trusted public keys the plugin binary was verified with. This is set
+	// during installation
+	PublicKeys []string `json:"publicKeys,omitempty"`
 }
 
 type Hook struct {
@@ -38,6 +53,15 @@ type Hook struct {
 	BaseArgs []string `json:"baseArgs,omitempty"`
 }
 
+type GRPC struct {
+	// BaseArgs that are passed to the plugin binary to start the grpc server
+	BaseArgs []string `json:"baseArgs,omitempty"`
+
+	// Timeout is the maximum duration of a build, deploy, render, purge or event call,
+	// e.g. 10m. Defaults to 30m
+	Timeout string `json:"timeout,omitempty"`
+}
+
 type Binary struct {
 	// The current OS
 	OS string `json:"os"`
@@ -47,6 +71,14 @@ type Binary struct {
 
 	// The binary url to download from or relative path to use
 	Path string `json:"path"`
+
+	// Checksum is the sha256 checksum of the binary, e.g. sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae.
+	// DevSpace verifies the downloaded binary against it
+	Checksum string `json:"checksum,omitempty"`
+
+	// Signature is the base64 encoded ed25519 signature of the binary, which is verified
+	// against the trusted public keys
+	Signature string `json:"signature,omitempty"`
 }
 
 type Command struct {
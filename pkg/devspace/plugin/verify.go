@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParsePublicKey parses an ed25519 public key, which is either a base64 encoded raw or PKIX key,
+// a PEM encoded PKIX key or a path to a file that contains one of those. The raw key is
+// returned base64 encoded.
+func ParsePublicKey(key string) (string, error) {
+	if out, err := ioutil.ReadFile(key); err == nil {
+		key = string(out)
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		return parsePKIXPublicKey(block.Bytes)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return "", errors.Wrap(err, "parse public key")
+	} else if len(decoded) != ed25519.PublicKeySize {
+		return parsePKIXPublicKey(decoded)
+	}
+
+	return base64.StdEncoding.EncodeToString(decoded), nil
+}
+
+func parsePKIXPublicKey(der []byte) (string, error) {
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return "", errors.Errorf("parse public key: expected a raw ed25519 key with %d bytes or a PKIX key, but got %d bytes that are neither", ed25519.PublicKeySize, len(der))
+	}
+
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return "", errors.New("parse public key: only ed25519 keys are supported")
+	}
+
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// verifyBinary checks the downloaded binary against the checksum and the signature of
+// the binary entry. If public keys are trusted, the binary has to be signed by one of them.
+func verifyBinary(binary Binary, file string, publicKeys []string) error {
+	if binary.Checksum == "" && binary.Signature == "" && len(publicKeys) == 0 {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if binary.Checksum != "" {
+		expected := strings.ToLower(strings.TrimPrefix(binary.Checksum, "sha256:"))
+		sum := sha256.Sum256(content)
+		if actual := hex.EncodeToString(sum[:]); actual != expected {
+			return errors.Errorf("checksum mismatch of binary %s: expected sha256:%s, but got sha256:%s", binary.Path, expected, actual)
+		}
+	}
+
+	if len(publicKeys) == 0 {
+		return nil
+	} else if binary.Signature == "" {
+		return errors.Errorf("binary %s is not signed, but public keys are trusted", binary.Path)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(binary.Signature)
+	if err != nil {
+		return errors.Wrapf(err, "decode signature of binary %s", binary.Path)
+	}
+
+	for _, key := range publicKeys {
+		publicKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return errors.Errorf("invalid public key %s", key)
+		}
+
+		if ed25519.Verify(publicKey, content, signature) {
+			return nil
+		}
+	}
+
+	return errors.Errorf("signature of binary %s doesn't match any of the trusted public keys", binary.Path)
+}
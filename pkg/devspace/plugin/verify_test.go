@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type verifyTestCase struct {
+	binary     Binary
+	publicKeys []string
+
+	expectedErr string
+}
+
+func TestVerifyBinary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := []byte("plugin binary")
+	file := filepath.Join(dir, "binary")
+	err = ioutil.WriteFile(file, content, 0755)
+	assert.NilError(t, err)
+
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))
+
+	testCases := map[string]verifyTestCase{
+		"Nothing to verify": {
+			binary: Binary{Path: "binary"},
+		},
+		"Valid checksum": {
+			binary: Binary{Path: "binary", Checksum: "sha256:" + checksum},
+		},
+		"Invalid checksum": {
+			binary:      Binary{Path: "binary", Checksum: "sha256:abc"},
+			expectedErr: "checksum mismatch of binary binary: expected sha256:abc, but got sha256:" + checksum,
+		},
+		"Valid signature": {
+			binary:     Binary{Path: "binary", Checksum: checksum, Signature: signature},
+			publicKeys: []string{base64.StdEncoding.EncodeToString(otherKey), base64.StdEncoding.EncodeToString(publicKey)},
+		},
+		"Untrusted signature": {
+			binary:      Binary{Path: "binary", Signature: signature},
+			publicKeys:  []string{base64.StdEncoding.EncodeToString(otherKey)},
+			expectedErr: "signature of binary binary doesn't match any of the trusted public keys",
+		},
+		"Missing signature": {
+			binary:      Binary{Path: "binary"},
+			publicKeys:  []string{base64.StdEncoding.EncodeToString(publicKey)},
+			expectedErr: "binary binary is not signed, but public keys are trusted",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		err := verifyBinary(testCase.binary, file, testCase.publicKeys)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Unexpected error in testCase %s", testName)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Unexpected error in testCase %s", testName)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(publicKey)
+
+	parsed, err := ParsePublicKey(encoded)
+	assert.NilError(t, err)
+	assert.Equal(t, parsed, encoded)
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NilError(t, err)
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644)
+	assert.NilError(t, err)
+
+	parsed, err = ParsePublicKey(keyFile)
+	assert.NilError(t, err)
+	assert.Equal(t, parsed, encoded)
+
+	// base64 encoded PKIX key as printed by openssl pkey -pubout without the PEM header
+	parsed, err = ParsePublicKey(base64.StdEncoding.EncodeToString(der))
+	assert.NilError(t, err)
+	assert.Equal(t, parsed, encoded)
+
+	_, err = ParsePublicKey("YWJj")
+	assert.ErrorContains(t, err, "expected a raw ed25519 key with 32 bytes or a PKIX key, but got 3 bytes")
+}
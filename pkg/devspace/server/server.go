@@ -13,6 +13,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/portforward"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
@@ -181,6 +182,7 @@ func newHandler(config config.Config, dependencies []types.Dependency, defaultCo
 	handler.mux.HandleFunc("/api/resize", handler.resize)
 	handler.mux.HandleFunc("/api/logs", handler.logs)
 	handler.mux.HandleFunc("/api/logs-multiple", handler.logsMultiple)
+	handler.mux.HandleFunc("/api/hooks", handler.hooks)
 	return handler, nil
 }
 
@@ -221,6 +223,27 @@ func (h *handler) version(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+func (h *handler) hooks(w http.ResponseWriter, r *http.Request) {
+	report, err := hook.LoadReport(filepath.Join(h.workingDirectory, hook.ReportPath))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		report = &hook.Report{}
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
 type returnConfig struct {
 	Config          *latest.Config              `yaml:"config"`
 	RawConfig       map[interface{}]interface{} `yaml:"rawConfig"`
@@ -7,10 +7,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
 )
 
 // StartAttach opens a new terminal
-func (serviceClient *client) StartAttach(options targetselector.Options, interrupt chan error) error {
+func (serviceClient *client) StartAttach(options targetselector.Options, debugImage string, interrupt chan error) error {
 	targetSelector := targetselector.NewTargetSelector(serviceClient.client)
 	options.Question = "Which pod do you want to attach to?"
 
@@ -19,6 +20,14 @@ func (serviceClient *client) StartAttach(options targetselector.Options, interru
 		return err
 	}
 
+	// attach to an ephemeral debug container instead
+	if debugImage != "" {
+		container, err = serviceClient.startDebugContainer(container, debugImage, "")
+		if err != nil {
+			return errors.Wrap(err, "start debug container")
+		}
+	}
+
 	wrapper, upgradeRoundTripper, err := serviceClient.client.GetUpgraderWrapper()
 	if err != nil {
 		return err
@@ -15,16 +15,19 @@ import (
 
 // Client implements all service functions
 type Client interface {
-	StartAttach(options targetselector.Options, interrupt chan error) error
+	StartAttach(options targetselector.Options, debugImage string, interrupt chan error) error
 
 	StartLogs(options targetselector.Options, follow bool, tail int64, wait bool) error
 	StartLogsWithWriter(options targetselector.Options, follow bool, tail int64, wait bool, writer io.Writer) error
 
 	StartPortForwarding(interrupt chan error, prefixFn PrefixFn) error
 	StartSync(interrupt chan error, printSyncLog bool, verboseSync bool, prefixFn PrefixFn) error
+	StartSSH(interrupt chan error) error
 
 	StartSyncFromCmd(options targetselector.Options, syncConfig *latest.SyncConfig, interrupt chan error, noWatch, verbose bool) error
-	StartTerminal(options targetselector.Options, args []string, workDir string, interrupt chan error, wait, restart bool, stdout io.Writer, stderr io.Writer, stdin io.Reader) (int, error)
+	StartTerminal(options targetselector.Options, args []string, workDir string, session string, debugImage string, interrupt chan error, wait, restart bool, stdout io.Writer, stderr io.Writer, stdin io.Reader) (int, error)
+
+	ExecuteCommandInContainer(container *latest.CommandContainer, command []string, stdout io.Writer, stderr io.Writer, stdin io.Reader) error
 
 	ReplacePods(prefixFn PrefixFn) error
 
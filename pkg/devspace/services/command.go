@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/exit"
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// ExecuteCommandInContainer executes a custom command in the container that matches the given
+// container config and forwards stdin, stdout and stderr
+func (serviceClient *client) ExecuteCommandInContainer(container *latest.CommandContainer, command []string, stdout io.Writer, stderr io.Writer, stdin io.Reader) error {
+	if serviceClient.client == nil {
+		return errors.New("kube client is not initialized")
+	}
+
+	imageSelectors := []imageselector.ImageSelector{}
+	if container.ImageSelector != "" {
+		if serviceClient.config == nil || serviceClient.config.Generated() == nil {
+			return errors.New("cannot resolve image selector: config is not loaded")
+		}
+
+		imageSelector, err := util.ResolveImageAsImageSelector(container.ImageSelector, serviceClient.config, serviceClient.dependencies)
+		if err != nil {
+			return err
+		}
+
+		imageSelectors = append(imageSelectors, *imageSelector)
+	}
+
+	labelSelector := ""
+	if len(container.LabelSelector) > 0 {
+		labelSelector = labels.Set(container.LabelSelector).String()
+	}
+
+	wait := true
+	podContainer, err := targetselector.NewTargetSelector(serviceClient.client).SelectSingleContainer(context.TODO(), targetselector.Options{
+		Selector: selector.Selector{
+			ImageSelector: imageSelectors,
+			LabelSelector: labelSelector,
+			Pod:           container.Pod,
+			ContainerName: container.ContainerName,
+			Namespace:     container.Namespace,
+		},
+		Wait:            &wait,
+		Timeout:         150,
+		SortPods:        selector.SortPodsByNewest,
+		SortContainers:  selector.SortContainersByNewest,
+		WaitingStrategy: targetselector.NewUntilNewestRunningWaitingStrategy(time.Second * 2),
+	}, serviceClient.log)
+	if err != nil {
+		return errors.Wrap(err, "select container")
+	}
+
+	_, err = serviceClient.execInContainer(podContainer, command, nil, stdout, stderr, stdin)
+	if err != nil {
+		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			return &exit.ReturnCodeError{
+				ExitCode: exitError.Code,
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
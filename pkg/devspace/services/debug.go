@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/util/randutil"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultDebugImage is the image that is used for ephemeral debug containers if none is specified
+const DefaultDebugImage = "busybox:latest"
+
+// debugContainerPrefix is the name prefix of ephemeral debug containers created by devspace
+const debugContainerPrefix = "devspace-debug-"
+
+// startDebugContainer adds a new ephemeral container with the given image to the pod of the selected
+// container that shares its process namespace and waits until the ephemeral container is running.
+// If previous is the name of a debug container that was started before, e.g. when the terminal
+// reconnects, that container is reused instead of adding a new one to the pod
+func (serviceClient *client) startDebugContainer(target *selector.SelectedPodContainer, image, previous string) (*selector.SelectedPodContainer, error) {
+	if image == "" {
+		image = DefaultDebugImage
+	}
+
+	// ephemeral containers cannot be restarted or removed, so we wait for the previous
+	// debug container instead of adding another one each time the terminal reconnects
+	if previous != "" {
+		if existing := findEphemeralContainer(target.Pod, previous); existing != nil {
+			return serviceClient.waitForDebugContainer(target.Pod, existing)
+		}
+	}
+
+	// reuse a running debug container, e.g. if another terminal started it
+	if existing := findRunningDebugContainer(target.Pod, target.Container.Name, image); existing != nil {
+		return &selector.SelectedPodContainer{
+			Pod:       target.Pod,
+			Container: existing,
+		}, nil
+	}
+
+	debugContainer := k8sv1.EphemeralContainer{
+		EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{
+			Name:                     debugContainerPrefix + strings.ToLower(randutil.GenerateRandomString(5)),
+			Image:                    image,
+			ImagePullPolicy:          k8sv1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: k8sv1.TerminationMessageReadFile,
+		},
+		TargetContainerName: target.Container.Name,
+	}
+
+	serviceClient.log.Infof("Starting debug container %s with image %s targeting %s:%s", ansi.Color(debugContainer.Name, "white+b"), ansi.Color(image, "white+b"), target.Pod.Name, target.Container.Name)
+	pod := target.Pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, debugContainer)
+	_, err := serviceClient.client.KubeClient().CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(context.TODO(), pod.Name, pod, metav1.UpdateOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errors.Errorf("ephemeral containers are not supported by this cluster: %v", err)
+		}
+
+		return nil, errors.Wrap(err, "add ephemeral container")
+	}
+
+	container := k8sv1.Container(debugContainer.EphemeralContainerCommon)
+	return serviceClient.waitForDebugContainer(pod, &container)
+}
+
+// waitForDebugContainer waits until the given ephemeral container of the pod is running
+func (serviceClient *client) waitForDebugContainer(pod *k8sv1.Pod, container *k8sv1.Container) (*selector.SelectedPodContainer, error) {
+	var (
+		updatedPod *k8sv1.Pod
+		err        error
+	)
+	err = wait.PollImmediate(time.Second, time.Minute*2, func() (bool, error) {
+		updatedPod, err = serviceClient.client.KubeClient().CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		for _, status := range updatedPod.Status.EphemeralContainerStatuses {
+			if status.Name != container.Name {
+				continue
+			}
+
+			if status.State.Running != nil {
+				return true, nil
+			} else if status.State.Terminated != nil {
+				return false, errors.Errorf("debug container %s terminated: %s (%s). Ephemeral containers cannot be restarted, please recreate the pod to start a new debug container", container.Name, status.State.Terminated.Reason, status.State.Terminated.Message)
+			} else if status.State.Waiting != nil && kubectl.CriticalStatus[status.State.Waiting.Reason] {
+				return false, errors.Errorf("debug container is in critical status %s: %s", status.State.Waiting.Reason, status.State.Waiting.Message)
+			}
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "wait for debug container")
+	}
+
+	return &selector.SelectedPodContainer{
+		Pod:       updatedPod,
+		Container: container,
+	}, nil
+}
+
+func findEphemeralContainer(pod *k8sv1.Pod, name string) *k8sv1.Container {
+	for _, ephemeralContainer := range pod.Spec.EphemeralContainers {
+		if ephemeralContainer.Name == name {
+			container := k8sv1.Container(ephemeralContainer.EphemeralContainerCommon)
+			return &container
+		}
+	}
+
+	return nil
+}
+
+func findRunningDebugContainer(pod *k8sv1.Pod, targetContainer, image string) *k8sv1.Container {
+	for _, ephemeralContainer := range pod.Spec.EphemeralContainers {
+		if !strings.HasPrefix(ephemeralContainer.Name, debugContainerPrefix) || ephemeralContainer.TargetContainerName != targetContainer || ephemeralContainer.Image != image {
+			continue
+		}
+
+		for _, status := range pod.Status.EphemeralContainerStatuses {
+			if status.Name == ephemeralContainer.Name && status.State.Running != nil {
+				container := k8sv1.Container(ephemeralContainer.EphemeralContainerCommon)
+				return &container
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindRunningDebugContainer(t *testing.T) {
+	pod := &k8sv1.Pod{
+		Spec: k8sv1.PodSpec{
+			EphemeralContainers: []k8sv1.EphemeralContainer{
+				{
+					EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{Name: "devspace-debug-abcde", Image: DefaultDebugImage},
+					TargetContainerName:      "api",
+				},
+				{
+					EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{Name: "devspace-debug-fghij", Image: DefaultDebugImage},
+					TargetContainerName:      "worker",
+				},
+				{
+					EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{Name: "other-debugger", Image: DefaultDebugImage},
+					TargetContainerName:      "api",
+				},
+			},
+		},
+		Status: k8sv1.PodStatus{
+			EphemeralContainerStatuses: []k8sv1.ContainerStatus{
+				{Name: "devspace-debug-abcde", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}}},
+				{Name: "devspace-debug-fghij", State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{}}},
+				{Name: "other-debugger", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}}},
+			},
+		},
+	}
+
+	container := findRunningDebugContainer(pod, "api", DefaultDebugImage)
+	assert.Assert(t, container != nil)
+	assert.Equal(t, "devspace-debug-abcde", container.Name)
+
+	assert.Assert(t, findRunningDebugContainer(pod, "api", "alpine") == nil)
+	assert.Assert(t, findRunningDebugContainer(pod, "worker", DefaultDebugImage) == nil)
+}
+
+func TestStartDebugContainerReconnect(t *testing.T) {
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-0", Namespace: "default"},
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{{Name: "api"}},
+			EphemeralContainers: []k8sv1.EphemeralContainer{
+				{
+					EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{Name: "devspace-debug-abcde", Image: DefaultDebugImage},
+					TargetContainerName:      "api",
+				},
+				{
+					EphemeralContainerCommon: k8sv1.EphemeralContainerCommon{Name: "devspace-debug-fghij", Image: DefaultDebugImage},
+					TargetContainerName:      "api",
+				},
+			},
+		},
+		Status: k8sv1.PodStatus{
+			EphemeralContainerStatuses: []k8sv1.ContainerStatus{
+				{Name: "devspace-debug-abcde", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}}},
+				{Name: "devspace-debug-fghij", State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{Reason: "Completed"}}},
+			},
+		},
+	}
+	kube := fake.NewSimpleClientset(pod)
+	serviceClient := &client{
+		client: &fakekube.Client{Client: kube},
+		log:    log.Discard,
+	}
+	target := &selector.SelectedPodContainer{Pod: pod, Container: &pod.Spec.Containers[0]}
+
+	container, err := serviceClient.startDebugContainer(target, DefaultDebugImage, "devspace-debug-abcde")
+	assert.NilError(t, err)
+	assert.Equal(t, "devspace-debug-abcde", container.Container.Name)
+
+	_, err = serviceClient.startDebugContainer(target, DefaultDebugImage, "devspace-debug-fghij")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, strings.Contains(err.Error(), "cannot be restarted"), err.Error())
+
+	for _, action := range kube.Actions() {
+		assert.Assert(t, action.GetVerb() == "get", "unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
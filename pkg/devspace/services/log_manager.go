@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -13,10 +14,13 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/logfilter"
+	"github.com/loft-sh/devspace/pkg/devspace/services/logstore"
 	"github.com/loft-sh/devspace/pkg/util/imageselector"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/ptr"
 	"github.com/loft-sh/devspace/pkg/util/scanner"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,7 +39,9 @@ type logManager struct {
 	imageSelectors []namespacedImageSelector
 	labelSelectors []latest.LogsSelector
 
-	tail int64
+	tail   int64
+	filter *logfilter.Filter
+	store  *logstore.Store
 
 	interrupt chan error
 	output    log.Logger
@@ -103,6 +109,17 @@ func NewLogManager(client kubectl.Client, config config.Config, dependencies []t
 	if tail == nil {
 		tail = ptr.Int64(50)
 	}
+
+	filter, err := logfilter.New(logfilter.NewOptionsFromConfig(c.Dev.Logs))
+	if err != nil {
+		return nil, errors.Wrap(err, "create log filter")
+	}
+
+	var store *logstore.Store
+	if c.Dev.Logs != nil && c.Dev.Logs.Persist != nil && c.Dev.Logs.Persist.Enabled {
+		store = logstore.NewStore(logstore.DefaultFolder, c.Dev.Logs.Persist.MaxSize, c.Dev.Logs.Persist.MaxFiles)
+	}
+
 	return &logManager{
 		client:         client,
 		imageSelectors: imageSelectors,
@@ -110,6 +127,8 @@ func NewLogManager(client kubectl.Client, config config.Config, dependencies []t
 		interrupt:      interrupt,
 		output:         out,
 		tail:           *tail,
+		filter:         filter,
+		store:          store,
 		activeLogs:     map[string]activeLog{},
 	}, nil
 }
@@ -119,6 +138,22 @@ type activeLog struct {
 	log       log.Logger
 }
 
+// logs streams the logs of the container. If logs are persisted, every line is prefixed with the
+// time the container runtime received it, so that lines are persisted with their original time
+// and lines that are streamed again after a reconnect can be skipped.
+func (l *logManager) logs(ctx context.Context, namespace, pod, container string) (io.ReadCloser, error) {
+	if l.store == nil {
+		return l.client.Logs(ctx, namespace, pod, container, false, &l.tail, true)
+	}
+
+	return l.client.KubeClient().CoreV1().Pods(namespace).GetLogs(pod, &k8sv1.PodLogOptions{
+		Container:  container,
+		TailLines:  &l.tail,
+		Follow:     true,
+		Timestamps: true,
+	}).Stream(ctx)
+}
+
 func (l *logManager) Start() error {
 	l.output.Info("Starting log streaming")
 	for {
@@ -143,15 +178,37 @@ func (l *logManager) Start() error {
 			logsLog := log.NewPrefixLogger("["+t.name+"] ", log.Colors[(len(log.Colors)-1)-(len(l.activeLogs)%len(log.Colors))], l.output)
 			go func() {
 				logsLog.Infof("Start streaming logs for %s", t.key)
-				reader, err := l.client.Logs(logsContext, namespace, pod, container, false, &l.tail, true)
+				reader, err := l.logs(logsContext, namespace, pod, container)
 				if err != nil {
 					logsLog.Warnf("Error streaming logs: %v", err)
 				}
 
 				if reader != nil {
+					var storeWriter *logstore.Writer
+					if l.store != nil {
+						storeWriter, err = l.store.Writer(namespace, pod, container)
+						if err != nil {
+							logsLog.Warnf("Error persisting logs: %v", err)
+						} else {
+							defer storeWriter.Close()
+						}
+					}
+
 					scanner := scanner.NewScanner(reader)
 					for scanner.Scan() {
-						logsLog.Info(scanner.Text())
+						text := scanner.Text()
+						if l.store != nil {
+							var t time.Time
+							t, text = logstore.SplitTimestamp(text)
+							if storeWriter != nil {
+								_ = storeWriter.WriteLine(t, text)
+							}
+						}
+
+						line, ok := l.filter.Process(text)
+						if ok {
+							logsLog.Info(line)
+						}
 					}
 					if scanner.Err() != nil && scanner.Err() != context.Canceled {
 						logsLog.Warnf("Error streaming logs for %s: %v", t.key, scanner.Err())
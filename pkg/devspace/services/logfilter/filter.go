@@ -0,0 +1,229 @@
+package logfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// levelKeys are the json keys that are checked for the log level
+var levelKeys = []string{"level", "lvl", "severity", "loglevel"}
+
+// messageKeys are the json keys that are checked for the log message
+var messageKeys = []string{"msg", "message"}
+
+// Options defines how log lines are filtered and formatted
+type Options struct {
+	// Include are regular expressions of which at least one has to match for a line to be printed
+	Include []string
+	// Exclude are regular expressions that drop a line if any of them match
+	Exclude []string
+	// JSON enables json log parsing and level based coloring
+	JSON bool
+	// Fields are the json fields to print. If empty, all fields are printed
+	Fields []string
+}
+
+// NewOptionsFromConfig returns the filter options from the logs config
+func NewOptionsFromConfig(config *latest.LogsConfig) *Options {
+	if config == nil {
+		return &Options{}
+	}
+
+	return &Options{
+		Include: config.Include,
+		Exclude: config.Exclude,
+		JSON:    config.JSON,
+		Fields:  config.Fields,
+	}
+}
+
+// Filter filters and formats single log lines
+type Filter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	json    bool
+	fields  []string
+}
+
+// New creates a new filter from the given options
+func New(options *Options) (*Filter, error) {
+	if options == nil {
+		return &Filter{}, nil
+	}
+
+	include, err := compile(options.Include)
+	if err != nil {
+		return nil, errors.Wrap(err, "include")
+	}
+	exclude, err := compile(options.Exclude)
+	if err != nil {
+		return nil, errors.Wrap(err, "exclude")
+	}
+
+	return &Filter{
+		include: include,
+		exclude: exclude,
+		json:    options.JSON || len(options.Fields) > 0,
+		fields:  options.Fields,
+	}, nil
+}
+
+func compile(expressions []string) ([]*regexp.Regexp, error) {
+	compiled := []*regexp.Regexp{}
+	for _, expression := range expressions {
+		r, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "compile '%s'", expression)
+		}
+
+		compiled = append(compiled, r)
+	}
+
+	return compiled, nil
+}
+
+// Process returns the formatted line and whether the line should be printed at all
+func (f *Filter) Process(line string) (string, bool) {
+	if f == nil {
+		return line, true
+	}
+
+	if len(f.include) > 0 {
+		matched := false
+		for _, r := range f.include {
+			if r.MatchString(line) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", false
+		}
+	}
+	for _, r := range f.exclude {
+		if r.MatchString(line) {
+			return "", false
+		}
+	}
+
+	if f.json {
+		return f.formatJSON(line), true
+	}
+
+	return line, true
+}
+
+func (f *Filter) formatJSON(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return line
+	}
+
+	fields := map[string]interface{}{}
+	err := json.Unmarshal([]byte(trimmed), &fields)
+	if err != nil {
+		return line
+	}
+
+	parts := []string{}
+	levelKey, level := lookup(fields, levelKeys)
+	if level != "" {
+		parts = append(parts, colorLevel(level))
+	}
+
+	if len(f.fields) > 0 {
+		for _, key := range f.fields {
+			value, ok := fields[key]
+			if !ok {
+				continue
+			}
+
+			if contains(messageKeys, key) {
+				parts = append(parts, toString(value))
+			} else {
+				parts = append(parts, key+"="+toString(value))
+			}
+		}
+
+		return strings.Join(parts, " ")
+	}
+
+	messageKey, message := lookup(fields, messageKeys)
+	if message != "" {
+		parts = append(parts, message)
+	}
+
+	keys := []string{}
+	for key := range fields {
+		if key != levelKey && key != messageKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, key+"="+toString(fields[key]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func lookup(fields map[string]interface{}, keys []string) (string, string) {
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			return key, toString(value)
+		}
+	}
+
+	return "", ""
+}
+
+func colorLevel(level string) string {
+	tag := "[" + strings.ToUpper(level) + "]"
+	switch strings.ToLower(level) {
+	case "error", "err", "fatal", "panic", "critical", "crit":
+		return ansi.Color(tag, "red+b")
+	case "warn", "warning":
+		return ansi.Color(tag, "yellow+b")
+	case "info":
+		return ansi.Color(tag, "cyan+b")
+	case "debug", "trace":
+		return ansi.Color(tag, "white")
+	}
+
+	return tag
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case float64, bool:
+		return fmt.Sprintf("%v", v)
+	}
+
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(out)
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+
+	return false
+}
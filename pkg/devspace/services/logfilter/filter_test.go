@@ -0,0 +1,97 @@
+package logfilter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"gotest.tools/assert"
+)
+
+type processTestCase struct {
+	name string
+
+	options *Options
+	line    string
+
+	expectedLine  string
+	expectedPrint bool
+}
+
+func TestProcess(t *testing.T) {
+	testCases := []processTestCase{
+		{
+			name:          "No options",
+			options:       &Options{},
+			line:          "hello world",
+			expectedLine:  "hello world",
+			expectedPrint: true,
+		},
+		{
+			name:          "Include matches",
+			options:       &Options{Include: []string{"^GET", "error"}},
+			line:          "an error occurred",
+			expectedLine:  "an error occurred",
+			expectedPrint: true,
+		},
+		{
+			name:    "Include does not match",
+			options: &Options{Include: []string{"^GET"}},
+			line:    "POST /api",
+		},
+		{
+			name:    "Exclude matches",
+			options: &Options{Exclude: []string{"healthz"}},
+			line:    "GET /healthz 200",
+		},
+		{
+			name:          "Non json line with json enabled",
+			options:       &Options{JSON: true},
+			line:          "plain text",
+			expectedLine:  "plain text",
+			expectedPrint: true,
+		},
+		{
+			name:          "Json line",
+			options:       &Options{JSON: true},
+			line:          `{"level":"error","msg":"failed","code":500,"path":"/api"}`,
+			expectedLine:  ansi.Color("[ERROR]", "red+b") + " failed code=500 path=/api",
+			expectedPrint: true,
+		},
+		{
+			name:          "Json line with fields",
+			options:       &Options{Fields: []string{"msg", "trace_id", "missing"}},
+			line:          `{"severity":"warn","message":"x","msg":"slow request","trace_id":"abc","path":"/api"}`,
+			expectedLine:  ansi.Color("[WARN]", "yellow+b") + " slow request trace_id=abc",
+			expectedPrint: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		filter, err := New(testCase.options)
+		assert.NilError(t, err, "Error in test case %s", testCase.name)
+
+		line, print := filter.Process(testCase.line)
+		assert.Equal(t, testCase.expectedPrint, print, "Unexpected print in test case %s", testCase.name)
+		assert.Equal(t, testCase.expectedLine, line, "Unexpected line in test case %s", testCase.name)
+	}
+}
+
+func TestInvalidExpression(t *testing.T) {
+	_, err := New(&Options{Include: []string{"("}})
+	assert.ErrorContains(t, err, "include")
+}
+
+func TestWriter(t *testing.T) {
+	filter, err := New(&Options{Exclude: []string{"drop"}})
+	assert.NilError(t, err)
+
+	out := &bytes.Buffer{}
+	writer := NewWriter(out, filter)
+	_, err = writer.Write([]byte("first\ndrop me\nsec"))
+	assert.NilError(t, err)
+	_, err = writer.Write([]byte("ond\nlast"))
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Flush())
+	assert.Equal(t, "first\nsecond\nlast\n", out.String())
+}
@@ -0,0 +1,62 @@
+package logfilter
+
+import (
+	"bytes"
+	"io"
+)
+
+// Writer is an io.Writer that filters and formats complete lines before
+// passing them to the underlying writer
+type Writer struct {
+	out    io.Writer
+	filter *Filter
+	buffer bytes.Buffer
+}
+
+// NewWriter creates a new filtering writer
+func NewWriter(out io.Writer, filter *Filter) *Writer {
+	return &Writer{
+		out:    out,
+		filter: filter,
+	}
+}
+
+// Write implements io.Writer
+func (w *Writer) Write(p []byte) (int, error) {
+	w.buffer.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buffer.Bytes(), '\n')
+		if idx == -1 {
+			break
+		}
+
+		line := string(w.buffer.Next(idx + 1))
+		err := w.writeLine(line[:len(line)-1])
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush writes a remaining incomplete line
+func (w *Writer) Flush() error {
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	line := w.buffer.String()
+	w.buffer.Reset()
+	return w.writeLine(line)
+}
+
+func (w *Writer) writeLine(line string) error {
+	formatted, ok := w.filter.Process(line)
+	if !ok {
+		return nil
+	}
+
+	_, err := w.out.Write([]byte(formatted + "\n"))
+	return err
+}
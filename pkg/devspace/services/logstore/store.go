@@ -0,0 +1,387 @@
+package logstore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/pkg/errors"
+)
+
+// DefaultFolder is the folder relative to the project root where logs are persisted
+var DefaultFolder = filepath.Join(constants.DefaultCacheFolder, "logs")
+
+// DefaultMaxSize is the default maximum size in megabytes of a single log file
+const DefaultMaxSize = 10
+
+// DefaultMaxFiles is the default amount of rotated log files that are kept per container
+const DefaultMaxFiles = 5
+
+const fileSuffix = ".log"
+
+// lastLinesBuffer is the amount of bytes at the end of a log file that are read to find the
+// newest persisted lines
+const lastLinesBuffer = 64 * 1024
+
+// Store persists container logs in rotating files on disk
+type Store struct {
+	folder   string
+	maxSize  int64
+	maxFiles int
+}
+
+// NewStore creates a new store in the given folder. maxSize is specified in megabytes
+func NewStore(folder string, maxSize, maxFiles int) *Store {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxFiles
+	}
+
+	return &Store{
+		folder:   folder,
+		maxSize:  int64(maxSize) * 1024 * 1024,
+		maxFiles: maxFiles,
+	}
+}
+
+// Writer returns a new writer for the given container. The caller has to close
+// the writer after it is done.
+func (s *Store) Writer(namespace, pod, container string) (*Writer, error) {
+	folder := filepath.Join(s.folder, namespace, pod)
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		path:      filepath.Join(folder, container+fileSuffix),
+		maxSize:   s.maxSize,
+		maxFiles:  s.maxFiles,
+		lastLines: map[string]bool{},
+	}
+	err = w.readLast()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Writer appends log lines of a single container to its log file
+type Writer struct {
+	m sync.Mutex
+
+	path     string
+	maxSize  int64
+	maxFiles int
+
+	file *os.File
+	size int64
+
+	// last is the time of the newest persisted line and lastLines are the lines persisted at
+	// that time, which are used to skip lines that are streamed again after a reconnect
+	last      time.Time
+	lastLines map[string]bool
+}
+
+func (w *Writer) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = stat.Size()
+	return nil
+}
+
+// readLast reads the newest persisted lines of the container, so that lines which were already
+// persisted by a previous writer are not persisted again
+func (w *Writer) readLast() error {
+	for _, path := range []string{w.path, w.path + ".1"} {
+		file, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// the newest lines are at the end of the file
+		stat, err := file.Stat()
+		if err == nil && stat.Size() > lastLinesBuffer {
+			_, err = file.Seek(stat.Size()-lastLinesBuffer, io.SeekStart)
+		}
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			t, line, ok := parseLine(scanner.Text())
+			if ok {
+				w.remember(t, line)
+			}
+		}
+		_ = file.Close()
+		if !w.last.IsZero() {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer) remember(t time.Time, line string) {
+	if t.After(w.last) {
+		w.last = t
+		w.lastLines = map[string]bool{}
+	}
+
+	w.lastLines[line] = true
+}
+
+// WriteLine persists a single log line with the time the container runtime received it. Lines
+// that are older than the newest persisted line or were already persisted are skipped, because
+// they are streamed again when the log stream reconnects.
+func (w *Writer) WriteLine(t time.Time, line string) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.file == nil {
+		return errors.New("writer is closed")
+	} else if t.Before(w.last) || (t.Equal(w.last) && w.lastLines[line]) {
+		return nil
+	}
+
+	if w.size >= w.maxSize {
+		err := w.rotate()
+		if err != nil {
+			return errors.Wrap(err, "rotate log file")
+		}
+	}
+
+	n, err := fmt.Fprintf(w.file, "%s %s\n", t.UTC().Format(time.RFC3339Nano), line)
+	w.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	w.remember(t, line)
+	return nil
+}
+
+// SplitTimestamp splits a log line that was streamed with timestamps into the time and the
+// actual line. Lines without a valid timestamp are returned with the current time.
+func SplitTimestamp(text string) (time.Time, string) {
+	t, line, ok := parseLine(text)
+	if !ok {
+		return time.Now(), text
+	}
+
+	return t, line
+}
+
+func parseLine(text string) (time.Time, string, bool) {
+	splitted := strings.SplitN(text, " ", 2)
+	if len(splitted) != 2 {
+		return time.Time{}, "", false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, splitted[0])
+	if err != nil {
+		return time.Time{}, "", false
+	}
+
+	return t, splitted[1], true
+}
+
+// Close closes the underlying log file
+func (w *Writer) Close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *Writer) rotate() error {
+	err := w.file.Close()
+	if err != nil {
+		return err
+	}
+
+	// shift container.log.N-1 -> container.log.N and drop the oldest
+	_ = os.Remove(w.path + "." + strconv.Itoa(w.maxFiles))
+	for i := w.maxFiles - 1; i >= 1; i-- {
+		_ = os.Rename(w.path+"."+strconv.Itoa(i), w.path+"."+strconv.Itoa(i+1))
+	}
+
+	err = os.Rename(w.path, w.path+".1")
+	if err != nil {
+		return err
+	}
+
+	return w.open()
+}
+
+// Entry is a single persisted log line
+type Entry struct {
+	Time      time.Time
+	Namespace string
+	Pod       string
+	Container string
+	Line      string
+}
+
+// Query selects which persisted log lines should be returned
+type Query struct {
+	// Since only returns lines received after this time
+	Since time.Time
+	// Pod only returns lines of this pod if not empty
+	Pod string
+	// Container only returns lines of this container if not empty
+	Container string
+	// Match is called for every line and drops it if it returns false
+	Match func(line string) bool
+}
+
+// Search returns all persisted log lines of the store that match the query ordered by time
+func (s *Store) Search(query Query) ([]Entry, error) {
+	entries := []Entry{}
+	namespaces, err := readDirNames(s.folder)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaces {
+		pods, err := readDirNames(filepath.Join(s.folder, namespace))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods {
+			if query.Pod != "" && query.Pod != pod {
+				continue
+			}
+
+			files, err := readDirNames(filepath.Join(s.folder, namespace, pod))
+			if err != nil {
+				return nil, err
+			}
+
+			for _, file := range files {
+				container, ok := containerName(file)
+				if !ok || (query.Container != "" && query.Container != container) {
+					continue
+				}
+
+				fileEntries, err := readFile(filepath.Join(s.folder, namespace, pod, file), namespace, pod, container, query)
+				if err != nil {
+					return nil, err
+				}
+
+				entries = append(entries, fileEntries...)
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+func containerName(file string) (string, bool) {
+	// strip the rotation suffix, e.g. container.log.1
+	if ext := filepath.Ext(file); ext != fileSuffix {
+		if _, err := strconv.Atoi(strings.TrimPrefix(ext, ".")); err != nil {
+			return "", false
+		}
+
+		file = strings.TrimSuffix(file, ext)
+	}
+	if !strings.HasSuffix(file, fileSuffix) {
+		return "", false
+	}
+
+	return strings.TrimSuffix(file, fileSuffix), true
+}
+
+func readFile(path, namespace, pod, container string, query Query) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		t, line, ok := parseLine(scanner.Text())
+		if !ok {
+			continue
+		} else if !query.Since.IsZero() && t.Before(query.Since) {
+			continue
+		} else if query.Match != nil && !query.Match(line) {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Time:      t,
+			Namespace: namespace,
+			Pod:       pod,
+			Container: container,
+			Line:      line,
+		})
+	}
+
+	return entries, scanner.Err()
+}
+
+func readDirNames(folder string) ([]string, error) {
+	entries, err := ioutil.ReadDir(folder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	return names, nil
+}
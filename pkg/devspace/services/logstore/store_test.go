@@ -0,0 +1,136 @@
+package logstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestWriteAndSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logstore")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewStore(dir, 1, 2)
+	now := time.Now()
+
+	w1, err := store.Writer("default", "api-1", "api")
+	assert.NilError(t, err)
+	assert.NilError(t, w1.WriteLine(now.Add(-2*time.Hour), "old line"))
+	assert.NilError(t, w1.WriteLine(now.Add(-time.Minute), "error in api-1"))
+	assert.NilError(t, w1.Close())
+
+	w2, err := store.Writer("default", "api-2", "api")
+	assert.NilError(t, err)
+	assert.NilError(t, w2.WriteLine(now.Add(-30*time.Second), "error in api-2"))
+	assert.NilError(t, w2.WriteLine(now, "ok in api-2"))
+	assert.NilError(t, w2.Close())
+
+	entries, err := store.Search(Query{})
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(entries))
+	assert.Equal(t, "old line", entries[0].Line)
+	assert.Equal(t, "ok in api-2", entries[3].Line)
+
+	entries, err = store.Search(Query{
+		Since: now.Add(-time.Hour),
+		Match: func(line string) bool {
+			return strings.Contains(line, "error")
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "api-1", entries[0].Pod)
+	assert.Equal(t, "api-2", entries[1].Pod)
+
+	entries, err = store.Search(Query{Pod: "api-2"})
+	assert.NilError(t, err)
+	assert.Equal(t, 2, len(entries))
+}
+
+func TestRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logstore")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	w, err := NewStore(dir, 1, 2).Writer("default", "pod", "container")
+	assert.NilError(t, err)
+	w.maxSize = 100
+
+	line := strings.Repeat("x", 60)
+	for i := 0; i < 10; i++ {
+		assert.NilError(t, w.WriteLine(time.Now(), line))
+	}
+	assert.NilError(t, w.Close())
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "default", "pod"))
+	assert.NilError(t, err)
+	names := []string{}
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.DeepEqual(t, []string{"container.log", "container.log.1", "container.log.2"}, names)
+}
+
+func TestContainerName(t *testing.T) {
+	name, ok := containerName("api.log")
+	assert.Assert(t, ok)
+	assert.Equal(t, "api", name)
+
+	name, ok = containerName("api.log.3")
+	assert.Assert(t, ok)
+	assert.Equal(t, "api", name)
+
+	_, ok = containerName("api.txt")
+	assert.Assert(t, !ok)
+}
+
+func TestWriterSkipsStreamedLines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logstore")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := NewStore(dir, 1, 2)
+	start := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	w, err := store.Writer("default", "pod", "container")
+	assert.NilError(t, err)
+	assert.NilError(t, w.WriteLine(start, "first"))
+	assert.NilError(t, w.WriteLine(start.Add(time.Second), "second"))
+	assert.NilError(t, w.WriteLine(start.Add(time.Second), "second again"))
+	assert.NilError(t, w.Close())
+
+	// a reconnect streams the tail of the logs again
+	w, err = store.Writer("default", "pod", "container")
+	assert.NilError(t, err)
+	assert.NilError(t, w.WriteLine(start, "first"))
+	assert.NilError(t, w.WriteLine(start.Add(time.Second), "second"))
+	assert.NilError(t, w.WriteLine(start.Add(time.Second), "second again"))
+	assert.NilError(t, w.WriteLine(start.Add(2*time.Second), "third"))
+	assert.NilError(t, w.Close())
+
+	entries, err := store.Search(Query{})
+	assert.NilError(t, err)
+	lines := []string{}
+	for _, entry := range entries {
+		lines = append(lines, entry.Line)
+	}
+	assert.DeepEqual(t, []string{"first", "second", "second again", "third"}, lines)
+	assert.Equal(t, entries[3].Time, start.Add(2*time.Second))
+}
+
+func TestSplitTimestamp(t *testing.T) {
+	timestamp, line := SplitTimestamp("2021-06-01T12:00:00.123456789Z Listening on :8080")
+	assert.Equal(t, timestamp, time.Date(2021, 6, 1, 12, 0, 0, 123456789, time.UTC))
+	assert.Equal(t, line, "Listening on :8080")
+
+	before := time.Now()
+	timestamp, line = SplitTimestamp("Listening on :8080")
+	assert.Assert(t, !timestamp.Before(before))
+	assert.Equal(t, line, "Listening on :8080")
+}
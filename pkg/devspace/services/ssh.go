@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	sshpkg "github.com/loft-sh/devspace/pkg/devspace/services/ssh"
+	"github.com/loft-sh/devspace/pkg/devspace/services/synccontroller"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// StartSSH starts a local ssh endpoint that forwards every connection to an ssh
+// server started by the devspace helper in the selected container
+func (serviceClient *client) StartSSH(interrupt chan error) error {
+	if serviceClient.config == nil || serviceClient.config.Config() == nil {
+		return fmt.Errorf("DevSpace config is not set")
+	}
+
+	sshConfig := serviceClient.config.Config().Dev.SSH
+	if sshConfig == nil || sshConfig.Disabled {
+		return nil
+	}
+
+	// apply config & set image selector
+	options := targetselector.NewEmptyOptions().ApplyConfigParameter(sshConfig.LabelSelector, sshConfig.Namespace, sshConfig.ContainerName, "")
+	options.AllowPick = false
+	options.ImageSelector = []imageselector.ImageSelector{}
+	if sshConfig.ImageSelector != "" {
+		imageSelector, err := util.ResolveImageAsImageSelector(sshConfig.ImageSelector, serviceClient.config, serviceClient.dependencies)
+		if err != nil {
+			return err
+		}
+
+		options.ImageSelector = append(options.ImageSelector, *imageSelector)
+	}
+	options.WaitingStrategy = targetselector.NewUntilNewestRunningWaitingStrategy(time.Second * 2)
+	options.SkipInitContainers = true
+
+	host := sshConfig.LocalHostname
+	if host == "" {
+		host = sshpkg.DefaultHost(filepath.Dir(serviceClient.config.Path()))
+	}
+
+	privateKeyPath, authorizedKey, err := sshpkg.GenerateKeyPair(host)
+	if err != nil {
+		return errors.Wrap(err, "generate ssh key pair")
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", sshConfig.LocalPort))
+	if err != nil {
+		return errors.Wrap(err, "listen for ssh connections")
+	}
+
+	err = sshpkg.ConfigureHost(host, listener.Addr().(*net.TCPAddr).Port, privateKeyPath)
+	if err != nil {
+		_ = listener.Close()
+		return errors.Wrap(err, "configure ssh host")
+	}
+
+	fileLog := logpkg.GetFileLogger("ssh")
+	serviceClient.log.Donef("SSH: Connect to the container via 'ssh %s'", ansi.Color(host, "white+b"))
+
+	go func() {
+		<-interrupt
+		_ = listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				err := serviceClient.forwardSSHConnection(conn, options, sshConfig, authorizedKey, fileLog)
+				if err != nil {
+					fileLog.Errorf("Error forwarding ssh connection: %v", err)
+				}
+			}(conn)
+		}
+	}()
+
+	return nil
+}
+
+func (serviceClient *client) forwardSSHConnection(conn net.Conn, options targetselector.Options, sshConfig *latest.SSH, authorizedKey string, log logpkg.Logger) error {
+	container, err := targetselector.NewTargetSelector(serviceClient.client).SelectSingleContainer(context.TODO(), options, log)
+	if err != nil {
+		return errors.Errorf("%s: %s", message.SelectorErrorPod, err.Error())
+	}
+
+	// make sure the devspace helper binary is injected
+	err = inject.InjectDevSpaceHelper(serviceClient.client, container.Pod, container.Container.Name, string(sshConfig.Arch), log)
+	if err != nil {
+		return err
+	}
+
+	return synccontroller.StartStream(serviceClient.client, container.Pod, container.Container.Name, []string{inject.DevSpaceHelperContainerPath, "ssh", "--authorized-key", authorizedKey}, conn, conn, false, log)
+}
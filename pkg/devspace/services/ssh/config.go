@@ -0,0 +1,107 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+var invalidHostCharsRegEx = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// DefaultHost returns the host name that is used for a project in the given directory
+func DefaultHost(projectDir string) string {
+	name := invalidHostCharsRegEx.ReplaceAllString(filepath.Base(projectDir), "-")
+	name = strings.Trim(name, "-.")
+	if name == "" {
+		name = "project"
+	}
+
+	return strings.ToLower(name) + ".devspace"
+}
+
+// ConfigureHost adds or replaces the host entry in the users ~/.ssh/config
+func ConfigureHost(host string, port int, privateKeyPath string) error {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return err
+	}
+
+	sshDir := filepath.Join(homeDir, ".ssh")
+	err = os.MkdirAll(sshDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	configPath := filepath.Join(sshDir, "config")
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "read ssh config")
+	}
+
+	newContent, err := replaceHost(string(content), host, port, privateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(configPath, []byte(newContent), 0600)
+}
+
+func startMarker(host string) string {
+	return "# DevSpace Start " + host
+}
+
+func endMarker(host string) string {
+	return "# DevSpace End " + host
+}
+
+// replaceHost removes an existing entry for the host from the ssh config content
+// and prepends a new entry, because ssh uses the first matching host entry
+func replaceHost(content, host string, port int, privateKeyPath string) (string, error) {
+	entry := &bytes.Buffer{}
+	fmt.Fprintln(entry, startMarker(host))
+	fmt.Fprintln(entry, "Host "+host)
+	fmt.Fprintln(entry, "  HostName localhost")
+	fmt.Fprintf(entry, "  Port %d\n", port)
+	fmt.Fprintln(entry, "  IdentityFile \""+privateKeyPath+"\"")
+	fmt.Fprintln(entry, "  IdentitiesOnly yes")
+	fmt.Fprintln(entry, "  StrictHostKeyChecking no")
+	fmt.Fprintln(entry, "  UserKnownHostsFile /dev/null")
+	fmt.Fprintln(entry, "  LogLevel error")
+	fmt.Fprintln(entry, "  User devspace")
+	fmt.Fprintln(entry, endMarker(host))
+
+	lines := strings.Split(content, "\n")
+	out := []string{}
+	inEntry := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == startMarker(host) {
+			inEntry = true
+			continue
+		} else if inEntry && trimmed == endMarker(host) {
+			inEntry = false
+			continue
+		} else if inEntry {
+			continue
+		}
+
+		out = append(out, line)
+	}
+	if inEntry {
+		return "", errors.Errorf("ssh config contains '%s' without '%s'", startMarker(host), endMarker(host))
+	}
+
+	rest := strings.TrimLeft(strings.Join(out, "\n"), "\n")
+	if rest == "" {
+		return entry.String(), nil
+	}
+
+	return entry.String() + "\n" + rest, nil
+}
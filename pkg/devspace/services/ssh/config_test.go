@@ -0,0 +1,44 @@
+package ssh
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const expectedEntry = `# DevSpace Start app.devspace
+Host app.devspace
+  HostName localhost
+  Port 10022
+  IdentityFile "/home/user/.devspace/ssh/app.devspace"
+  IdentitiesOnly yes
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+  LogLevel error
+  User devspace
+# DevSpace End app.devspace
+`
+
+func TestReplaceHost(t *testing.T) {
+	out, err := replaceHost("", "app.devspace", 10022, "/home/user/.devspace/ssh/app.devspace")
+	assert.NilError(t, err)
+	assert.Equal(t, expectedEntry, out)
+
+	existing := "Host other\n  HostName example.com\n"
+	out, err = replaceHost(existing, "app.devspace", 10022, "/home/user/.devspace/ssh/app.devspace")
+	assert.NilError(t, err)
+	assert.Equal(t, expectedEntry+"\n"+existing, out)
+
+	// replacing again should not duplicate the entry
+	out, err = replaceHost(out, "app.devspace", 10022, "/home/user/.devspace/ssh/app.devspace")
+	assert.NilError(t, err)
+	assert.Equal(t, expectedEntry+"\n"+existing, out)
+
+	_, err = replaceHost("# DevSpace Start app.devspace\nHost app.devspace\n", "app.devspace", 10022, "key")
+	assert.ErrorContains(t, err, "without")
+}
+
+func TestDefaultHost(t *testing.T) {
+	assert.Equal(t, "my-project.devspace", DefaultHost("/home/user/My Project"))
+	assert.Equal(t, "project.devspace", DefaultHost("/"))
+}
@@ -0,0 +1,62 @@
+package ssh
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// KeyFolder is the folder within the devspace home folder where the generated keys are stored
+const KeyFolder = "ssh"
+
+// GenerateKeyPair creates a new ephemeral key pair for the given host and returns the
+// path to the private key as well as the public key in authorized_keys format
+func GenerateKeyPair(host string) (string, string, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return "", "", err
+	}
+
+	keyFolder := filepath.Join(homeDir, constants.DefaultHomeDevSpaceFolder, KeyFolder)
+	err = os.MkdirAll(keyFolder, 0700)
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", errors.Wrap(err, "generate key")
+	}
+
+	privateKeyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey, err := gossh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKeyPath := filepath.Join(keyFolder, host)
+	err = ioutil.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: privateKeyBytes,
+	}), 0600)
+	if err != nil {
+		return "", "", errors.Wrap(err, "write private key")
+	}
+
+	return privateKeyPath, strings.TrimSpace(string(gossh.MarshalAuthorizedKey(publicKey))), nil
+}
@@ -10,10 +10,13 @@ import (
 	kubectlExec "k8s.io/client-go/util/exec"
 
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	interruptpkg "github.com/loft-sh/devspace/pkg/util/interrupt"
 
 	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
 )
 
 type InterruptError struct{}
@@ -27,6 +30,26 @@ func (serviceClient *client) StartTerminal(
 	options targetselector.Options,
 	args []string,
 	workDir string,
+	session string,
+	debugImage string,
+	interrupt chan error,
+	wait,
+	restart bool,
+	stdout io.Writer,
+	stderr io.Writer,
+	stdin io.Reader,
+) (int, error) {
+	return serviceClient.startTerminal(options, args, workDir, session, debugImage, "", interrupt, wait, restart, stdout, stderr, stdin)
+}
+
+// startTerminal opens a new terminal and reuses the debug container with the given name if the terminal restarts
+func (serviceClient *client) startTerminal(
+	options targetselector.Options,
+	args []string,
+	workDir string,
+	session string,
+	debugImage string,
+	debugContainer string,
 	interrupt chan error,
 	wait,
 	restart bool,
@@ -35,6 +58,19 @@ func (serviceClient *client) StartTerminal(
 	stdin io.Reader,
 ) (int, error) {
 	command := serviceClient.getCommand(args, workDir)
+	if serviceClient.config != nil && serviceClient.config.Config() != nil && serviceClient.config.Config().Dev.Terminal != nil {
+		terminalConfig := serviceClient.config.Config().Dev.Terminal
+		if session == "" {
+			session = terminalConfig.Session
+		}
+		if debugImage == "" && terminalConfig.Debug != nil && terminalConfig.Debug.Enabled {
+			debugImage = terminalConfig.Debug.Image
+			if debugImage == "" {
+				debugImage = DefaultDebugImage
+			}
+		}
+	}
+
 	targetSelector := targetselector.NewTargetSelector(serviceClient.client)
 	if !wait {
 		options.Wait = &wait
@@ -50,12 +86,71 @@ func (serviceClient *client) StartTerminal(
 		return 0, err
 	}
 
-	wrapper, upgradeRoundTripper, err := serviceClient.client.GetUpgraderWrapper()
-	if err != nil {
+	// open the terminal in an ephemeral debug container instead
+	if debugImage != "" {
+		container, err = serviceClient.startDebugContainer(container, debugImage, debugContainer)
+		if err != nil {
+			return 0, errors.Wrap(err, "start debug container")
+		}
+
+		debugContainer = container.Container.Name
+	}
+
+	if session != "" {
+		err = inject.InjectDevSpaceHelper(serviceClient.client, container.Pod, container.Container.Name, "", serviceClient.log)
+		if err != nil {
+			return 0, errors.Wrap(err, "inject devspacehelper")
+		}
+
+		command = append([]string{inject.DevSpaceHelperContainerPath, "session", "attach", session, "--"}, command...)
+	}
+
+	if session != "" {
+		serviceClient.log.Infof("Attaching to session %s in pod:container %s:%s", ansi.Color(session, "white+b"), ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+	} else {
+		serviceClient.log.Infof("Opening shell to pod:container %s:%s", ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+	}
+
+	interrupted, err := serviceClient.execInContainer(container, command, interrupt, stdout, stderr, stdin)
+	if interrupted {
+		return 0, err
+	} else if err != nil {
+		if _, ok := err.(*InterruptError); ok {
+			return 0, err
+		} else if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			// Expected exit codes are (https://shapeshed.com/unix-exit-codes/):
+			// 1 - Catchall for general errors
+			// 2 - Misuse of shell builtins (according to Bash documentation)
+			// 126 - Command invoked cannot execute
+			// 127 - “command not found”
+			// 128 - Invalid argument to exit
+			// 130 - Script terminated by Control-C
+			if restart && IsUnexpectedExitCode(exitError.Code) {
+				serviceClient.log.WriteString("\n")
+				serviceClient.log.Infof("Restarting terminal because: %s", err)
+				return serviceClient.startTerminal(options, args, workDir, session, debugImage, debugContainer, interrupt, wait, restart, stdout, stderr, stdin)
+			}
+
+			return exitError.Code, nil
+		} else if restart {
+			serviceClient.log.WriteString("\n")
+			serviceClient.log.Infof("Restarting terminal because: %s", err)
+			return serviceClient.startTerminal(options, args, workDir, session, debugImage, debugContainer, interrupt, wait, restart, stdout, stderr, stdin)
+		}
+
 		return 0, err
 	}
 
-	serviceClient.log.Infof("Opening shell to pod:container %s:%s", ansi.Color(container.Pod.Name, "white+b"), ansi.Color(container.Container.Name, "white+b"))
+	return 0, nil
+}
+
+// execInContainer executes the command in the container with a tty if stdin is a terminal and
+// returns when the command has finished or the interrupt channel receives an error
+func (serviceClient *client) execInContainer(container *selector.SelectedPodContainer, command []string, interrupt chan error, stdout io.Writer, stderr io.Writer, stdin io.Reader) (bool, error) {
+	wrapper, upgradeRoundTripper, err := serviceClient.client.GetUpgraderWrapper()
+	if err != nil {
+		return false, err
+	}
 
 	done := make(chan error)
 	go func() {
@@ -83,37 +178,10 @@ func (serviceClient *client) StartTerminal(
 	case err = <-interrupt:
 		_ = upgradeRoundTripper.Close()
 		<-done
-		return 0, err
+		return true, err
 	case err = <-done:
-		if err != nil {
-			if _, ok := err.(*InterruptError); ok {
-				return 0, err
-			} else if exitError, ok := err.(kubectlExec.CodeExitError); ok {
-				// Expected exit codes are (https://shapeshed.com/unix-exit-codes/):
-				// 1 - Catchall for general errors
-				// 2 - Misuse of shell builtins (according to Bash documentation)
-				// 126 - Command invoked cannot execute
-				// 127 - “command not found”
-				// 128 - Invalid argument to exit
-				// 130 - Script terminated by Control-C
-				if restart && IsUnexpectedExitCode(exitError.Code) {
-					serviceClient.log.WriteString("\n")
-					serviceClient.log.Infof("Restarting terminal because: %s", err)
-					return serviceClient.StartTerminal(options, args, workDir, interrupt, wait, restart, stdout, stderr, stdin)
-				}
-
-				return exitError.Code, nil
-			} else if restart {
-				serviceClient.log.WriteString("\n")
-				serviceClient.log.Infof("Restarting terminal because: %s", err)
-				return serviceClient.StartTerminal(options, args, workDir, interrupt, wait, restart, stdout, stderr, stdin)
-			}
-
-			return 0, err
-		}
+		return false, err
 	}
-
-	return 0, nil
 }
 
 func IsUnexpectedExitCode(code int) bool {
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"io"
 	"os"
 	"os/exec"
@@ -130,7 +131,12 @@ func ShouldExecuteOnOS(os string) bool {
 }
 
 func ExecuteCommandWithEnv(cmd string, args []string, dir string, stdout io.Writer, stderr io.Writer, extraEnvVars map[string]string) error {
-	err := NewStreamCommand(cmd, args).RunWithEnv(stdout, stderr, nil, dir, extraEnvVars)
+	return ExecuteCommandWithContext(context.Background(), cmd, args, dir, stdout, stderr, extraEnvVars)
+}
+
+// ExecuteCommandWithContext executes the command and kills it when the context is cancelled
+func ExecuteCommandWithContext(ctx context.Context, cmd string, args []string, dir string, stdout io.Writer, stderr io.Writer, extraEnvVars map[string]string) error {
+	err := (&StreamCommand{cmd: exec.CommandContext(ctx, cmd, args...)}).RunWithEnv(stdout, stderr, nil, dir, extraEnvVars)
 	if err != nil {
 		if errr, ok := err.(*exec.ExitError); ok {
 			return errors.Errorf("error executing command '%s %s': code: %d, error: %s, %s", cmd, strings.Join(args, " "), errr.ExitCode(), string(errr.Stderr), errr)
@@ -80,10 +80,49 @@ func (gr *GitCLIRepository) Clone(options CloneOptions) error {
 
 	// make sure the repo is up to date
 	if options.Commit == "" {
+		// a detached head cannot be pulled, so we clone the repository again
+		err := exec.Command("git", "-C", gr.LocalPath, "symbolic-ref", "-q", "HEAD").Run()
+		if err != nil {
+			err = os.RemoveAll(gr.LocalPath)
+			if err != nil {
+				return err
+			}
+
+			return gr.Clone(options)
+		}
+
 		out, err := exec.Command("git", "-C", gr.LocalPath, "pull").CombinedOutput()
 		if err != nil {
 			return errors.Errorf("Error running 'git pull %s': %v -> %s", options.URL, err, string(out))
 		}
+
+		return nil
+	}
+
+	// make sure the commit is checked out
+	out, err := exec.Command("git", "-C", gr.LocalPath, "rev-parse", "HEAD").Output()
+	if err == nil && strings.HasPrefix(strings.TrimSpace(string(out)), options.Commit) {
+		return nil
+	}
+
+	out, err = exec.Command("git", "-C", gr.LocalPath, "fetch", "origin", options.Commit).CombinedOutput()
+	if err != nil {
+		// some servers do not allow fetching a commit directly, so we fetch the whole history instead
+		fetchArgs := []string{"-C", gr.LocalPath, "fetch", "origin"}
+		out, err = exec.Command("git", "-C", gr.LocalPath, "rev-parse", "--is-shallow-repository").Output()
+		if err == nil && strings.TrimSpace(string(out)) == "true" {
+			fetchArgs = []string{"-C", gr.LocalPath, "fetch", "--unshallow", "origin"}
+		}
+
+		out, err = exec.Command("git", fetchArgs...).CombinedOutput()
+		if err != nil {
+			return errors.Errorf("Error running 'git fetch %s': %v -> %s", options.URL, err, string(out))
+		}
+	}
+
+	out, err = exec.Command("git", "-C", gr.LocalPath, "checkout", options.Commit).CombinedOutput()
+	if err != nil {
+		return errors.Errorf("Error running 'git checkout %s': %v -> %s", options.Commit, err, string(out))
 	}
 
 	return nil
@@ -30,7 +30,7 @@ func GetHash(localPath string) (string, error) {
 	if err != nil {
 		// last resort, try with cli
 		if isGitCommandAvailable() {
-			out, err := exec.Command("git", "rev-parse", "HEAD").CombinedOutput()
+			out, err := exec.Command("git", "-C", localPath, "rev-parse", "HEAD").CombinedOutput()
 			if err != nil {
 				return "", errors.Errorf("Error running 'git rev-parse HEAD': %v -> %s", err, string(out))
 			}
@@ -34,11 +34,13 @@ func GetFileLogger(filename string) Logger {
 			logger: logrus.New(),
 		}
 		newLogger.logger.Formatter = &logrus.JSONFormatter{}
-		newLogger.logger.SetOutput(&lumberjack.Logger{
-			Filename:   Logdir + filename + ".log",
-			MaxAge:     12,
-			MaxBackups: 4,
-			MaxSize:    10 * 1024 * 1024,
+		newLogger.logger.SetOutput(&redactWriter{
+			writer: &lumberjack.Logger{
+				Filename:   Logdir + filename + ".log",
+				MaxAge:     12,
+				MaxBackups: 4,
+				MaxSize:    10 * 1024 * 1024,
+			},
 		})
 
 		newLogger.SetLevel(GetInstance().GetLevel())
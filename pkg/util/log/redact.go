@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// RedactedValue is printed instead of a secret value
+const RedactedValue = "******"
+
+var (
+	secrets     []string
+	secretsLock sync.RWMutex
+)
+
+// AddSecret registers a secret value that is redacted from all log output
+func AddSecret(secret string) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return
+	}
+
+	secretsLock.Lock()
+	defer secretsLock.Unlock()
+
+	for _, s := range secrets {
+		if s == secret {
+			return
+		}
+	}
+
+	secrets = append(secrets, secret)
+}
+
+// Redact replaces all registered secrets within the message
+func Redact(message string) string {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+
+	for _, secret := range secrets {
+		message = strings.ReplaceAll(message, secret, RedactedValue)
+	}
+
+	return message
+}
+
+func redactBytes(message []byte) []byte {
+	secretsLock.RLock()
+	defer secretsLock.RUnlock()
+
+	for _, secret := range secrets {
+		message = bytes.ReplaceAll(message, []byte(secret), []byte(RedactedValue))
+	}
+
+	return message
+}
+
+// redactWriter redacts all registered secrets before writing to the underlying writer
+type redactWriter struct {
+	writer io.Writer
+}
+
+func (r *redactWriter) Write(message []byte) (int, error) {
+	_, err := r.writer.Write(redactBytes(message))
+	return len(message), err
+}
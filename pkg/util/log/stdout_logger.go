@@ -108,7 +108,7 @@ func (s *stdoutLogger) writeMessage(fnType logFunctionType, message string) {
 			_, _ = fnInformation.stream.Write([]byte(ansi.Color(formatInt(now.Hour())+":"+formatInt(now.Minute())+":"+formatInt(now.Second())+" ", "white+b")))
 		}
 		_, _ = fnInformation.stream.Write([]byte(ansi.Color(fnInformation.tag, fnInformation.color)))
-		_, _ = fnInformation.stream.Write([]byte(message))
+		_, _ = fnInformation.stream.Write([]byte(Redact(message)))
 
 		if s.loadingText != nil && fnType != fatalFn {
 			s.loadingText.Start()
@@ -416,13 +416,13 @@ func (s *stdoutLogger) Write(message []byte) (int, error) {
 			s.loadingText.Stop()
 		}
 
-		n, err := fnTypeInformationMap[infoFn].stream.Write(message)
+		_, err := fnTypeInformationMap[infoFn].stream.Write(redactBytes(message))
 
 		if s.loadingText != nil {
 			s.loadingText.Start()
 		}
 
-		return n, err
+		return len(message), err
 	}
 
 	return len(message), nil
@@ -437,7 +437,7 @@ func (s *stdoutLogger) WriteString(message string) {
 			s.loadingText.Stop()
 		}
 
-		_, _ = fnTypeInformationMap[infoFn].stream.Write([]byte(message))
+		_, _ = fnTypeInformationMap[infoFn].stream.Write([]byte(Redact(message)))
 
 		if s.loadingText != nil {
 			s.loadingText.Start()
@@ -77,7 +77,7 @@ func (s *StreamLogger) writeMessage(fnType logFunctionType, message string) {
 			panic(err)
 		}
 
-		_, err = s.stream.Write([]byte(message))
+		_, err = s.stream.Write([]byte(Redact(message)))
 		if err != nil {
 			panic(err)
 		}
@@ -295,7 +295,8 @@ func (s *StreamLogger) Write(message []byte) (int, error) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	return s.stream.Write(message)
+	_, err := s.stream.Write(redactBytes(message))
+	return len(message), err
 }
 
 // WriteString implements interface
@@ -303,7 +304,7 @@ func (s *StreamLogger) WriteString(message string) {
 	s.logMutex.Lock()
 	defer s.logMutex.Unlock()
 
-	_, err := s.stream.Write([]byte(message))
+	_, err := s.stream.Write([]byte(Redact(message)))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,89 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+// builtin is a portable implementation of a common command, which is used if the command
+// cannot be found on the system, e.g. on windows machines without coreutils
+type builtin func(ctx context.Context, hc *interp.HandlerContext, args []string) error
+
+var builtins = map[string]builtin{
+	"cat": func(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+		return cat(hc, args)
+	},
+	"cp":    cp,
+	"grep":  grep,
+	"mkdir": mkdir,
+	"mv":    mv,
+	"rm":    rm,
+	"sed":   sed,
+	"sleep": sleep,
+	"touch": touch,
+}
+
+// executeBuiltin executes the builtin and converts its error to an exit status
+func executeBuiltin(ctx context.Context, hc *interp.HandlerContext, command builtin, args []string) error {
+	err := command(ctx, hc, args)
+	if err != nil {
+		if _, ok := interp.IsExitStatus(err); ok {
+			return err
+		} else if exitErr, ok := err.(*exitError); ok {
+			_, _ = fmt.Fprintln(hc.Stderr, exitErr.message)
+			return interp.NewExitStatus(exitErr.status)
+		}
+
+		_, _ = fmt.Fprintln(hc.Stderr, err)
+		return interp.NewExitStatus(1)
+	}
+
+	return interp.NewExitStatus(0)
+}
+
+// parseFlags parses the leading short flags, e.g. -rf, of the arguments. Flags that are not
+// part of allowed return an error. The flag parsing stops at the first argument that is not
+// a flag or at --
+func parseFlags(command string, args []string, allowed string) (map[rune]bool, []string, error) {
+	flags := map[rune]bool{}
+	for i, arg := range args {
+		if arg == "--" {
+			return flags, args[i+1:], nil
+		} else if len(arg) < 2 || arg[0] != '-' {
+			return flags, args[i:], nil
+		}
+
+		for _, flag := range arg[1:] {
+			if !strings.ContainsRune(allowed, flag) {
+				return nil, nil, fmt.Errorf("%s: invalid option -- '%c'", command, flag)
+			}
+
+			flags[flag] = true
+		}
+	}
+
+	return flags, []string{}, nil
+}
+
+// resolvePath returns the path relative to the working directory of the shell. An empty
+// path stays empty, so that it doesn't resolve to the working directory itself
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
+
+func stdin(hc *interp.HandlerContext) io.Reader {
+	if hc.Stdin == nil {
+		return strings.NewReader("")
+	}
+
+	return hc.Stdin
+}
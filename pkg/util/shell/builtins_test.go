@@ -0,0 +1,185 @@
+package shell
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+)
+
+type builtinTestCase struct {
+	name           string
+	command        string
+	expectedOutput string
+	expectedStatus uint8
+}
+
+// this test forces the builtin implementations to execute
+func TestBuiltins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "file.txt"), []byte("hello world\nHello DevSpace\nbye\n"), 0644)
+	assert.NilError(t, err)
+
+	lookPathDir = func(cwd string, env expand.Environ, file string) (string, error) {
+		return "", errors.New("not found")
+	}
+	defer func() { lookPathDir = interp.LookPathDir }()
+
+	// the test cases depend on each other
+	testCases := []builtinTestCase{
+		{
+			name:           "mkdir, touch and test",
+			command:        "mkdir -p a/b/c && touch a/b/c/file && test -f a/b/c/file && [ -d a/b ] && echo ok",
+			expectedOutput: "ok\n",
+		},
+		{
+			name:           "mkdir existing",
+			command:        "mkdir a",
+			expectedStatus: 1,
+		},
+		{
+			name:           "cp and mv",
+			command:        "mkdir copy && cp file.txt copy && cp -r copy copy2 && mv copy2/file.txt moved.txt && cat moved.txt",
+			expectedOutput: "hello world\nHello DevSpace\nbye\n",
+		},
+		{
+			name:           "cp directory without -r",
+			command:        "cp copy copy3",
+			expectedStatus: 1,
+		},
+		{
+			name:           "cp directory into itself",
+			command:        "! cp -r copy copy/ && test ! -e copy/copy && echo refused",
+			expectedOutput: "refused\n",
+		},
+		{
+			name:           "mv directory into itself",
+			command:        "! mv copy copy/ && test ! -e copy/copy && test -f copy/file.txt && echo refused",
+			expectedOutput: "refused\n",
+		},
+		{
+			name:           "rm",
+			command:        "rm -rf a copy copy2 notexisting && rm moved.txt && test ! -e a -a ! -e moved.txt && echo removed",
+			expectedOutput: "removed\n",
+		},
+		{
+			name:           "rm without -f",
+			command:        "rm notexisting",
+			expectedStatus: 1,
+		},
+		{
+			name:           "rm empty operand",
+			command:        `rm -rf "$UNSET_VARIABLE" && test -f file.txt && echo kept`,
+			expectedOutput: "kept\n",
+		},
+		{
+			name:           "rm empty operand without -f",
+			command:        `rm -r "$UNSET_VARIABLE"`,
+			expectedStatus: 1,
+		},
+		{
+			name:           "rm current directory",
+			command:        "! rm -rf . && test -f file.txt && echo kept",
+			expectedOutput: "kept\n",
+		},
+		{
+			name:           "rm parent directory",
+			command:        "mkdir sub && ! rm -rf sub/.. && test -d sub && echo kept",
+			expectedOutput: "kept\n",
+		},
+		{
+			name:           "rm root directory",
+			command:        "rm -rf /",
+			expectedStatus: 1,
+		},
+		{
+			name:           "grep",
+			command:        "grep -in hello file.txt",
+			expectedOutput: "1:hello world\n2:Hello DevSpace\n",
+		},
+		{
+			name:           "grep basic regex",
+			command:        `grep '^\(bye\|hello\)' file.txt`,
+			expectedOutput: "hello world\nbye\n",
+		},
+		{
+			name:           "grep stdin",
+			command:        "echo abc | grep -c -v -E 'x|y'",
+			expectedOutput: "1\n",
+		},
+		{
+			name:           "grep no match",
+			command:        "grep -q missing file.txt",
+			expectedStatus: 1,
+		},
+		{
+			name:           "grep missing file",
+			command:        "grep hello missing.txt",
+			expectedStatus: 2,
+		},
+		{
+			name:           "sed substitute",
+			command:        `sed 's/\(hello\) \(.*\)/\2 \1/; s/e/E/g' file.txt`,
+			expectedOutput: "world hEllo\nHEllo DEvSpacE\nbyE\n",
+		},
+		{
+			name:           "sed address",
+			command:        "sed -n -e '/Hello/,$p' -e '1d' file.txt",
+			expectedOutput: "Hello DevSpace\nbye\n",
+		},
+		{
+			name:           "sed stdin",
+			command:        `printf '1 2 3\n4 5\n6\n' | sed -E 's|([0-9]+)|<&>|2;2q'`,
+			expectedOutput: "1 <2> 3\n4 <5>\n",
+		},
+		{
+			name:           "sed in place",
+			command:        "sed -i '$d' file.txt && cat file.txt",
+			expectedOutput: "hello world\nHello DevSpace\n",
+		},
+		{
+			name:           "sed invalid",
+			command:        "sed 'x' file.txt",
+			expectedStatus: 1,
+		},
+		{
+			name:           "sleep",
+			command:        "sleep 0.01 0.01s && echo slept",
+			expectedOutput: "slept\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		err := ExecuteShellCommand(testCase.command, nil, dir, stdout, stderr, nil)
+		status, _ := interp.IsExitStatus(err)
+		if testCase.expectedStatus == 0 {
+			assert.NilError(t, err, "Unexpected error in testCase %s: %s", testCase.name, stderr.String())
+		}
+		assert.Equal(t, status, testCase.expectedStatus, "Unexpected exit status in testCase %s: %v", testCase.name, err)
+		assert.Equal(t, stdout.String(), testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+	}
+}
+
+func TestIsCrossDevice(t *testing.T) {
+	errno := syscall.EXDEV
+	if runtime.GOOS == "windows" {
+		errno = errorNotSameDevice
+	}
+
+	assert.Assert(t, isCrossDevice(&os.LinkError{Op: "rename", Old: "a", New: "b", Err: errno}))
+	assert.Assert(t, !isCrossDevice(&os.LinkError{Op: "rename", Old: "a", New: "b", Err: syscall.ENOENT}))
+	assert.Assert(t, !isCrossDevice(errors.New("rename failed")))
+}
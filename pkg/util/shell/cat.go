@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 
 	"mvdan.cc/sh/v3/interp"
 )
@@ -19,7 +18,7 @@ func cat(ctx *interp.HandlerContext, args []string) error {
 	}
 
 	for _, arg := range args {
-		file := filepath.Join(ctx.Dir, arg)
+		file := resolvePath(ctx.Dir, arg)
 		err := printFile(file, ctx.Stdout)
 		if err != nil {
 			return fmt.Errorf("cat: %v", err)
@@ -0,0 +1,310 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+func mkdir(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	flags, args, err := parseFlags("mkdir", args, "p")
+	if err != nil {
+		return err
+	} else if len(args) == 0 {
+		return fmt.Errorf("mkdir: missing operand")
+	}
+
+	for _, arg := range args {
+		if flags['p'] {
+			err = os.MkdirAll(resolvePath(hc.Dir, arg), 0755)
+		} else {
+			err = os.Mkdir(resolvePath(hc.Dir, arg), 0755)
+		}
+		if err != nil {
+			return fmt.Errorf("mkdir: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func rm(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	flags, args, err := parseFlags("rm", args, "rRf")
+	if err != nil {
+		return err
+	} else if len(args) == 0 && !flags['f'] {
+		return fmt.Errorf("rm: missing operand")
+	}
+
+	recursive := flags['r'] || flags['R']
+	for _, arg := range args {
+		if arg == "" {
+			if flags['f'] {
+				continue
+			}
+
+			return fmt.Errorf("rm: cannot remove '': No such file or directory")
+		} else if base := filepath.Base(arg); base == "." || base == ".." {
+			return fmt.Errorf("rm: refusing to remove '.' or '..' directory: skipping '%s'", arg)
+		}
+
+		path := resolvePath(hc.Dir, arg)
+		if isRoot(path) {
+			return fmt.Errorf("rm: it is dangerous to operate recursively on '%s'", arg)
+		}
+
+		stat, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) && flags['f'] {
+				continue
+			}
+
+			return fmt.Errorf("rm: cannot remove '%s': %v", arg, err)
+		} else if stat.IsDir() && !recursive {
+			return fmt.Errorf("rm: cannot remove '%s': Is a directory", arg)
+		}
+
+		if recursive {
+			err = os.RemoveAll(path)
+		} else {
+			err = os.Remove(path)
+		}
+		if err != nil {
+			return fmt.Errorf("rm: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// isRoot returns true if the path is the root of a filesystem, e.g. / or C:\
+func isRoot(path string) bool {
+	path = filepath.Clean(path)
+	return filepath.Dir(path) == path
+}
+
+func cp(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	flags, args, err := parseFlags("cp", args, "rRfp")
+	if err != nil {
+		return err
+	} else if len(args) < 2 {
+		return fmt.Errorf("cp: missing destination file operand")
+	}
+
+	return moveOrCopy("cp", hc, args, func(source, target string) error {
+		stat, err := os.Stat(source)
+		if err != nil {
+			return err
+		} else if stat.IsDir() && !flags['r'] && !flags['R'] {
+			return fmt.Errorf("-r not specified; omitting directory '%s'", source)
+		} else if stat.IsDir() && isSubPath(source, target) {
+			return fmt.Errorf("cannot copy a directory, '%s', into itself, '%s'", source, target)
+		}
+
+		return copyPath(source, target)
+	})
+}
+
+func mv(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	_, args, err := parseFlags("mv", args, "f")
+	if err != nil {
+		return err
+	} else if len(args) < 2 {
+		return fmt.Errorf("mv: missing destination file operand")
+	}
+
+	return moveOrCopy("mv", hc, args, movePath)
+}
+
+// movePath renames the source to the target and falls back to copying and removing the
+// source if both are on different filesystems
+func movePath(source, target string) error {
+	if isSubPath(source, target) {
+		return fmt.Errorf("cannot move '%s' to a subdirectory of itself, '%s'", source, target)
+	}
+
+	err := os.Rename(source, target)
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+
+	err = copyPath(source, target)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(source)
+}
+
+// errorNotSameDevice is the windows error that is returned when renaming across volumes
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDevice returns true if the error is returned by os.Rename because source and
+// target are on different filesystems
+func isCrossDevice(err error) bool {
+	linkErr, ok := err.(*os.LinkError)
+	if !ok {
+		return false
+	}
+
+	errno, ok := linkErr.Err.(syscall.Errno)
+	if !ok {
+		return false
+	} else if runtime.GOOS == "windows" {
+		return errno == errorNotSameDevice
+	}
+
+	return errno == syscall.EXDEV
+}
+
+// isSubPath returns true if the path is below the parent directory
+func isSubPath(parent, path string) bool {
+	rel, err := filepath.Rel(filepath.Clean(parent), filepath.Clean(path))
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// moveOrCopy calls fn for every source. If the last argument is an existing directory, the
+// sources are moved or copied into it
+func moveOrCopy(command string, hc *interp.HandlerContext, args []string, fn func(source, target string) error) error {
+	sources := args[:len(args)-1]
+	target := resolvePath(hc.Dir, args[len(args)-1])
+	stat, err := os.Stat(target)
+	targetIsDir := err == nil && stat.IsDir()
+	if len(sources) > 1 && !targetIsDir {
+		return fmt.Errorf("%s: target '%s' is not a directory", command, args[len(args)-1])
+	}
+
+	for _, source := range sources {
+		sourcePath := resolvePath(hc.Dir, source)
+		targetPath := target
+		if targetIsDir {
+			targetPath = filepath.Join(target, filepath.Base(sourcePath))
+		}
+
+		err = fn(sourcePath, targetPath)
+		if err != nil {
+			return fmt.Errorf("%s: %v", command, err)
+		}
+	}
+
+	return nil
+}
+
+func copyPath(source, target string) error {
+	return filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+
+		targetPath := filepath.Join(target, rel)
+		if info.IsDir() {
+			return os.MkdirAll(targetPath, info.Mode().Perm())
+		}
+
+		return copyFile(path, targetPath, info.Mode().Perm())
+	})
+}
+
+func copyFile(source, target string, mode os.FileMode) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+func touch(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("touch: missing file operand")
+	}
+
+	now := time.Now()
+	for _, arg := range args {
+		path := resolvePath(hc.Dir, arg)
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("touch: %v", err)
+			}
+
+			_ = f.Close()
+			continue
+		}
+
+		err = os.Chtimes(path, now, now)
+		if err != nil {
+			return fmt.Errorf("touch: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func sleep(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("sleep: missing operand")
+	}
+
+	var total time.Duration
+	for _, arg := range args {
+		duration, err := parseSleepDuration(arg)
+		if err != nil {
+			return fmt.Errorf("sleep: invalid time interval '%s'", arg)
+		}
+
+		total += duration
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(total):
+		return nil
+	}
+}
+
+// parseSleepDuration parses a number with an optional suffix s, m, h or d
+func parseSleepDuration(value string) (time.Duration, error) {
+	unit := time.Second
+	switch {
+	case len(value) > 1 && value[len(value)-1] == 's':
+		value = value[:len(value)-1]
+	case len(value) > 1 && value[len(value)-1] == 'm':
+		value, unit = value[:len(value)-1], time.Minute
+	case len(value) > 1 && value[len(value)-1] == 'h':
+		value, unit = value[:len(value)-1], time.Hour
+	case len(value) > 1 && value[len(value)-1] == 'd':
+		value, unit = value[:len(value)-1], 24*time.Hour
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid time interval")
+	}
+
+	return time.Duration(number * float64(unit)), nil
+}
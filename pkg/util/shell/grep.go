@@ -0,0 +1,214 @@
+package shell
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+func grep(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	patterns := []string{}
+	flags := map[rune]bool{}
+	rest := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i+1:]...)
+			break
+		} else if arg == "-e" {
+			if i+1 >= len(args) {
+				return grepError("grep: option requires an argument -- 'e'")
+			}
+
+			patterns = append(patterns, args[i+1])
+			i++
+			continue
+		} else if len(arg) < 2 || arg[0] != '-' {
+			rest = append(rest, arg)
+			continue
+		}
+
+		for _, flag := range arg[1:] {
+			if !strings.ContainsRune("icvqnlxwFEHhs", flag) {
+				return grepError(fmt.Sprintf("grep: invalid option -- '%c'", flag))
+			}
+
+			flags[flag] = true
+		}
+	}
+	if len(patterns) == 0 {
+		if len(rest) == 0 {
+			return grepError("usage: grep [-icvqnlxwFEHhs] [-e pattern] pattern [file...]")
+		}
+
+		patterns, rest = []string{rest[0]}, rest[1:]
+	}
+
+	expressions := []string{}
+	for _, pattern := range patterns {
+		// a pattern can contain multiple patterns separated by newlines
+		for _, p := range strings.Split(pattern, "\n") {
+			if flags['F'] {
+				p = regexp.QuoteMeta(p)
+			} else if !flags['E'] {
+				p = basicToExtended(p)
+			}
+			if flags['w'] {
+				p = `\b(?:` + p + `)\b`
+			}
+
+			expressions = append(expressions, "(?:"+p+")")
+		}
+	}
+
+	expression := strings.Join(expressions, "|")
+	if flags['x'] {
+		expression = "^(?:" + expression + ")$"
+	}
+	if flags['i'] {
+		expression = "(?i)" + expression
+	}
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		return grepError(fmt.Sprintf("grep: %v", err))
+	}
+
+	showNames := (len(rest) > 1 || flags['H']) && !flags['h']
+	matched := false
+	failed := false
+	if len(rest) == 0 {
+		matched = grepReader(hc.Stdout, stdin(hc), "(standard input)", re, flags, false)
+	}
+	for _, file := range rest {
+		f, err := os.Open(resolvePath(hc.Dir, file))
+		if err != nil {
+			if !flags['s'] {
+				_, _ = fmt.Fprintf(hc.Stderr, "grep: %s: %v\n", file, unwrapPathError(err))
+			}
+
+			failed = true
+			continue
+		}
+
+		if grepReader(hc.Stdout, f, file, re, flags, showNames) {
+			matched = true
+		}
+		_ = f.Close()
+		if matched && flags['q'] {
+			break
+		}
+	}
+
+	if failed && !(matched && flags['q']) {
+		return interp.NewExitStatus(2)
+	} else if !matched {
+		return interp.NewExitStatus(1)
+	}
+
+	return nil
+}
+
+// grepReader prints the matching lines of the reader and returns true if a line matched
+func grepReader(stdout io.Writer, reader io.Reader, name string, re *regexp.Regexp, flags map[rune]bool, showName bool) bool {
+	count := 0
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if re.MatchString(line) == flags['v'] {
+			continue
+		}
+
+		count++
+		if flags['q'] {
+			return true
+		} else if flags['l'] {
+			_, _ = fmt.Fprintln(stdout, name)
+			return true
+		} else if flags['c'] {
+			continue
+		}
+
+		prefix := ""
+		if showName {
+			prefix += name + ":"
+		}
+		if flags['n'] {
+			prefix += fmt.Sprintf("%d:", lineNumber)
+		}
+		_, _ = fmt.Fprintln(stdout, prefix+line)
+	}
+
+	if flags['c'] {
+		if showName {
+			_, _ = fmt.Fprintf(stdout, "%s:%d\n", name, count)
+		} else {
+			_, _ = fmt.Fprintln(stdout, count)
+		}
+	}
+
+	return count > 0
+}
+
+func grepError(message string) error {
+	return &exitError{message: message, status: 2}
+}
+
+// exitError is an error that is printed and exits with the given status
+type exitError struct {
+	message string
+	status  uint8
+}
+
+func (e *exitError) Error() string {
+	return e.message
+}
+
+// basicToExtended converts a posix basic regular expression, e.g. \(a\|b\)\+, into an
+// extended regular expression that can be compiled by go, e.g. (a|b)+
+func basicToExtended(pattern string) string {
+	out := strings.Builder{}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c == '\\' && i+1 < len(pattern) {
+			next := pattern[i+1]
+			switch next {
+			case '(', ')', '{', '}', '|', '+', '?':
+				out.WriteByte(next)
+			default:
+				out.WriteByte(c)
+				out.WriteByte(next)
+			}
+
+			i++
+			continue
+		}
+
+		switch c {
+		case '(', ')', '{', '}', '|', '+', '?':
+			out.WriteByte('\\')
+		}
+		out.WriteByte(c)
+	}
+
+	return out.String()
+}
+
+// unwrapPathError returns the underlying error of a path error, so that the path is not printed twice
+func unwrapPathError(err error) error {
+	if pathErr, ok := err.(*os.PathError); ok {
+		if os.IsNotExist(pathErr.Err) {
+			return fmt.Errorf("No such file or directory")
+		}
+
+		return pathErr.Err
+	}
+
+	return err
+}
@@ -0,0 +1,489 @@
+package shell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mvdan.cc/sh/v3/interp"
+)
+
+// sedCommand is a single command of a sed script, e.g. 1,/^$/s/a/b/g
+type sedCommand struct {
+	from, to *sedAddress
+	negate   bool
+	name     byte
+
+	// substitution
+	re          *regexp.Regexp
+	replacement string
+	global      bool
+	occurrence  int
+	print       bool
+
+	active bool
+}
+
+// sedAddress selects lines by number, regular expression or $ for the last line
+type sedAddress struct {
+	line int
+	last bool
+	re   *regexp.Regexp
+}
+
+func sed(ctx context.Context, hc *interp.HandlerContext, args []string) error {
+	scripts := []string{}
+	quiet, inPlace, extended := false, false, false
+	files := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			files = append(files, args[i+1:]...)
+			i = len(args)
+		case arg == "-e" || arg == "--expression":
+			if i+1 >= len(args) {
+				return fmt.Errorf("sed: option requires an argument -- 'e'")
+			}
+
+			scripts = append(scripts, args[i+1])
+			i++
+		case len(arg) > 1 && arg[0] == '-':
+			for _, flag := range arg[1:] {
+				switch flag {
+				case 'n':
+					quiet = true
+				case 'i':
+					inPlace = true
+				case 'E', 'r':
+					extended = true
+				default:
+					return fmt.Errorf("sed: invalid option -- '%c'", flag)
+				}
+			}
+		default:
+			files = append(files, arg)
+		}
+	}
+	if len(scripts) == 0 {
+		if len(files) == 0 {
+			return fmt.Errorf("usage: sed [-nEi] [-e script] script [file...]")
+		}
+
+		scripts, files = []string{files[0]}, files[1:]
+	}
+
+	commands, err := parseSedScript(strings.Join(scripts, "\n"), extended)
+	if err != nil {
+		return fmt.Errorf("sed: %v", err)
+	}
+
+	if inPlace {
+		if len(files) == 0 {
+			return fmt.Errorf("sed: no input files")
+		}
+
+		for _, file := range files {
+			path := resolvePath(hc.Dir, file)
+			stat, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("sed: can't read %s: %v", file, unwrapPathError(err))
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("sed: can't read %s: %v", file, unwrapPathError(err))
+			}
+
+			out := &bytes.Buffer{}
+			runSed(out, string(content), copySedCommands(commands), quiet)
+			err = ioutil.WriteFile(path, out.Bytes(), stat.Mode().Perm())
+			if err != nil {
+				return fmt.Errorf("sed: %v", err)
+			}
+		}
+
+		return nil
+	}
+
+	input := &bytes.Buffer{}
+	if len(files) == 0 {
+		_, err = io.Copy(input, stdin(hc))
+		if err != nil {
+			return fmt.Errorf("sed: %v", err)
+		}
+	}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(resolvePath(hc.Dir, file))
+		if err != nil {
+			return fmt.Errorf("sed: can't read %s: %v", file, unwrapPathError(err))
+		}
+
+		input.Write(content)
+	}
+
+	runSed(hc.Stdout, input.String(), commands, quiet)
+	return nil
+}
+
+// runSed applies the commands to every line of the input and writes the result to out
+func runSed(out io.Writer, input string, commands []*sedCommand, quiet bool) {
+	lines := strings.SplitAfter(input, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+lines:
+	for index, line := range lines {
+		newline := strings.HasSuffix(line, "\n")
+		pattern := strings.TrimSuffix(line, "\n")
+		lineNumber := index + 1
+		last := index == len(lines)-1
+		for _, command := range commands {
+			if !command.matches(pattern, lineNumber, last) {
+				continue
+			}
+
+			switch command.name {
+			case 'd':
+				continue lines
+			case 'p':
+				writeSedLine(out, pattern, true)
+			case 'q':
+				if !quiet {
+					writeSedLine(out, pattern, newline)
+				}
+				return
+			case 's':
+				replaced, ok := command.substitute(pattern)
+				if ok {
+					pattern = replaced
+					if command.print {
+						writeSedLine(out, pattern, true)
+					}
+				}
+			}
+		}
+
+		if !quiet {
+			writeSedLine(out, pattern, newline)
+		}
+	}
+}
+
+func writeSedLine(out io.Writer, line string, newline bool) {
+	if newline {
+		line += "\n"
+	}
+
+	_, _ = out.Write([]byte(line))
+}
+
+// matches checks if the command should be applied to the line and updates the range state
+func (c *sedCommand) matches(line string, lineNumber int, last bool) bool {
+	matches := false
+	if c.from == nil {
+		matches = true
+	} else if c.to == nil {
+		matches = c.from.matches(line, lineNumber, last)
+	} else if c.active {
+		matches = true
+		if c.to.matches(line, lineNumber, last) || (c.to.re == nil && !c.to.last && lineNumber >= c.to.line) {
+			c.active = false
+		}
+	} else if c.from.matches(line, lineNumber, last) {
+		matches = true
+		// the end of a range is only checked on the following lines, except for line numbers
+		c.active = !last && (c.to.re != nil || c.to.last || c.to.line > lineNumber)
+	}
+
+	return matches != c.negate
+}
+
+func (a *sedAddress) matches(line string, lineNumber int, last bool) bool {
+	if a.re != nil {
+		return a.re.MatchString(line)
+	} else if a.last {
+		return last
+	}
+
+	return a.line == lineNumber
+}
+
+// substitute replaces the matches of the command within the line
+func (c *sedCommand) substitute(line string) (string, bool) {
+	matches := c.re.FindAllStringSubmatchIndex(line, -1)
+	if len(matches) == 0 {
+		return line, false
+	}
+
+	out := strings.Builder{}
+	position := 0
+	replaced := false
+	for index, match := range matches {
+		if !c.global && index+1 != c.occurrence {
+			continue
+		}
+
+		out.WriteString(line[position:match[0]])
+		out.WriteString(c.expand(line, match))
+		position = match[1]
+		replaced = true
+		if !c.global {
+			break
+		}
+	}
+	out.WriteString(line[position:])
+
+	return out.String(), replaced
+}
+
+// expand replaces & and \1 to \9 within the replacement with the matched text
+func (c *sedCommand) expand(line string, match []int) string {
+	out := strings.Builder{}
+	for i := 0; i < len(c.replacement); i++ {
+		char := c.replacement[i]
+		if char == '&' {
+			out.WriteString(line[match[0]:match[1]])
+		} else if char == '\\' && i+1 < len(c.replacement) {
+			i++
+			next := c.replacement[i]
+			switch {
+			case next >= '0' && next <= '9':
+				group := int(next - '0')
+				if 2*group+1 < len(match) && match[2*group] >= 0 {
+					out.WriteString(line[match[2*group]:match[2*group+1]])
+				}
+			case next == 'n':
+				out.WriteByte('\n')
+			case next == 't':
+				out.WriteByte('\t')
+			default:
+				out.WriteByte(next)
+			}
+		} else {
+			out.WriteByte(char)
+		}
+	}
+
+	return out.String()
+}
+
+func copySedCommands(commands []*sedCommand) []*sedCommand {
+	ret := []*sedCommand{}
+	for _, command := range commands {
+		c := *command
+		c.active = false
+		ret = append(ret, &c)
+	}
+
+	return ret
+}
+
+// parseSedScript parses a script with the commands s, d, p and q separated by ; or newlines
+func parseSedScript(script string, extended bool) ([]*sedCommand, error) {
+	p := &sedParser{script: script, extended: extended}
+	commands := []*sedCommand{}
+	for {
+		p.skip(" \t\n;")
+		if p.done() {
+			return commands, nil
+		}
+
+		command, err := p.parseCommand()
+		if err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, command)
+	}
+}
+
+type sedParser struct {
+	script   string
+	pos      int
+	extended bool
+}
+
+func (p *sedParser) done() bool {
+	return p.pos >= len(p.script)
+}
+
+func (p *sedParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+
+	return p.script[p.pos]
+}
+
+func (p *sedParser) skip(chars string) {
+	for !p.done() && strings.IndexByte(chars, p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *sedParser) parseCommand() (*sedCommand, error) {
+	command := &sedCommand{}
+	var err error
+	command.from, err = p.parseAddress()
+	if err != nil {
+		return nil, err
+	}
+	if command.from != nil && p.peek() == ',' {
+		p.pos++
+		command.to, err = p.parseAddress()
+		if err != nil {
+			return nil, err
+		} else if command.to == nil {
+			return nil, fmt.Errorf("unexpected `,'")
+		}
+	}
+
+	p.skip(" \t")
+	if p.peek() == '!' {
+		command.negate = true
+		p.pos++
+		p.skip(" \t")
+	}
+	if p.done() {
+		return nil, fmt.Errorf("missing command")
+	}
+
+	command.name = p.peek()
+	p.pos++
+	switch command.name {
+	case 'd', 'p', 'q':
+	case 's':
+		err = p.parseSubstitution(command)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown command: `%c'", command.name)
+	}
+
+	p.skip(" \t")
+	if !p.done() && p.peek() != ';' && p.peek() != '\n' {
+		return nil, fmt.Errorf("extra characters after command")
+	}
+
+	return command, nil
+}
+
+func (p *sedParser) parseAddress() (*sedAddress, error) {
+	switch c := p.peek(); {
+	case c == '$':
+		p.pos++
+		return &sedAddress{last: true}, nil
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+
+		line, _ := strconv.Atoi(p.script[start:p.pos])
+		if line == 0 {
+			return nil, fmt.Errorf("invalid usage of line address 0")
+		}
+
+		return &sedAddress{line: line}, nil
+	case c == '/':
+		p.pos++
+		pattern, err := p.parseDelimited('/')
+		if err != nil {
+			return nil, err
+		}
+
+		re, err := p.compile(pattern, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sedAddress{re: re}, nil
+	}
+
+	return nil, nil
+}
+
+func (p *sedParser) parseSubstitution(command *sedCommand) error {
+	if p.done() {
+		return fmt.Errorf("unterminated `s' command")
+	}
+
+	delimiter := p.peek()
+	p.pos++
+	pattern, err := p.parseDelimited(delimiter)
+	if err != nil {
+		return err
+	}
+	command.replacement, err = p.parseDelimited(delimiter)
+	if err != nil {
+		return err
+	}
+
+	ignoreCase := false
+	for !p.done() && strings.IndexByte(" \t\n;", p.peek()) < 0 {
+		switch c := p.peek(); {
+		case c == 'g':
+			command.global = true
+		case c == 'p':
+			command.print = true
+		case c == 'i' || c == 'I':
+			ignoreCase = true
+		case c >= '1' && c <= '9':
+			command.occurrence = int(c - '0')
+		default:
+			return fmt.Errorf("unknown option to `s'")
+		}
+		p.pos++
+	}
+	if command.occurrence == 0 {
+		command.occurrence = 1
+	}
+
+	command.re, err = p.compile(pattern, ignoreCase)
+	return err
+}
+
+// parseDelimited returns the text until the unescaped delimiter. Escaped delimiters are
+// unescaped, all other escape sequences are kept
+func (p *sedParser) parseDelimited(delimiter byte) (string, error) {
+	out := strings.Builder{}
+	for !p.done() {
+		c := p.peek()
+		p.pos++
+		if c == delimiter {
+			return out.String(), nil
+		} else if c == '\\' && !p.done() {
+			next := p.peek()
+			p.pos++
+			if next != delimiter {
+				out.WriteByte('\\')
+			}
+			out.WriteByte(next)
+			continue
+		}
+
+		out.WriteByte(c)
+	}
+
+	return "", fmt.Errorf("unterminated address regex or `s' command")
+}
+
+func (p *sedParser) compile(pattern string, ignoreCase bool) (*regexp.Regexp, error) {
+	if !p.extended {
+		pattern = basicToExtended(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+
+	return regexp.Compile(pattern)
+}
@@ -19,6 +19,17 @@ import (
 )
 
 func ExecuteShellCommand(command string, args []string, dir string, stdout io.Writer, stderr io.Writer, extraEnvVars map[string]string) error {
+	return ExecuteShellCommandWithContext(context.Background(), command, args, dir, stdout, stderr, extraEnvVars)
+}
+
+// ExecuteShellCommandWithContext executes the shell command and stops it when the context is cancelled
+func ExecuteShellCommandWithContext(ctx context.Context, command string, args []string, dir string, stdout io.Writer, stderr io.Writer, extraEnvVars map[string]string) error {
+	return ExecuteShellCommandWithStdin(ctx, command, args, dir, os.Stdin, stdout, stderr, extraEnvVars)
+}
+
+// ExecuteShellCommandWithStdin executes the shell command with the given input instead of the
+// input of the devspace process
+func ExecuteShellCommandWithStdin(ctx context.Context, command string, args []string, dir string, stdin io.Reader, stdout io.Writer, stderr io.Writer, extraEnvVars map[string]string) error {
 	env := os.Environ()
 	for k, v := range extraEnvVars {
 		env = append(env, k+"="+v)
@@ -39,7 +50,7 @@ func ExecuteShellCommand(command string, args []string, dir string, stdout io.Wr
 	}
 
 	// Create shell runner
-	r, err := interp.New(interp.Dir(dir), interp.StdIO(os.Stdin, stdout, stderr),
+	r, err := interp.New(interp.Dir(dir), interp.StdIO(stdin, stdout, stderr),
 		interp.Env(expand.ListEnviron(env...)),
 		interp.ExecHandler(DevSpaceExecHandler))
 	if err != nil {
@@ -48,7 +59,7 @@ func ExecuteShellCommand(command string, args []string, dir string, stdout io.Wr
 	r.Params = args
 
 	// Run command
-	err = r.Run(context.Background(), file)
+	err = r.Run(ctx, file)
 	if err != nil {
 		if status, ok := interp.IsExitStatus(err); ok && status == 0 {
 			return nil
@@ -68,14 +79,11 @@ func DevSpaceExecHandler(ctx context.Context, args []string) error {
 		hc := interp.HandlerCtx(ctx)
 		_, err := lookPathDir(hc.Dir, hc.Env, args[0])
 		if err != nil {
+			if command, ok := builtins[args[0]]; ok {
+				return executeBuiltin(ctx, &hc, command, args[1:])
+			}
+
 			switch args[0] {
-			case "cat":
-				err = cat(&hc, args[1:])
-				if err != nil {
-					_, _ = fmt.Fprintln(hc.Stderr, err)
-					return interp.NewExitStatus(1)
-				}
-				return interp.NewExitStatus(0)
 			case "kubectl":
 				path, err := downloader.NewDownloader(commands.NewKubectlCommand(), logger).EnsureCommand()
 				if err != nil {
@@ -19,6 +19,9 @@ const CommandsLinkTabSelector = (props: Props) => {
       <CustomNavLink to={`${currentPath}/commands/commands`} activeClassName={styles['selected']}>
         Commands
       </CustomNavLink>
+      <CustomNavLink to={`${currentPath}/commands/hooks`} activeClassName={styles['selected']}>
+        Hooks
+      </CustomNavLink>
     </LinkTabSelector>
   );
 };
@@ -0,0 +1,106 @@
+import React from 'react';
+import styles from './HooksList.module.scss';
+import { PortletSimple } from 'components/basic/Portlet/PortletSimple/PortletSimple';
+import LeftAlignIcon from 'images/left-alignment.svg';
+import IconButton from 'components/basic/IconButton/IconButton';
+import CodeSnippet from 'components/basic/CodeSnippet/CodeSnippet';
+import SimpleCodeLine from 'components/basic/CodeSnippet/SimpleCodeLine/SimpleCodeLine';
+import StatusIconText from 'components/basic/IconText/StatusIconText/StatusIconText';
+
+export interface HookReport {
+  name: string;
+  type: string;
+  event: string;
+  background?: boolean;
+  startTime: string;
+  duration: number;
+  attempts: number;
+  exitCode: number;
+  error?: string;
+  output?: string;
+}
+
+interface Props {
+  hooks: HookReport[];
+}
+
+interface State {
+  openHookIdx: number;
+}
+
+// formatDuration formats a duration in nanoseconds
+const formatDuration = (duration: number) => {
+  const milliseconds = Math.round(duration / 1000000);
+  if (milliseconds < 1000) {
+    return `${milliseconds}ms`;
+  }
+
+  return `${(milliseconds / 1000).toFixed(1)}s`;
+};
+
+class HooksList extends React.PureComponent<Props, State> {
+  state: State = {
+    openHookIdx: -1,
+  };
+
+  renderHooks = () => {
+    return this.props.hooks.map((hook, idx) => {
+      return (
+        <PortletSimple key={idx}>
+          {{
+            top: {
+              left: (
+                <StatusIconText status={hook.exitCode === 0 ? 'Completed' : 'Error'}>
+                  {hook.name} ({hook.type})
+                </StatusIconText>
+              ),
+              right: (
+                <React.Fragment>
+                  <span className={styles.details}>
+                    {hook.event} · {formatDuration(hook.duration)}
+                    {hook.attempts > 1 ? ` · ${hook.attempts} attempts` : ''} · exit code {hook.exitCode}
+                  </span>
+                  <IconButton
+                    filter={false}
+                    icon={LeftAlignIcon}
+                    tooltipText="Show Output"
+                    onClick={() => {
+                      this.onShowOutputClick(idx);
+                    }}
+                  />
+                </React.Fragment>
+              ),
+            },
+            content:
+              idx === this.state.openHookIdx ? (
+                <div className={styles['show-output']}>
+                  <CodeSnippet className={styles.codesnippet}>
+                    {(hook.output || '')
+                      .split('\n')
+                      .concat(hook.error ? [hook.error] : [])
+                      .map((line, lineIdx) => (
+                        <SimpleCodeLine key={lineIdx}>{line}</SimpleCodeLine>
+                      ))}
+                  </CodeSnippet>
+                </div>
+              ) : null,
+          }}
+        </PortletSimple>
+      );
+    });
+  };
+
+  onShowOutputClick = (idx: number) => {
+    this.setState({ openHookIdx: this.state.openHookIdx === idx ? -1 : idx });
+  };
+
+  render() {
+    return (
+      <div className={styles['hooks-list']}>
+        <div className={styles['hooks-list-wrapper']}>{this.renderHooks()}</div>
+      </div>
+    );
+  }
+}
+
+export default HooksList;
@@ -0,0 +1,93 @@
+import React from 'react';
+import { withRouter, RouteComponentProps } from 'react-router';
+import styles from './hooks.module.scss';
+import PageLayout from 'components/basic/PageLayout/PageLayout';
+import withPopup, { PopupContext } from 'contexts/withPopup/withPopup';
+import withDevSpaceConfig, { DevSpaceConfigContext } from 'contexts/withDevSpaceConfig/withDevSpaceConfig';
+import withWarning, { WarningContext } from 'contexts/withWarning/withWarning';
+import CommandsLinkTabSelector from 'components/basic/LinkTabSelector/CommandsLinkTabSelector/CommandsLinkTabSelector';
+import HooksList, { HookReport } from 'components/views/Commands/Hooks/HooksList/HooksList';
+import authFetch from '../../lib/fetch';
+
+interface Props extends DevSpaceConfigContext, PopupContext, WarningContext, RouteComponentProps {}
+
+interface Report {
+  command?: string[];
+  startTime?: string;
+  hooks?: HookReport[];
+}
+
+interface State {
+  report?: Report;
+}
+
+class Hooks extends React.PureComponent<Props, State> {
+  timeout: any;
+  state: State = {};
+
+  fetchHooks = async () => {
+    const response = await authFetch('/api/hooks');
+    if (response.status !== 200) {
+      throw new Error(await response.text());
+    }
+
+    const report = await response.json();
+    if (JSON.stringify(this.state.report) !== JSON.stringify(report)) {
+      this.setState({
+        report,
+      });
+    }
+  };
+
+  componentDidMount = async () => {
+    try {
+      await this.fetchHooks();
+    } catch (err) {
+      let message = err.message;
+      if (message === 'Failed to fetch') {
+        message = 'Hooks: Failed to fetch hooks. Is the UI server running?';
+      } else {
+        message = 'Hooks: ' + message;
+      }
+
+      if (!this.props.warning.getActive()) {
+        this.props.warning.show(message);
+      }
+    }
+
+    this.timeout = setTimeout(this.componentDidMount, 1500);
+  };
+
+  componentWillUnmount() {
+    clearTimeout(this.timeout);
+  }
+
+  render() {
+    const report = this.state.report;
+    return (
+      <PageLayout className={styles['hooks-component']} heading={<CommandsLinkTabSelector />}>
+        {!report || !report.hooks || report.hooks.length === 0 ? (
+          <div className={styles['no-hooks']}>
+            <div>
+              No hooks were executed yet. Take a look at&nbsp;
+              <a target="_blank" href="https://devspace.cloud/docs/cli/configuration/hooks/basics">
+                hooks
+              </a>
+              &nbsp;to add hooks to your config
+            </div>
+          </div>
+        ) : (
+          <React.Fragment>
+            <div className={styles['last-run']}>
+              Hooks executed by <code>{(report.command || []).join(' ')}</code> at{' '}
+              {new Date(report.startTime).toLocaleString()}
+            </div>
+            <HooksList hooks={report.hooks} />
+          </React.Fragment>
+        )}
+      </PageLayout>
+    );
+  }
+}
+
+export default withRouter(withPopup(withDevSpaceConfig(withWarning(Hooks))));
@@ -5,6 +5,7 @@ import LogsContainers from 'pages/logs/containers';
 import ConditionalRoute from 'components/advanced/ConditionalRoute/ConditionalRoute';
 import StackConfiguration from 'pages/stack/configuration';
 import Commands from 'pages/commands/commands';
+import Hooks from 'pages/commands/hooks';
 
 interface Props {}
 
@@ -15,6 +16,7 @@ const Routes = (_: Props) => {
         <Route exact path="/logs/containers" component={LogsContainers} />
         <Route exact path="/stack/configuration" component={StackConfiguration} />
         <Route exact path="/commands/commands" component={Commands} />
+        <Route exact path="/commands/hooks" component={Hooks} />
         <ConditionalRoute exact path="/" redirectTo="/logs/containers" when={true} component={LogsContainers} />
         <Route render={() => <h1>Page not found</h1>} />
       </Switch>